@@ -0,0 +1,334 @@
+package classfile
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+)
+
+// ============================================================
+// Annotations (Java 5+, JVMS 4.7.16 ~ 4.7.21)
+// ============================================================
+// - Runtime(In)VisibleAnnotations:          ClassFile / field / method / record component
+// - Runtime(In)VisibleParameterAnnotations: method, one annotation list per parameter
+// - Runtime(In)VisibleTypeAnnotations:      Java 8+, ClassFile / field / method / Code / record component
+//
+// Visible: @Retention(RUNTIME), can be read by reflection
+// Invisible: @Retention(CLASS), kept in class file only
+
+// AnnotationsAttribute Runtime(In)VisibleAnnotations
+type AnnotationsAttribute struct {
+	cp          ClassFileConstantPool
+	visible     bool
+	annotations []*Annotation
+}
+
+func (a *AnnotationsAttribute) readInfo(reader *ClassReader) {
+	a.annotations = readAnnotations(reader, a.cp)
+}
+
+func (a *AnnotationsAttribute) IsVisible() bool            { return a.visible }
+func (a *AnnotationsAttribute) Annotations() []*Annotation { return a.annotations }
+
+// ParameterAnnotationsAttribute Runtime(In)VisibleParameterAnnotations
+// parameterAnnotations[i]: annotations of i-th parameter
+type ParameterAnnotationsAttribute struct {
+	cp                   ClassFileConstantPool
+	visible              bool
+	parameterAnnotations [][]*Annotation
+}
+
+func (p *ParameterAnnotationsAttribute) readInfo(reader *ClassReader) {
+	numParameters := reader.readU1()
+	p.parameterAnnotations = make([][]*Annotation, numParameters)
+	for i := range p.parameterAnnotations {
+		p.parameterAnnotations[i] = readAnnotations(reader, p.cp)
+	}
+}
+
+func (p *ParameterAnnotationsAttribute) IsVisible() bool { return p.visible }
+func (p *ParameterAnnotationsAttribute) ParameterAnnotations() [][]*Annotation {
+	return p.parameterAnnotations
+}
+
+// TypeAnnotationsAttribute Runtime(In)VisibleTypeAnnotations
+type TypeAnnotationsAttribute struct {
+	cp              ClassFileConstantPool
+	visible         bool
+	typeAnnotations []*TypeAnnotation
+}
+
+func (t *TypeAnnotationsAttribute) readInfo(reader *ClassReader) {
+	numAnnotations := reader.readU2()
+	t.typeAnnotations = make([]*TypeAnnotation, numAnnotations)
+	for i := range t.typeAnnotations {
+		t.typeAnnotations[i] = readTypeAnnotation(reader, t.cp)
+	}
+}
+
+func (t *TypeAnnotationsAttribute) IsVisible() bool                    { return t.visible }
+func (t *TypeAnnotationsAttribute) TypeAnnotations() []*TypeAnnotation { return t.typeAnnotations }
+
+// findAnnotationsAttribute helper for ClassFile / MemberInfo / RecordComponentInfo accessors
+func findAnnotationsAttribute(attributes []AttributeInfo, visible bool) *AnnotationsAttribute {
+	for _, attr := range attributes {
+		if aAttr, ok := attr.(*AnnotationsAttribute); ok && aAttr.visible == visible {
+			return aAttr
+		}
+	}
+	return nil
+}
+
+func findParameterAnnotationsAttribute(attributes []AttributeInfo, visible bool) *ParameterAnnotationsAttribute {
+	for _, attr := range attributes {
+		if paAttr, ok := attr.(*ParameterAnnotationsAttribute); ok && paAttr.visible == visible {
+			return paAttr
+		}
+	}
+	return nil
+}
+
+func findTypeAnnotationsAttribute(attributes []AttributeInfo, visible bool) *TypeAnnotationsAttribute {
+	for _, attr := range attributes {
+		if taAttr, ok := attr.(*TypeAnnotationsAttribute); ok && taAttr.visible == visible {
+			return taAttr
+		}
+	}
+	return nil
+}
+
+// ============================================================
+// annotation & element_value
+// ============================================================
+
+// Annotation @Foo(name = value, ...)
+// type: field descriptor of annotation interface, ex: Ljava/lang/Deprecated;
+type Annotation struct {
+	cp                ClassFileConstantPool
+	typeIndex         uint16
+	elementValuePairs []*ElementValuePair
+}
+
+type ElementValuePair struct {
+	cp               ClassFileConstantPool
+	elementNameIndex uint16
+	value            *ElementValue
+}
+
+// ElementValue tag:
+//   - B C D F I J S Z s: constValueIndex (CONSTANT_Integer / Long / Float / Double / Utf8)
+//   - e: enum, enumTypeNameIndex + enumConstNameIndex
+//   - c: class, classInfoIndex (return descriptor, ex: Ljava/lang/String; or V)
+//   - @: nested annotation
+//   - [: array of values
+type ElementValue struct {
+	cp                 ClassFileConstantPool
+	tag                uint8
+	constValueIndex    uint16
+	enumTypeNameIndex  uint16
+	enumConstNameIndex uint16
+	classInfoIndex     uint16
+	annotation         *Annotation
+	values             []*ElementValue
+}
+
+func readAnnotations(reader *ClassReader, cp ClassFileConstantPool) []*Annotation {
+	numAnnotations := reader.readU2()
+	annotations := make([]*Annotation, numAnnotations)
+	for i := range annotations {
+		annotations[i] = readAnnotation(reader, cp)
+	}
+	return annotations
+}
+
+func readAnnotation(reader *ClassReader, cp ClassFileConstantPool) *Annotation {
+	annotation := &Annotation{cp: cp, typeIndex: reader.readU2()}
+	numPairs := reader.readU2()
+	annotation.elementValuePairs = make([]*ElementValuePair, numPairs)
+	for i := range annotation.elementValuePairs {
+		annotation.elementValuePairs[i] = &ElementValuePair{
+			cp:               cp,
+			elementNameIndex: reader.readU2(),
+			value:            readElementValue(reader, cp),
+		}
+	}
+	return annotation
+}
+
+func readElementValue(reader *ClassReader, cp ClassFileConstantPool) *ElementValue {
+	value := &ElementValue{cp: cp, tag: reader.readU1()}
+	switch value.tag {
+	case 'B', 'C', 'D', 'F', 'I', 'J', 'S', 'Z', 's':
+		value.constValueIndex = reader.readU2()
+	case 'e':
+		value.enumTypeNameIndex = reader.readU2()
+		value.enumConstNameIndex = reader.readU2()
+	case 'c':
+		value.classInfoIndex = reader.readU2()
+	case '@':
+		value.annotation = readAnnotation(reader, cp)
+	case '[':
+		numValues := reader.readU2()
+		value.values = make([]*ElementValue, numValues)
+		for i := range value.values {
+			value.values[i] = readElementValue(reader, cp)
+		}
+	default:
+		panic(common.NewJavaException("java/lang/ClassFormatError", fmt.Sprintf("invalid annotation element_value tag '%c'", value.tag)))
+	}
+	return value
+}
+
+func (a *Annotation) TypeDescriptor() string                 { return getUtf8(a.cp, a.typeIndex) }
+func (a *Annotation) ElementValuePairs() []*ElementValuePair { return a.elementValuePairs }
+func (p *ElementValuePair) Name() string                     { return getUtf8(p.cp, p.elementNameIndex) }
+func (p *ElementValuePair) Value() *ElementValue             { return p.value }
+func (v *ElementValue) Tag() uint8                           { return v.tag }
+func (v *ElementValue) ConstValueIndex() uint16              { return v.constValueIndex }
+func (v *ElementValue) Annotation() *Annotation              { return v.annotation }
+func (v *ElementValue) Values() []*ElementValue              { return v.values }
+
+// EnumValue (type descriptor, const name), ex: ("Ljava/lang/annotation/RetentionPolicy;", "RUNTIME")
+func (v *ElementValue) EnumValue() (string, string) {
+	return getUtf8(v.cp, v.enumTypeNameIndex), getUtf8(v.cp, v.enumConstNameIndex)
+}
+
+// ClassInfo return descriptor, ex: Ljava/lang/String; (String.class), V (void.class)
+func (v *ElementValue) ClassInfo() string {
+	return getUtf8(v.cp, v.classInfoIndex)
+}
+
+// ============================================================
+// type_annotation (Java 8+, JVMS 4.7.20)
+// ============================================================
+// @NonNull String s  /  List<@NonNull String>  /  (@NonNull Object) x ...
+// targetType decide which field of TypeAnnotationTarget is used (target_info union).
+
+// target_type
+const (
+	TARGET_CLASS_TYPE_PARAMETER            uint8 = 0x00
+	TARGET_METHOD_TYPE_PARAMETER           uint8 = 0x01
+	TARGET_CLASS_EXTENDS                   uint8 = 0x10
+	TARGET_CLASS_TYPE_PARAMETER_BOUND      uint8 = 0x11
+	TARGET_METHOD_TYPE_PARAMETER_BOUND     uint8 = 0x12
+	TARGET_FIELD                           uint8 = 0x13
+	TARGET_METHOD_RETURN                   uint8 = 0x14
+	TARGET_METHOD_RECEIVER                 uint8 = 0x15
+	TARGET_METHOD_FORMAL_PARAMETER         uint8 = 0x16
+	TARGET_THROWS                          uint8 = 0x17
+	TARGET_LOCAL_VARIABLE                  uint8 = 0x40
+	TARGET_RESOURCE_VARIABLE               uint8 = 0x41
+	TARGET_EXCEPTION_PARAMETER             uint8 = 0x42
+	TARGET_INSTANCEOF                      uint8 = 0x43
+	TARGET_NEW                             uint8 = 0x44
+	TARGET_CONSTRUCTOR_REFERENCE           uint8 = 0x45
+	TARGET_METHOD_REFERENCE                uint8 = 0x46
+	TARGET_CAST                            uint8 = 0x47
+	TARGET_CONSTRUCTOR_INVOCATION_TYPE_ARG uint8 = 0x48
+	TARGET_METHOD_INVOCATION_TYPE_ARG      uint8 = 0x49
+	TARGET_CONSTRUCTOR_REFERENCE_TYPE_ARG  uint8 = 0x4A
+	TARGET_METHOD_REFERENCE_TYPE_ARG       uint8 = 0x4B
+)
+
+// TypeAnnotation annotation + where it is (target) + path into the type (typePath)
+type TypeAnnotation struct {
+	targetType uint8
+	target     *TypeAnnotationTarget
+	typePath   []*TypePathEntry
+	annotation *Annotation
+}
+
+// TypeAnnotationTarget target_info union, only fields of targetType are set:
+//   - type_parameter_target:       TypeParameterIndex
+//   - supertype_target:            SupertypeIndex (0xFFFF = superclass, others = interface index)
+//   - type_parameter_bound_target: TypeParameterIndex, BoundIndex
+//   - empty_target:                (none)
+//   - formal_parameter_target:     FormalParameterIndex
+//   - throws_target:               ThrowsTypeIndex
+//   - localvar_target:             LocalVarTable
+//   - catch_target:                ExceptionTableIndex
+//   - offset_target:               Offset
+//   - type_argument_target:        Offset, TypeArgumentIndex
+type TypeAnnotationTarget struct {
+	TypeParameterIndex   uint8
+	SupertypeIndex       uint16
+	BoundIndex           uint8
+	FormalParameterIndex uint8
+	ThrowsTypeIndex      uint16
+	LocalVarTable        []*LocalVarTargetEntry
+	ExceptionTableIndex  uint16
+	Offset               uint16
+	TypeArgumentIndex    uint8
+}
+
+// LocalVarTargetEntry local variable live in code [StartPc, StartPc + Length) at LocalVars[Index]
+type LocalVarTargetEntry struct {
+	StartPc uint16
+	Length  uint16
+	Index   uint16
+}
+
+// TypePathEntry
+// kind: 0 = deeper in array, 1 = deeper in nested type, 2 = wildcard bound, 3 = type argument (argIndex)
+type TypePathEntry struct {
+	Kind     uint8
+	ArgIndex uint8
+}
+
+func readTypeAnnotation(reader *ClassReader, cp ClassFileConstantPool) *TypeAnnotation {
+	ta := &TypeAnnotation{targetType: reader.readU1()}
+	ta.target = readTypeAnnotationTarget(reader, ta.targetType)
+
+	pathLength := reader.readU1()
+	ta.typePath = make([]*TypePathEntry, pathLength)
+	for i := range ta.typePath {
+		ta.typePath[i] = &TypePathEntry{Kind: reader.readU1(), ArgIndex: reader.readU1()}
+	}
+
+	ta.annotation = readAnnotation(reader, cp)
+	return ta
+}
+
+func readTypeAnnotationTarget(reader *ClassReader, targetType uint8) *TypeAnnotationTarget {
+	target := &TypeAnnotationTarget{}
+	switch targetType {
+	case TARGET_CLASS_TYPE_PARAMETER, TARGET_METHOD_TYPE_PARAMETER:
+		target.TypeParameterIndex = reader.readU1()
+	case TARGET_CLASS_EXTENDS:
+		target.SupertypeIndex = reader.readU2()
+	case TARGET_CLASS_TYPE_PARAMETER_BOUND, TARGET_METHOD_TYPE_PARAMETER_BOUND:
+		target.TypeParameterIndex = reader.readU1()
+		target.BoundIndex = reader.readU1()
+	case TARGET_FIELD, TARGET_METHOD_RETURN, TARGET_METHOD_RECEIVER:
+		// empty_target
+	case TARGET_METHOD_FORMAL_PARAMETER:
+		target.FormalParameterIndex = reader.readU1()
+	case TARGET_THROWS:
+		target.ThrowsTypeIndex = reader.readU2()
+	case TARGET_LOCAL_VARIABLE, TARGET_RESOURCE_VARIABLE:
+		tableLength := reader.readU2()
+		target.LocalVarTable = make([]*LocalVarTargetEntry, tableLength)
+		for i := range target.LocalVarTable {
+			target.LocalVarTable[i] = &LocalVarTargetEntry{
+				StartPc: reader.readU2(),
+				Length:  reader.readU2(),
+				Index:   reader.readU2(),
+			}
+		}
+	case TARGET_EXCEPTION_PARAMETER:
+		target.ExceptionTableIndex = reader.readU2()
+	case TARGET_INSTANCEOF, TARGET_NEW, TARGET_CONSTRUCTOR_REFERENCE, TARGET_METHOD_REFERENCE:
+		target.Offset = reader.readU2()
+	case TARGET_CAST, TARGET_CONSTRUCTOR_INVOCATION_TYPE_ARG, TARGET_METHOD_INVOCATION_TYPE_ARG,
+		TARGET_CONSTRUCTOR_REFERENCE_TYPE_ARG, TARGET_METHOD_REFERENCE_TYPE_ARG:
+		target.Offset = reader.readU2()
+		target.TypeArgumentIndex = reader.readU1()
+	default:
+		panic(common.NewJavaException("java/lang/ClassFormatError", fmt.Sprintf("invalid type annotation target_type 0x%02X", targetType)))
+	}
+	return target
+}
+
+func (t *TypeAnnotation) TargetType() uint8             { return t.targetType }
+func (t *TypeAnnotation) Target() *TypeAnnotationTarget { return t.target }
+func (t *TypeAnnotation) TypePath() []*TypePathEntry    { return t.typePath }
+func (t *TypeAnnotation) Annotation() *Annotation       { return t.annotation }
@@ -0,0 +1,164 @@
+package classfile
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+)
+
+// ============================================================
+// StackMapTable (Java 6+, Code attribute, JVMS 4.7.4)
+// ============================================================
+// type of LocalVars & OperandStack at each branch target / exception handler,
+// used by type-checking verifier (no need to infer types by data-flow analysis).
+//
+// frames are delta compressed:
+//   - first frame: offset = offsetDelta, based on initial frame (from method descriptor)
+//   - next frame:  offset = prevOffset + offsetDelta + 1, based on previous frame
+//
+// frame_type:
+//
+//	0 ~ 63    SAME                                  same locals, empty stack, offsetDelta = frame_type
+//	64 ~ 127  SAME_LOCALS_1_STACK_ITEM              same locals, 1 stack item, offsetDelta = frame_type - 64
+//	128 ~ 246 reserved
+//	247       SAME_LOCALS_1_STACK_ITEM_EXTENDED     same as above, explicit offsetDelta
+//	248 ~ 250 CHOP                                  remove last (251 - frame_type) locals, empty stack
+//	251       SAME_FRAME_EXTENDED                   same as SAME, explicit offsetDelta
+//	252 ~ 254 APPEND                                add (frame_type - 251) locals, empty stack
+//	255       FULL_FRAME                            all locals & stack items
+
+type StackMapFrameKind uint8
+
+const (
+	SAME_FRAME StackMapFrameKind = iota
+	SAME_LOCALS_1_STACK_ITEM_FRAME
+	CHOP_FRAME
+	APPEND_FRAME
+	FULL_FRAME
+)
+
+// verification_type_info tag
+const (
+	ITEM_Top               uint8 = 0
+	ITEM_Integer           uint8 = 1
+	ITEM_Float             uint8 = 2
+	ITEM_Double            uint8 = 3
+	ITEM_Long              uint8 = 4
+	ITEM_Null              uint8 = 5
+	ITEM_UninitializedThis uint8 = 6
+	ITEM_Object            uint8 = 7
+	ITEM_Uninitialized     uint8 = 8
+)
+
+// StackMapTableAttribute
+type StackMapTableAttribute struct {
+	cp      ClassFileConstantPool
+	entries []*StackMapFrame
+}
+
+// StackMapFrame one entry of StackMapTable
+//   - chopCount: CHOP only, number of removed locals
+//   - locals:    APPEND (added locals) / FULL_FRAME (all locals)
+//   - stack:     SAME_LOCALS_1_STACK_ITEM (1 item) / FULL_FRAME (all items)
+//
+// long & double take 1 verification type (not 2) in locals and stack
+type StackMapFrame struct {
+	frameType   uint8
+	kind        StackMapFrameKind
+	offsetDelta uint16
+	chopCount   int
+	locals      []*VerificationTypeInfo
+	stack       []*VerificationTypeInfo
+}
+
+// VerificationTypeInfo
+//   - ITEM_Object:        cpoolIndex pointing to CONSTANT_Class
+//   - ITEM_Uninitialized: offset of the `new` instruction which create this object
+type VerificationTypeInfo struct {
+	cp         ClassFileConstantPool
+	tag        uint8
+	cpoolIndex uint16
+	offset     uint16
+}
+
+func (s *StackMapTableAttribute) readInfo(reader *ClassReader) {
+	numberOfEntries := reader.readU2()
+	s.entries = make([]*StackMapFrame, numberOfEntries)
+	for i := range s.entries {
+		s.entries[i] = readStackMapFrame(reader, s.cp)
+	}
+}
+
+func (s *StackMapTableAttribute) Entries() []*StackMapFrame {
+	return s.entries
+}
+
+func readStackMapFrame(reader *ClassReader, cp ClassFileConstantPool) *StackMapFrame {
+	frameType := reader.readU1()
+	frame := &StackMapFrame{frameType: frameType}
+
+	switch {
+	case frameType <= 63:
+		frame.kind = SAME_FRAME
+		frame.offsetDelta = uint16(frameType)
+	case frameType <= 127:
+		frame.kind = SAME_LOCALS_1_STACK_ITEM_FRAME
+		frame.offsetDelta = uint16(frameType - 64)
+		frame.stack = []*VerificationTypeInfo{readVerificationTypeInfo(reader, cp)}
+	case frameType <= 246:
+		panic(common.NewJavaException("java/lang/ClassFormatError", fmt.Sprintf("reserved StackMapTable frame type %d", frameType)))
+	case frameType == 247:
+		frame.kind = SAME_LOCALS_1_STACK_ITEM_FRAME
+		frame.offsetDelta = reader.readU2()
+		frame.stack = []*VerificationTypeInfo{readVerificationTypeInfo(reader, cp)}
+	case frameType <= 250:
+		frame.kind = CHOP_FRAME
+		frame.offsetDelta = reader.readU2()
+		frame.chopCount = int(251 - frameType)
+	case frameType == 251:
+		frame.kind = SAME_FRAME
+		frame.offsetDelta = reader.readU2()
+	case frameType <= 254:
+		frame.kind = APPEND_FRAME
+		frame.offsetDelta = reader.readU2()
+		frame.locals = readVerificationTypeInfos(reader, cp, int(frameType-251))
+	default: // 255
+		frame.kind = FULL_FRAME
+		frame.offsetDelta = reader.readU2()
+		frame.locals = readVerificationTypeInfos(reader, cp, int(reader.readU2()))
+		frame.stack = readVerificationTypeInfos(reader, cp, int(reader.readU2()))
+	}
+	return frame
+}
+
+func readVerificationTypeInfos(reader *ClassReader, cp ClassFileConstantPool, count int) []*VerificationTypeInfo {
+	infos := make([]*VerificationTypeInfo, count)
+	for i := range infos {
+		infos[i] = readVerificationTypeInfo(reader, cp)
+	}
+	return infos
+}
+
+func readVerificationTypeInfo(reader *ClassReader, cp ClassFileConstantPool) *VerificationTypeInfo {
+	info := &VerificationTypeInfo{cp: cp, tag: reader.readU1()}
+	switch info.tag {
+	case ITEM_Object:
+		info.cpoolIndex = reader.readU2()
+	case ITEM_Uninitialized:
+		info.offset = reader.readU2()
+	case ITEM_Top, ITEM_Integer, ITEM_Float, ITEM_Double, ITEM_Long, ITEM_Null, ITEM_UninitializedThis:
+	default:
+		panic(common.NewJavaException("java/lang/ClassFormatError", fmt.Sprintf("invalid verification type tag %d in StackMapTable", info.tag)))
+	}
+	return info
+}
+
+func (f *StackMapFrame) FrameType() uint8                { return f.frameType }
+func (f *StackMapFrame) Kind() StackMapFrameKind         { return f.kind }
+func (f *StackMapFrame) OffsetDelta() uint16             { return f.offsetDelta }
+func (f *StackMapFrame) ChopCount() int                  { return f.chopCount }
+func (f *StackMapFrame) Locals() []*VerificationTypeInfo { return f.locals }
+func (f *StackMapFrame) Stack() []*VerificationTypeInfo  { return f.stack }
+func (v *VerificationTypeInfo) Tag() uint8               { return v.tag }
+func (v *VerificationTypeInfo) Offset() uint16           { return v.offset }
+func (v *VerificationTypeInfo) ClassName() string        { return v.cp.getClassName(v.cpoolIndex) }
+func (v *VerificationTypeInfo) IsCategory2() bool        { return v.tag == ITEM_Long || v.tag == ITEM_Double }
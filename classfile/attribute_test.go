@@ -0,0 +1,190 @@
+package classfile
+
+import (
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testClassPath = "../test/class"
+
+// parseFixture parse test/class/<name>.class
+func parseFixture(t *testing.T, name string) *ClassFile {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(testClassPath, name+".class"))
+	if err != nil {
+		t.Skip("test classes not found")
+	}
+	cf, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cf
+}
+
+func findMember(members []*MemberInfo, name string) *MemberInfo {
+	for _, member := range members {
+		if member.Name() == name {
+			return member
+		}
+	}
+	return nil
+}
+
+func stackMapFrames(t *testing.T, cf *ClassFile, method string) []*StackMapFrame {
+	t.Helper()
+	attr := findMember(cf.Methods(), method).CodeAttribute().StackMapTableAttribute()
+	if !assert.NotNil(t, attr, method) {
+		t.FailNow()
+	}
+	return attr.Entries()
+}
+
+func Test_StackMapTable(t *testing.T) {
+	// for (int i = 1; i <= 100; i++) sum += i;  → append [int int], chop 2
+	frames := stackMapFrames(t, parseFixture(t, "TestLoopSum"), "main")
+	if assert.Len(t, frames, 2) {
+		assert.Equal(t, APPEND_FRAME, frames[0].Kind())
+		assert.Equal(t, uint16(4), frames[0].OffsetDelta())
+		if assert.Len(t, frames[0].Locals(), 2) {
+			assert.Equal(t, ITEM_Integer, frames[0].Locals()[0].Tag())
+		}
+		assert.Equal(t, CHOP_FRAME, frames[1].Kind())
+		assert.Equal(t, 1, frames[1].ChopCount())
+	}
+
+	// catch handler: same locals, 1 stack item (exception)
+	frames = stackMapFrames(t, parseFixture(t, "TestFinally"), "main")
+	if assert.NotEmpty(t, frames) {
+		assert.Equal(t, SAME_LOCALS_1_STACK_ITEM_FRAME, frames[0].Kind())
+		assert.Equal(t, uint16(29), frames[0].OffsetDelta())
+		if assert.Len(t, frames[0].Stack(), 1) {
+			assert.Equal(t, ITEM_Object, frames[0].Stack()[0].Tag())
+		}
+	}
+
+	// if (n <= 1) return n;  → same
+	frames = stackMapFrames(t, parseFixture(t, "Fibonacci"), "fib")
+	if assert.Len(t, frames, 1) {
+		assert.Equal(t, SAME_FRAME, frames[0].Kind())
+		assert.Equal(t, uint16(7), frames[0].OffsetDelta())
+	}
+
+	// lookupswitch targets: full frame [int int]
+	frames = stackMapFrames(t, parseFixture(t, "TestSwitch"), "sparse")
+	if assert.Len(t, frames, 4) {
+		assert.Equal(t, FULL_FRAME, frames[0].Kind())
+		assert.Equal(t, uint16(36), frames[0].OffsetDelta())
+		assert.Len(t, frames[0].Locals(), 2)
+		assert.Empty(t, frames[0].Stack())
+		assert.Equal(t, uint16(41-36-1), frames[1].OffsetDelta())
+	}
+}
+
+func Test_Annotations(t *testing.T) {
+	cf := parseFixture(t, "TestAnnotation")
+	tag := "LTestAnnotation$Tag;"
+
+	// class: @Tag(value = "class", kinds = {CLASS, RUNTIME})
+	annotations := cf.AnnotationsAttribute(true).Annotations()
+	if assert.Len(t, annotations, 1) {
+		assert.Equal(t, tag, annotations[0].TypeDescriptor())
+		pairs := annotations[0].ElementValuePairs()
+		if assert.Len(t, pairs, 2) {
+			assert.Equal(t, "value", pairs[0].Name())
+			assert.Equal(t, uint8('s'), pairs[0].Value().Tag())
+			assert.Equal(t, "class", getUtf8(cf.ConstantPool(), pairs[0].Value().ConstValueIndex()))
+
+			assert.Equal(t, "kinds", pairs[1].Name())
+			kinds := pairs[1].Value().Values()
+			if assert.Len(t, kinds, 2) {
+				typeName, constName := kinds[1].EnumValue()
+				assert.Equal(t, "Ljava/lang/annotation/RetentionPolicy;", typeName)
+				assert.Equal(t, "RUNTIME", constName)
+			}
+		}
+	}
+	assert.Nil(t, cf.AnnotationsAttribute(false))
+
+	// field: @Tag(value = "field", level = 2)
+	fieldAnnotations := findMember(cf.Fields(), "counter").AnnotationsAttribute(true).Annotations()
+	if assert.Len(t, fieldAnnotations, 1) && assert.Len(t, fieldAnnotations[0].ElementValuePairs(), 2) {
+		level := fieldAnnotations[0].ElementValuePairs()[1].Value()
+		assert.Equal(t, uint8('I'), level.Tag())
+		assert.Equal(t, int32(2), cf.ConstantPool()[level.ConstValueIndex()].(*ConstantIntegerInfo).Value())
+	}
+
+	// method: @Deprecated @Tag("method"), parameter: @Tag("param")
+	read := findMember(cf.Methods(), "read")
+	assert.True(t, read.IsDeprecated())
+	methodAnnotations := read.AnnotationsAttribute(true).Annotations()
+	if assert.Len(t, methodAnnotations, 2) {
+		assert.Equal(t, "Ljava/lang/Deprecated;", methodAnnotations[0].TypeDescriptor())
+		assert.Equal(t, tag, methodAnnotations[1].TypeDescriptor())
+	}
+	parameters := read.ParameterAnnotationsAttribute(true).ParameterAnnotations()
+	if assert.Len(t, parameters, 1) && assert.Len(t, parameters[0], 1) {
+		assert.Equal(t, tag, parameters[0][0].TypeDescriptor())
+	}
+
+	// annotation interface: @Retention(RUNTIME), default value kept as unparsed AnnotationDefault
+	tagClass := parseFixture(t, "TestAnnotation$Tag")
+	assert.Equal(t, []string{"java/lang/annotation/Annotation"}, tagClass.InterfaceNames())
+	retention := tagClass.AnnotationsAttribute(true).Annotations()
+	if assert.Len(t, retention, 1) {
+		_, constName := retention[0].ElementValuePairs()[0].Value().EnumValue()
+		assert.Equal(t, "RUNTIME", constName)
+	}
+}
+
+func Test_Nestmates_Record(t *testing.T) {
+	outer := parseFixture(t, "TestModernClassFile")
+	assert.Equal(t, uint16(65), outer.MajorVersion())
+	assert.Nil(t, outer.NestHostAttribute())
+	assert.ElementsMatch(t, []string{
+		"TestModernClassFile$Inner", "TestModernClassFile$Point", "TestModernClassFile$Shape",
+		"TestModernClassFile$Circle", "TestModernClassFile$Square",
+	}, outer.NestMembersAttribute().ClassNames())
+
+	inner := parseFixture(t, "TestModernClassFile$Inner")
+	assert.Equal(t, "TestModernClassFile", inner.NestHostAttribute().HostClassName())
+	assert.Nil(t, inner.NestMembersAttribute())
+
+	point := parseFixture(t, "TestModernClassFile$Point")
+	assert.Equal(t, "java/lang/Record", point.SuperClassName())
+	components := point.RecordAttribute().Components()
+	if assert.Len(t, components, 2) {
+		assert.Equal(t, "x", components[0].Name())
+		assert.Equal(t, "y", components[1].Name())
+		assert.Equal(t, "I", components[1].Descriptor())
+	}
+	// toString / hashCode / equals → ObjectMethods.bootstrap
+	assert.Len(t, point.BootstrapMethodsAttribute().BootstrapMethods(), 1)
+
+	shape := parseFixture(t, "TestModernClassFile$Shape")
+	assert.Equal(t, []string{"TestModernClassFile$Circle", "TestModernClassFile$Square"},
+		shape.PermittedSubclassesAttribute().ClassNames())
+}
+
+// attribute_length 與實際解析的長度不同 → ClassFormatError
+func Test_readAttribute_lengthMismatch(t *testing.T) {
+	cp := ClassFileConstantPool{nil, &ConstantUtf8Info{str: "SourceFile"}, &ConstantUtf8Info{str: "Foo.java"}}
+	read := func(data []byte) (attr AttributeInfo, ex *common.JavaException) {
+		defer func() {
+			ex, _ = recover().(*common.JavaException)
+		}()
+		return readAttribute(&ClassReader{data: data, majorVersion: 52}, cp), nil
+	}
+
+	// attribute_name_index, attribute_length = 2, sourcefile_index
+	attr, ex := read([]byte{0, 1, 0, 0, 0, 2, 0, 2})
+	assert.Nil(t, ex)
+	assert.Equal(t, "Foo.java", attr.(*SourceFileAttribute).FileName())
+
+	_, ex = read([]byte{0, 1, 0, 0, 0, 4, 0, 2, 0, 0})
+	if assert.NotNil(t, ex) {
+		assert.Equal(t, "java/lang/ClassFormatError", ex.ClassName)
+	}
+}
@@ -27,11 +27,16 @@ type ClassFile struct {
 func Parse(classData []byte) (cf *ClassFile, err error) {
 	defer func() { // prevent panic error happened, convert to error.
 		if r := recover(); r != nil {
+			// java error (ex: UnsupportedClassVersionError) keep as it is, loader throw it directly
+			if javaErr, ok := r.(*common.JavaException); ok {
+				err = javaErr
+				return
+			}
 			err = fmt.Errorf("parse class file error: %v", r)
 		}
 	}()
 
-	cr := &ClassReader{classData}
+	cr := &ClassReader{data: classData}
 	cf = &ClassFile{}
 	cf.read(cr)
 	return
@@ -56,7 +61,7 @@ func (cf *ClassFile) read(reader *ClassReader) {
 func (cf *ClassFile) readAndCheckMagic(reader *ClassReader) {
 	fileMagic := reader.readU4() // magic number take 4 bytes
 	if fileMagic != HOLY_MAGIC {
-		panic("java.lang.ClassFormatError: Invalid magic number")
+		panic(common.NewJavaException("java/lang/ClassFormatError", "Invalid magic number"))
 	}
 	cf.magic = fileMagic
 }
@@ -67,15 +72,14 @@ func (cf *ClassFile) readAndCheckVersion(reader *ClassReader) {
 	cf.minorVersion = reader.readU2()
 	cf.majorVersion = reader.readU2()
 
-	// gogo-jvm support from Java 1.0 ~ Java 8 (version no 45-52)
-	// java 8 is 52.0, java 7 is 51.0 ...
-	switch cf.majorVersion {
-	case 45, 46, 47, 48, 49, 50, 51, 52, 53:
-		return
+	// gogo-jvm support from Java 1.1 ~ Java 21 (version no 45-65)
+	// java 21 is 65.0, java 17 is 61.0, java 8 is 52.0 ... (see class_version.go)
+	if errMsg := checkClassVersion(cf.majorVersion, cf.minorVersion); errMsg != "" {
+		panic(common.NewJavaException("java/lang/UnsupportedClassVersionError", errMsg))
 	}
 
-	panic(fmt.Sprintf("java.lang.UnsupportedClassVersionError: %d.%d",
-		cf.majorVersion, cf.minorVersion))
+	// constants pool and attributes parsing are gated by version
+	reader.majorVersion = cf.majorVersion
 }
 
 // ====================================================================
@@ -133,6 +137,11 @@ func (cf *ClassFile) IsAbstract() bool {
 	return cf.accessFlags&common.ACC_ABSTRACT != 0
 }
 
+// IsModule module-info.class (Java 9+), not a class can be loaded
+func (cf *ClassFile) IsModule() bool {
+	return cf.accessFlags&common.ACC_MODULE != 0
+}
+
 // SourceFileAttribute
 func (cf *ClassFile) SourceFileAttribute() *SourceFileAttribute {
 	for _, attr := range cf.attributes {
@@ -143,6 +152,124 @@ func (cf *ClassFile) SourceFileAttribute() *SourceFileAttribute {
 	return nil
 }
 
+// BootstrapMethodsAttribute (nil if class has no invokedynamic)
+func (cf *ClassFile) BootstrapMethodsAttribute() *BootstrapMethodsAttribute {
+	for _, attr := range cf.attributes {
+		if bmAttr, ok := attr.(*BootstrapMethodsAttribute); ok {
+			return bmAttr
+		}
+	}
+	return nil
+}
+
+// Attributes all ClassFile attributes (including UnparsedAttribute)
+func (cf *ClassFile) Attributes() []AttributeInfo {
+	return cf.attributes
+}
+
+// InnerClassesAttribute (nil if class has no nested class reference)
+func (cf *ClassFile) InnerClassesAttribute() *InnerClassesAttribute {
+	for _, attr := range cf.attributes {
+		if icAttr, ok := attr.(*InnerClassesAttribute); ok {
+			return icAttr
+		}
+	}
+	return nil
+}
+
+// EnclosingMethodAttribute (Java 5+, nil if class is not a local / anonymous class)
+func (cf *ClassFile) EnclosingMethodAttribute() *EnclosingMethodAttribute {
+	for _, attr := range cf.attributes {
+		if emAttr, ok := attr.(*EnclosingMethodAttribute); ok {
+			return emAttr
+		}
+	}
+	return nil
+}
+
+// SignatureAttribute (Java 5+, nil if class is not generic)
+func (cf *ClassFile) SignatureAttribute() *SignatureAttribute {
+	for _, attr := range cf.attributes {
+		if sigAttr, ok := attr.(*SignatureAttribute); ok {
+			return sigAttr
+		}
+	}
+	return nil
+}
+
+// IsDeprecated has Deprecated attribute
+func (cf *ClassFile) IsDeprecated() bool {
+	for _, attr := range cf.attributes {
+		if _, ok := attr.(*DeprecatedAttribute); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSynthetic ACC_SYNTHETIC or Synthetic attribute (before Java 5)
+func (cf *ClassFile) IsSynthetic() bool {
+	if cf.accessFlags&common.ACC_SYNTHETIC != 0 {
+		return true
+	}
+	for _, attr := range cf.attributes {
+		if _, ok := attr.(*SyntheticAttribute); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AnnotationsAttribute RuntimeVisibleAnnotations (visible = true) or RuntimeInvisibleAnnotations
+func (cf *ClassFile) AnnotationsAttribute(visible bool) *AnnotationsAttribute {
+	return findAnnotationsAttribute(cf.attributes, visible)
+}
+
+// TypeAnnotationsAttribute RuntimeVisibleTypeAnnotations (visible = true) or RuntimeInvisibleTypeAnnotations
+func (cf *ClassFile) TypeAnnotationsAttribute(visible bool) *TypeAnnotationsAttribute {
+	return findTypeAnnotationsAttribute(cf.attributes, visible)
+}
+
+// NestHostAttribute (Java 11+, nil if class is not a nest member)
+func (cf *ClassFile) NestHostAttribute() *NestHostAttribute {
+	for _, attr := range cf.attributes {
+		if nhAttr, ok := attr.(*NestHostAttribute); ok {
+			return nhAttr
+		}
+	}
+	return nil
+}
+
+// NestMembersAttribute (Java 11+, nil if class is not a nest host)
+func (cf *ClassFile) NestMembersAttribute() *NestMembersAttribute {
+	for _, attr := range cf.attributes {
+		if nmAttr, ok := attr.(*NestMembersAttribute); ok {
+			return nmAttr
+		}
+	}
+	return nil
+}
+
+// RecordAttribute (Java 16+, nil if class is not a record)
+func (cf *ClassFile) RecordAttribute() *RecordAttribute {
+	for _, attr := range cf.attributes {
+		if rAttr, ok := attr.(*RecordAttribute); ok {
+			return rAttr
+		}
+	}
+	return nil
+}
+
+// PermittedSubclassesAttribute (Java 17+, nil if class is not sealed)
+func (cf *ClassFile) PermittedSubclassesAttribute() *PermittedSubclassesAttribute {
+	for _, attr := range cf.attributes {
+		if psAttr, ok := attr.(*PermittedSubclassesAttribute); ok {
+			return psAttr
+		}
+	}
+	return nil
+}
+
 // MajorVersion 主版本號
 func (cf *ClassFile) MajorVersion() uint16 {
 	return cf.majorVersion
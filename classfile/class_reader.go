@@ -8,7 +8,8 @@ import (
 // Class file is binary data, including different length of data type.
 // ClassReader provider interface to read those data
 type ClassReader struct {
-	data []byte
+	data         []byte
+	majorVersion uint16 // set after version is read, for feature gating (constant tags, attributes)
 }
 
 // readU1 read a un-sign byte (u1 in JVM spec)
@@ -0,0 +1,100 @@
+package classfile
+
+import "fmt"
+
+// ============================================================
+// Class File Version (JVMS 4.1)
+// ============================================================
+// major version -> Java SE release:
+//
+//	45 = 1.1, 49 = 5, 50 = 6, 51 = 7, 52 = 8, 53 = 9, 55 = 11, 60 = 16, 61 = 17, 65 = 21
+//
+// gogo-jvm accept 45 ~ 65, new features are gated by version:
+// a class file is only allowed to use features introduced in (or before) its own version,
+// attributes / constants from a newer version are ignored (attribute) or rejected (constant tag).
+
+const (
+	JAVA_1_1 uint16 = 45
+	JAVA_5   uint16 = 49
+	JAVA_6   uint16 = 50
+	JAVA_7   uint16 = 51
+	JAVA_8   uint16 = 52
+	JAVA_9   uint16 = 53
+	JAVA_11  uint16 = 55
+	JAVA_12  uint16 = 56
+	JAVA_16  uint16 = 60
+	JAVA_17  uint16 = 61
+	JAVA_21  uint16 = 65
+
+	MIN_SUPPORTED_MAJOR_VERSION uint16 = JAVA_1_1
+	MAX_SUPPORTED_MAJOR_VERSION uint16 = JAVA_21
+
+	// PREVIEW_MINOR_VERSION class compiled with --enable-preview (Java 12+)
+	PREVIEW_MINOR_VERSION uint16 = 0xFFFF
+)
+
+// first major version of each feature
+const (
+	FEATURE_INVOKEDYNAMIC    = JAVA_7  // CONSTANT_MethodHandle / MethodType / InvokeDynamic, BootstrapMethods
+	FEATURE_MODULES          = JAVA_9  // CONSTANT_Module / Package, ACC_MODULE
+	FEATURE_NESTMATES        = JAVA_11 // NestHost / NestMembers
+	FEATURE_DYNAMIC_CONSTANT = JAVA_11 // CONSTANT_Dynamic
+	FEATURE_PREVIEW          = JAVA_12 // minor version 0xFFFF
+	FEATURE_RECORDS          = JAVA_16 // Record
+	FEATURE_SEALED           = JAVA_17 // PermittedSubclasses
+)
+
+// checkClassVersion return UnsupportedClassVersionError message, empty if supported
+func checkClassVersion(major, minor uint16) string {
+	if major < MIN_SUPPORTED_MAJOR_VERSION || major > MAX_SUPPORTED_MAJOR_VERSION {
+		return fmt.Sprintf("class file version %d.%d, "+
+			"gogo-jvm only recognizes class file versions %d.0 ~ %d.0",
+			major, minor, MIN_SUPPORTED_MAJOR_VERSION, MAX_SUPPORTED_MAJOR_VERSION)
+	}
+
+	// before Java 12 any minor version is fine (45.3 is Java 1.0.2 ...)
+	if major < FEATURE_PREVIEW {
+		return ""
+	}
+
+	switch minor {
+	case 0:
+		return ""
+	case PREVIEW_MINOR_VERSION:
+		// preview features belong to one release only, they may change (or be removed) in next release.
+		// we can only run preview features of the newest version we support.
+		if major != MAX_SUPPORTED_MAJOR_VERSION {
+			return fmt.Sprintf("class file version %d.%d uses preview features of Java %d, "+
+				"gogo-jvm only supports preview features of class file version %d",
+				major, minor, javaRelease(major), MAX_SUPPORTED_MAJOR_VERSION)
+		}
+		return ""
+	default:
+		// JVMS 4.1: major >= 56, minor must be 0 or 65535
+		return fmt.Sprintf("class file version %d.%d has invalid minor version (must be 0 or %d)",
+			major, minor, PREVIEW_MINOR_VERSION)
+	}
+}
+
+// javaRelease major version -> Java SE release number (52 -> 8, 65 -> 21)
+func javaRelease(major uint16) int {
+	if major <= JAVA_1_1 {
+		return 1
+	}
+	return int(major) - 44
+}
+
+// supportsFeature class file version >= feature version
+func supportsFeature(major, feature uint16) bool {
+	return major >= feature
+}
+
+// IsPreview class compiled with --enable-preview
+func (cf *ClassFile) IsPreview() bool {
+	return cf.majorVersion >= FEATURE_PREVIEW && cf.minorVersion == PREVIEW_MINOR_VERSION
+}
+
+// SupportsFeature check class file version, ex: cf.SupportsFeature(FEATURE_NESTMATES)
+func (cf *ClassFile) SupportsFeature(feature uint16) bool {
+	return supportsFeature(cf.majorVersion, feature)
+}
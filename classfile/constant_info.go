@@ -2,6 +2,7 @@ package classfile
 
 import (
 	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
 	"math"
 )
 
@@ -16,14 +17,18 @@ type ConstantInfo interface {
 func readConstantInfo(reader *ClassReader, cp ClassFileConstantPool) ConstantInfo {
 	tagUint8 := reader.readU1()
 	if tag, err := uint8ToTag(tagUint8); err == nil {
+		if reader.majorVersion < tag.minMajorVersion() {
+			panic(common.NewJavaException("java/lang/ClassFormatError", fmt.Sprintf("constants pool tag %d requires class file version %d, but is %d",
+				tag, tag.minMajorVersion(), reader.majorVersion)))
+		}
 		info := newConstantInfo(tag, cp)
 		if info == nil {
-			panic(fmt.Sprintf("java.lang.ClassFormatError: constants pool tag %d", tag))
+			panic(common.NewJavaException("java/lang/ClassFormatError", fmt.Sprintf("constants pool tag %d", tag)))
 		}
 		info.readInfo(reader)
 		return info
 	} else {
-		panic("java.lang.ClassFormatError: " + err.Error())
+		panic(common.NewJavaException("java/lang/ClassFormatError", err.Error()))
 	}
 }
 
@@ -51,8 +56,19 @@ func newConstantInfo(tag ConstantTag, cp ClassFileConstantPool) ConstantInfo {
 		return &ConstantInterfaceMethodRefInfo{ConstantMemberRefInfo{cp: cp}}
 	case CONSTANT_NameAndType:
 		return &ConstantNameAndTypeInfo{}
+	case CONSTANT_MethodHandle:
+		return &ConstantMethodHandleInfo{cp: cp}
+	case CONSTANT_MethodType:
+		return &ConstantMethodTypeInfo{cp: cp}
+	case CONSTANT_InvokeDynamic:
+		return &ConstantInvokeDynamicInfo{cp: cp}
+	case CONSTANT_Dynamic:
+		return &ConstantDynamicInfo{ConstantInvokeDynamicInfo{cp: cp}}
+	case CONSTANT_Module:
+		return &ConstantModuleInfo{cp: cp}
+	case CONSTANT_Package:
+		return &ConstantPackageInfo{cp: cp}
 	default:
-		// TODO: MVP Phase ignore: MethodHandle, MethodType, InvokeDynamic
 		return nil
 	}
 }
@@ -301,3 +317,173 @@ func (c *ConstantNameAndTypeInfo) NameIndex() uint16 {
 func (c *ConstantNameAndTypeInfo) DescriptorIndex() uint16 {
 	return c.descriptorIndex
 }
+
+// ============================================================
+// invokedynamic related constants (Java 7+)
+// ============================================================
+
+// MethodHandle reference_kind (JVMS §5.4.3.5)
+const (
+	REF_getField         uint8 = 1
+	REF_getStatic        uint8 = 2
+	REF_putField         uint8 = 3
+	REF_putStatic        uint8 = 4
+	REF_invokeVirtual    uint8 = 5
+	REF_invokeStatic     uint8 = 6
+	REF_invokeSpecial    uint8 = 7
+	REF_newInvokeSpecial uint8 = 8
+	REF_invokeInterface  uint8 = 9
+)
+
+// ConstantMethodHandleInfo method handle constants
+// referenceIndex pointing to Fieldref (kind 1~4), Methodref / InterfaceMethodref (kind 5~9)
+type ConstantMethodHandleInfo struct {
+	cp             ClassFileConstantPool
+	referenceKind  uint8
+	referenceIndex uint16
+}
+
+func (c *ConstantMethodHandleInfo) readInfo(reader *ClassReader) {
+	c.referenceKind = reader.readU1()
+	c.referenceIndex = reader.readU2()
+}
+
+func (c *ConstantMethodHandleInfo) String() string {
+	return fmt.Sprintf("MethodHandle: kind=%d, reference=%d", c.referenceKind, c.referenceIndex)
+}
+
+func (c *ConstantMethodHandleInfo) Tag() ConstantTag {
+	return CONSTANT_MethodHandle
+}
+
+func (c *ConstantMethodHandleInfo) ReferenceKind() uint8 {
+	return c.referenceKind
+}
+
+func (c *ConstantMethodHandleInfo) ReferenceIndex() uint16 {
+	return c.referenceIndex
+}
+
+// MemberRef resolve referenced field / method symbol
+func (c *ConstantMethodHandleInfo) MemberRef() (className, name, descriptor string) {
+	return c.cp.getMemberRef(int(c.referenceIndex))
+}
+
+// IsInterfaceRef referenced member is a InterfaceMethodref
+func (c *ConstantMethodHandleInfo) IsInterfaceRef() bool {
+	_, ok := c.cp[c.referenceIndex].(*ConstantInterfaceMethodRefInfo)
+	return ok
+}
+
+// ConstantMethodTypeInfo method type constants, ex: (Ljava/lang/Object;)Z
+type ConstantMethodTypeInfo struct {
+	cp              ClassFileConstantPool
+	descriptorIndex uint16
+}
+
+func (c *ConstantMethodTypeInfo) readInfo(reader *ClassReader) {
+	c.descriptorIndex = reader.readU2()
+}
+
+func (c *ConstantMethodTypeInfo) String() string {
+	return c.Descriptor()
+}
+
+func (c *ConstantMethodTypeInfo) Tag() ConstantTag {
+	return CONSTANT_MethodType
+}
+
+func (c *ConstantMethodTypeInfo) Descriptor() string {
+	return getUtf8(c.cp, c.descriptorIndex)
+}
+
+// ConstantInvokeDynamicInfo invokedynamic call site constants
+// bootstrapMethodAttrIndex is index of ClassFile's BootstrapMethods attribute (not constant pool)
+type ConstantInvokeDynamicInfo struct {
+	cp                       ClassFileConstantPool
+	bootstrapMethodAttrIndex uint16
+	nameAndTypeIndex         uint16
+}
+
+func (c *ConstantInvokeDynamicInfo) readInfo(reader *ClassReader) {
+	c.bootstrapMethodAttrIndex = reader.readU2()
+	c.nameAndTypeIndex = reader.readU2()
+}
+
+func (c *ConstantInvokeDynamicInfo) String() string {
+	return fmt.Sprintf("InvokeDynamic: bootstrap=%d, nameAndType=%d", c.bootstrapMethodAttrIndex, c.nameAndTypeIndex)
+}
+
+func (c *ConstantInvokeDynamicInfo) Tag() ConstantTag {
+	return CONSTANT_InvokeDynamic
+}
+
+func (c *ConstantInvokeDynamicInfo) BootstrapMethodAttrIndex() uint16 {
+	return c.bootstrapMethodAttrIndex
+}
+
+func (c *ConstantInvokeDynamicInfo) NameAndDescriptor() (string, string) {
+	return c.cp.getNameAndType(c.nameAndTypeIndex)
+}
+
+// ConstantDynamicInfo dynamically-computed constant (Java 11+, condy), same layout as InvokeDynamic
+// value is computed by bootstrap method when `ldc`
+type ConstantDynamicInfo struct {
+	ConstantInvokeDynamicInfo
+}
+
+func (c *ConstantDynamicInfo) String() string {
+	return fmt.Sprintf("Dynamic: bootstrap=%d, nameAndType=%d", c.bootstrapMethodAttrIndex, c.nameAndTypeIndex)
+}
+
+func (c *ConstantDynamicInfo) Tag() ConstantTag {
+	return CONSTANT_Dynamic
+}
+
+// ============================================================
+// module related constants (Java 9+, only in module-info.class)
+// ============================================================
+
+// ConstantModuleInfo module name, ex: java.base
+type ConstantModuleInfo struct {
+	cp        ClassFileConstantPool
+	nameIndex uint16
+}
+
+func (c *ConstantModuleInfo) readInfo(reader *ClassReader) {
+	c.nameIndex = reader.readU2()
+}
+
+func (c *ConstantModuleInfo) String() string {
+	return c.Name()
+}
+
+func (c *ConstantModuleInfo) Tag() ConstantTag {
+	return CONSTANT_Module
+}
+
+func (c *ConstantModuleInfo) Name() string {
+	return getUtf8(c.cp, c.nameIndex)
+}
+
+// ConstantPackageInfo package name (internal form), ex: java/lang
+type ConstantPackageInfo struct {
+	cp        ClassFileConstantPool
+	nameIndex uint16
+}
+
+func (c *ConstantPackageInfo) readInfo(reader *ClassReader) {
+	c.nameIndex = reader.readU2()
+}
+
+func (c *ConstantPackageInfo) String() string {
+	return c.Name()
+}
+
+func (c *ConstantPackageInfo) Tag() ConstantTag {
+	return CONSTANT_Package
+}
+
+func (c *ConstantPackageInfo) Name() string {
+	return getUtf8(c.cp, c.nameIndex)
+}
@@ -20,7 +20,10 @@ const (
 	CONSTANT_NameAndType        ConstantTag = 12
 	CONSTANT_MethodHandle       ConstantTag = 15
 	CONSTANT_MethodType         ConstantTag = 16
+	CONSTANT_Dynamic            ConstantTag = 17 // Java 11+
 	CONSTANT_InvokeDynamic      ConstantTag = 18
+	CONSTANT_Module             ConstantTag = 19 // Java 9+, module-info only
+	CONSTANT_Package            ConstantTag = 20 // Java 9+, module-info only
 )
 
 func uint8ToTag(input uint8) (ConstantTag, error) {
@@ -51,9 +54,29 @@ func uint8ToTag(input uint8) (ConstantTag, error) {
 		return CONSTANT_MethodHandle, nil
 	case 16:
 		return CONSTANT_MethodType, nil
+	case 17:
+		return CONSTANT_Dynamic, nil
 	case 18:
 		return CONSTANT_InvokeDynamic, nil
+	case 19:
+		return CONSTANT_Module, nil
+	case 20:
+		return CONSTANT_Package, nil
 	default:
 		return 0, fmt.Errorf("unknown tag: %d", input)
 	}
 }
+
+// minMajorVersion first class file version which allow this tag (JVMS 4.4 Table 4.4-B)
+func (tag ConstantTag) minMajorVersion() uint16 {
+	switch tag {
+	case CONSTANT_MethodHandle, CONSTANT_MethodType, CONSTANT_InvokeDynamic:
+		return FEATURE_INVOKEDYNAMIC
+	case CONSTANT_Module, CONSTANT_Package:
+		return FEATURE_MODULES
+	case CONSTANT_Dynamic:
+		return FEATURE_DYNAMIC_CONSTANT
+	default:
+		return MIN_SUPPORTED_MAJOR_VERSION
+	}
+}
@@ -1,5 +1,10 @@
 package classfile
 
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+)
+
 // AttributeInfo attribute interface
 // Attribute is a extendable java in a class, using for store extra info
 // like: method's bytecode, SourceFileName...
@@ -20,7 +25,7 @@ func readAttribute(reader *ClassReader, cp ClassFileConstantPool) AttributeInfo
 	attrName := getUtf8(cp, attrNameIndex)
 	attrLength := reader.readU4()
 
-	attrInfo := newAttributeInfo(attrName, attrLength, cp)
+	attrInfo := newAttributeInfo(attrName, attrLength, cp, reader.majorVersion)
 	if attrInfo == nil {
 		attrInfo = &UnparsedAttribute{
 			name:   attrName,
@@ -28,33 +33,107 @@ func readAttribute(reader *ClassReader, cp ClassFileConstantPool) AttributeInfo
 			info:   reader.readBytes(attrLength),
 		}
 	} else {
+		start := reader.remaining()
 		attrInfo.(interface {
 			readInfo(*ClassReader)
 		}).readInfo(reader)
+		// parsed attribute must consume exactly attribute_length bytes (JVMS 4.7)
+		if read := start - reader.remaining(); uint32(read) != attrLength {
+			panic(common.NewJavaException("java/lang/ClassFormatError",
+				fmt.Sprintf("Invalid %s attribute length: attribute_length is %d, but %d bytes are read", attrName, attrLength, read)))
+		}
 	}
 
 	return attrInfo
 }
 
-func newAttributeInfo(attrName string, attrLength uint32, cp ClassFileConstantPool) AttributeInfo {
+// newAttributeInfo
+// attributes introduced by newer version are ignored (kept as UnparsedAttribute) in older class file (JVMS 4.7)
+func newAttributeInfo(attrName string, attrLength uint32, cp ClassFileConstantPool, majorVersion uint16) AttributeInfo {
+	if majorVersion < attributeMinMajorVersion(attrName) {
+		return nil
+	}
+
 	switch attrName {
 	case "Code":
 		return &CodeAttribute{cp: cp}
 	case "ConstantValue":
 		return &ConstantValueAttribute{}
 	case "Exceptions":
-		return &ExceptionsAttribute{}
+		return &ExceptionsAttribute{cp: cp}
 	case "SourceFile":
 		return &SourceFileAttribute{cp: cp}
 	case "LineNumberTable":
 		return &LineNumberTableAttribute{}
 	case "LocalVariableTable":
-		return &LocalVariableTableAttribute{}
+		return &LocalVariableTableAttribute{cp: cp}
+	case "LocalVariableTypeTable":
+		return &LocalVariableTypeTableAttribute{cp: cp}
+	case "StackMapTable":
+		return &StackMapTableAttribute{cp: cp}
+	case "BootstrapMethods":
+		return &BootstrapMethodsAttribute{}
+	case "InnerClasses":
+		return &InnerClassesAttribute{cp: cp}
+	case "EnclosingMethod":
+		return &EnclosingMethodAttribute{cp: cp}
+	case "Signature":
+		return &SignatureAttribute{cp: cp}
+	case "Deprecated":
+		return &DeprecatedAttribute{}
+	case "Synthetic":
+		return &SyntheticAttribute{}
+	case "MethodParameters":
+		return &MethodParametersAttribute{cp: cp}
+	case "RuntimeVisibleAnnotations":
+		return &AnnotationsAttribute{cp: cp, visible: true}
+	case "RuntimeInvisibleAnnotations":
+		return &AnnotationsAttribute{cp: cp, visible: false}
+	case "RuntimeVisibleParameterAnnotations":
+		return &ParameterAnnotationsAttribute{cp: cp, visible: true}
+	case "RuntimeInvisibleParameterAnnotations":
+		return &ParameterAnnotationsAttribute{cp: cp, visible: false}
+	case "RuntimeVisibleTypeAnnotations":
+		return &TypeAnnotationsAttribute{cp: cp, visible: true}
+	case "RuntimeInvisibleTypeAnnotations":
+		return &TypeAnnotationsAttribute{cp: cp, visible: false}
+	case "NestHost":
+		return &NestHostAttribute{cp: cp}
+	case "NestMembers":
+		return &NestMembersAttribute{cp: cp}
+	case "Record":
+		return &RecordAttribute{cp: cp}
+	case "PermittedSubclasses":
+		return &PermittedSubclassesAttribute{cp: cp}
 	default:
 		return nil
 	}
 }
 
+// attributeMinMajorVersion first class file version which define this attribute (JVMS Table 4.7-B)
+func attributeMinMajorVersion(attrName string) uint16 {
+	switch attrName {
+	case "Signature", "EnclosingMethod", "LocalVariableTypeTable",
+		"RuntimeVisibleAnnotations", "RuntimeInvisibleAnnotations",
+		"RuntimeVisibleParameterAnnotations", "RuntimeInvisibleParameterAnnotations":
+		return JAVA_5
+	case "StackMapTable":
+		return JAVA_6
+	case "BootstrapMethods":
+		return FEATURE_INVOKEDYNAMIC
+	case "MethodParameters", "RuntimeVisibleTypeAnnotations", "RuntimeInvisibleTypeAnnotations":
+		return JAVA_8
+	case "NestHost", "NestMembers":
+		return FEATURE_NESTMATES
+	case "Record":
+		return FEATURE_RECORDS
+	case "PermittedSubclasses":
+		return FEATURE_SEALED
+	default:
+		return MIN_SUPPORTED_MAJOR_VERSION
+	}
+}
+
 // UnparsedAttribute
 type UnparsedAttribute struct {
 	name   string
@@ -85,6 +164,53 @@ func (c *CodeAttribute) MaxStack() uint16                    { return c.maxStack
 func (c *CodeAttribute) MaxLocals() uint16                   { return c.maxLocals }
 func (c *CodeAttribute) Code() []byte                        { return c.code }
 func (c *CodeAttribute) ExceptionTable() []*ExceptionHandler { return c.exceptionTable }
+func (c *CodeAttribute) Attributes() []AttributeInfo         { return c.attributes }
+
+// LineNumberTableAttribute (nil if compiled without -g:lines)
+func (c *CodeAttribute) LineNumberTableAttribute() *LineNumberTableAttribute {
+	for _, attr := range c.attributes {
+		if lntAttr, ok := attr.(*LineNumberTableAttribute); ok {
+			return lntAttr
+		}
+	}
+	return nil
+}
+
+// LocalVariableTableAttribute (nil if compiled without -g:vars)
+func (c *CodeAttribute) LocalVariableTableAttribute() *LocalVariableTableAttribute {
+	for _, attr := range c.attributes {
+		if lvtAttr, ok := attr.(*LocalVariableTableAttribute); ok {
+			return lvtAttr
+		}
+	}
+	return nil
+}
+
+// LocalVariableTypeTableAttribute (nil if no generic local variable)
+func (c *CodeAttribute) LocalVariableTypeTableAttribute() *LocalVariableTypeTableAttribute {
+	for _, attr := range c.attributes {
+		if lvttAttr, ok := attr.(*LocalVariableTypeTableAttribute); ok {
+			return lvttAttr
+		}
+	}
+	return nil
+}
+
+// StackMapTableAttribute (Java 6+, nil if method has no branch)
+func (c *CodeAttribute) StackMapTableAttribute() *StackMapTableAttribute {
+	for _, attr := range c.attributes {
+		if smtAttr, ok := attr.(*StackMapTableAttribute); ok {
+			return smtAttr
+		}
+	}
+	return nil
+}
+
+// TypeAnnotationsAttribute RuntimeVisibleTypeAnnotations (visible = true) or RuntimeInvisibleTypeAnnotations
+// in Code: annotations on local variable, new, cast, catch ...
+func (c *CodeAttribute) TypeAnnotationsAttribute(visible bool) *TypeAnnotationsAttribute {
+	return findTypeAnnotationsAttribute(c.attributes, visible)
+}
 
 type ExceptionHandler struct {
 	startPc   uint16
@@ -136,8 +262,9 @@ func (c *ConstantValueAttribute) ConstantValueIndex() uint16 {
 	return c.constantValueIndex
 }
 
-// ExceptionsAttribute
+// ExceptionsAttribute (Method attribute) `throws` clause
 type ExceptionsAttribute struct {
+	cp                   ClassFileConstantPool
 	exceptionsIndexTable []uint16
 }
 
@@ -145,6 +272,15 @@ func (e *ExceptionsAttribute) readInfo(reader *ClassReader) {
 	e.exceptionsIndexTable = reader.readU2Table()
 }
 
+func (e *ExceptionsAttribute) ExceptionIndexTable() []uint16 {
+	return e.exceptionsIndexTable
+}
+
+// ExceptionClassNames ex: [java/io/IOException]
+func (e *ExceptionsAttribute) ExceptionClassNames() []string {
+	return classNames(e.cp, e.exceptionsIndexTable)
+}
+
 // SourceFileAttribute
 type SourceFileAttribute struct {
 	cp              ClassFileConstantPool
@@ -180,12 +316,24 @@ func (l *LineNumberTableAttribute) readInfo(reader *ClassReader) {
 	}
 }
 
+func (l *LineNumberTableAttribute) LineNumberTable() []*LineNumberTableEntry {
+	return l.lineNumberTable
+}
+
+func (e *LineNumberTableEntry) StartPc() uint16    { return e.startPc }
+func (e *LineNumberTableEntry) LineNumber() uint16 { return e.lineNumber }
+
 // LocalVariableTableAttribute
 type LocalVariableTableAttribute struct {
+	cp                 ClassFileConstantPool
 	localVariableTable []*LocalVariableTableEntry
 }
 
+// LocalVariableTableEntry
+// variable `name` is valid in code [startPc, startPc + length), stored in LocalVars[index]
+// descriptor: field descriptor (LocalVariableTable) or field signature (LocalVariableTypeTable)
 type LocalVariableTableEntry struct {
+	cp              ClassFileConstantPool
 	startPc         uint16
 	length          uint16
 	nameIndex       uint16
@@ -194,10 +342,34 @@ type LocalVariableTableEntry struct {
 }
 
 func (l *LocalVariableTableAttribute) readInfo(reader *ClassReader) {
+	l.localVariableTable = readLocalVariableTable(reader, l.cp)
+}
+
+func (l *LocalVariableTableAttribute) LocalVariableTable() []*LocalVariableTableEntry {
+	return l.localVariableTable
+}
+
+// LocalVariableTypeTableAttribute (Java 5+) same layout as LocalVariableTable,
+// only for generic variable, descriptor is signature (ex: Ljava/util/List<Ljava/lang/String;>;)
+type LocalVariableTypeTableAttribute struct {
+	cp                     ClassFileConstantPool
+	localVariableTypeTable []*LocalVariableTableEntry
+}
+
+func (l *LocalVariableTypeTableAttribute) readInfo(reader *ClassReader) {
+	l.localVariableTypeTable = readLocalVariableTable(reader, l.cp)
+}
+
+func (l *LocalVariableTypeTableAttribute) LocalVariableTypeTable() []*LocalVariableTableEntry {
+	return l.localVariableTypeTable
+}
+
+func readLocalVariableTable(reader *ClassReader, cp ClassFileConstantPool) []*LocalVariableTableEntry {
 	localVarTableLength := reader.readU2()
-	l.localVariableTable = make([]*LocalVariableTableEntry, localVarTableLength)
-	for i := range l.localVariableTable {
-		l.localVariableTable[i] = &LocalVariableTableEntry{
+	localVariableTable := make([]*LocalVariableTableEntry, localVarTableLength)
+	for i := range localVariableTable {
+		localVariableTable[i] = &LocalVariableTableEntry{
+			cp:              cp,
 			startPc:         reader.readU2(),
 			length:          reader.readU2(),
 			nameIndex:       reader.readU2(),
@@ -205,4 +377,336 @@ func (l *LocalVariableTableAttribute) readInfo(reader *ClassReader) {
 			index:           reader.readU2(),
 		}
 	}
+	return localVariableTable
+}
+
+func (e *LocalVariableTableEntry) StartPc() uint16 { return e.startPc }
+func (e *LocalVariableTableEntry) Length() uint16  { return e.length }
+func (e *LocalVariableTableEntry) Index() uint16   { return e.index }
+func (e *LocalVariableTableEntry) Name() string    { return getUtf8(e.cp, e.nameIndex) }
+
+// Descriptor descriptor in LocalVariableTable, signature in LocalVariableTypeTable
+func (e *LocalVariableTableEntry) Descriptor() string { return getUtf8(e.cp, e.descriptorIndex) }
+
+// BootstrapMethodsAttribute (Java 7+, ClassFile attribute)
+// every CONSTANT_InvokeDynamic pointing to one of them by bootstrapMethodAttrIndex
+type BootstrapMethodsAttribute struct {
+	bootstrapMethods []*BootstrapMethod
+}
+
+// BootstrapMethod
+// - methodRef: CONSTANT_MethodHandle index, ex: LambdaMetafactory.metafactory
+// - arguments: static args (MethodType, MethodHandle, String, Integer ...) constant pool index
+type BootstrapMethod struct {
+	methodRef uint16
+	arguments []uint16
+}
+
+func (b *BootstrapMethodsAttribute) readInfo(reader *ClassReader) {
+	numBootstrapMethods := reader.readU2()
+	b.bootstrapMethods = make([]*BootstrapMethod, numBootstrapMethods)
+	for i := range b.bootstrapMethods {
+		b.bootstrapMethods[i] = &BootstrapMethod{
+			methodRef: reader.readU2(),
+			arguments: reader.readU2Table(),
+		}
+	}
+}
+
+func (b *BootstrapMethodsAttribute) BootstrapMethods() []*BootstrapMethod {
+	return b.bootstrapMethods
+}
+
+func (bm *BootstrapMethod) MethodRef() uint16 {
+	return bm.methodRef
+}
+
+func (bm *BootstrapMethod) Arguments() []uint16 {
+	return bm.arguments
+}
+
+// ============================================================
+// class structure attributes (inner class, generic signature, flags)
+// ============================================================
+
+// InnerClassesAttribute (ClassFile attribute)
+// every nested class referenced by this class (member, local, anonymous)
+type InnerClassesAttribute struct {
+	cp      ClassFileConstantPool
+	classes []*InnerClassInfo
+}
+
+// InnerClassInfo
+// ex: class Outer { static class Inner {} } -> inner: Outer$Inner, outer: Outer, name: Inner
+// outer is empty for local / anonymous class, name is empty for anonymous class
+type InnerClassInfo struct {
+	cp                    ClassFileConstantPool
+	innerClassInfoIndex   uint16
+	outerClassInfoIndex   uint16
+	innerNameIndex        uint16
+	innerClassAccessFlags uint16
+}
+
+func (i *InnerClassesAttribute) readInfo(reader *ClassReader) {
+	numberOfClasses := reader.readU2()
+	i.classes = make([]*InnerClassInfo, numberOfClasses)
+	for idx := range i.classes {
+		i.classes[idx] = &InnerClassInfo{
+			cp:                    i.cp,
+			innerClassInfoIndex:   reader.readU2(),
+			outerClassInfoIndex:   reader.readU2(),
+			innerNameIndex:        reader.readU2(),
+			innerClassAccessFlags: reader.readU2(),
+		}
+	}
+}
+
+func (i *InnerClassesAttribute) Classes() []*InnerClassInfo {
+	return i.classes
+}
+
+func (ic *InnerClassInfo) InnerClassName() string {
+	return ic.cp.getClassName(ic.innerClassInfoIndex)
+}
+
+func (ic *InnerClassInfo) OuterClassName() string {
+	if ic.outerClassInfoIndex == 0 {
+		return ""
+	}
+	return ic.cp.getClassName(ic.outerClassInfoIndex)
+}
+
+func (ic *InnerClassInfo) InnerName() string {
+	if ic.innerNameIndex == 0 {
+		return ""
+	}
+	return getUtf8(ic.cp, ic.innerNameIndex)
+}
+
+func (ic *InnerClassInfo) AccessFlags() uint16 {
+	return ic.innerClassAccessFlags
+}
+
+// EnclosingMethodAttribute (Java 5+, ClassFile attribute) for local / anonymous class
+// method is empty if class is not enclosed by a method (ex: in field initializer)
+type EnclosingMethodAttribute struct {
+	cp          ClassFileConstantPool
+	classIndex  uint16
+	methodIndex uint16
+}
+
+func (e *EnclosingMethodAttribute) readInfo(reader *ClassReader) {
+	e.classIndex = reader.readU2()
+	e.methodIndex = reader.readU2()
+}
+
+func (e *EnclosingMethodAttribute) ClassName() string {
+	return e.cp.getClassName(e.classIndex)
+}
+
+// MethodNameAndDescriptor ("", "") if not enclosed by a method
+func (e *EnclosingMethodAttribute) MethodNameAndDescriptor() (string, string) {
+	if e.methodIndex == 0 {
+		return "", ""
+	}
+	return e.cp.getNameAndType(e.methodIndex)
+}
+
+// SignatureAttribute (Java 5+, ClassFile / field / method / record component attribute)
+// generic signature, ex: List<String> field -> Ljava/util/List<Ljava/lang/String;>;
+type SignatureAttribute struct {
+	cp             ClassFileConstantPool
+	signatureIndex uint16
+}
+
+func (s *SignatureAttribute) readInfo(reader *ClassReader) {
+	s.signatureIndex = reader.readU2()
+}
+
+func (s *SignatureAttribute) Signature() string {
+	return getUtf8(s.cp, s.signatureIndex)
+}
+
+// DeprecatedAttribute marker attribute (@deprecated javadoc tag), no content
+type DeprecatedAttribute struct{}
+
+func (d *DeprecatedAttribute) readInfo(reader *ClassReader) {}
+
+// SyntheticAttribute marker attribute (member generated by compiler), no content
+// Java 5+ use ACC_SYNTHETIC instead
+type SyntheticAttribute struct{}
+
+func (s *SyntheticAttribute) readInfo(reader *ClassReader) {}
+
+// MethodParametersAttribute (Java 8+, method attribute, javac -parameters)
+type MethodParametersAttribute struct {
+	cp         ClassFileConstantPool
+	parameters []*MethodParameterInfo
+}
+
+// MethodParameterInfo name is empty if parameter has no name (nameIndex = 0)
+// accessFlags: ACC_FINAL, ACC_SYNTHETIC, ACC_MANDATED
+type MethodParameterInfo struct {
+	cp          ClassFileConstantPool
+	nameIndex   uint16
+	accessFlags uint16
+}
+
+func (m *MethodParametersAttribute) readInfo(reader *ClassReader) {
+	parametersCount := reader.readU1()
+	m.parameters = make([]*MethodParameterInfo, parametersCount)
+	for i := range m.parameters {
+		m.parameters[i] = &MethodParameterInfo{
+			cp:          m.cp,
+			nameIndex:   reader.readU2(),
+			accessFlags: reader.readU2(),
+		}
+	}
+}
+
+func (m *MethodParametersAttribute) Parameters() []*MethodParameterInfo {
+	return m.parameters
+}
+
+func (p *MethodParameterInfo) Name() string {
+	if p.nameIndex == 0 {
+		return ""
+	}
+	return getUtf8(p.cp, p.nameIndex)
+}
+
+func (p *MethodParameterInfo) AccessFlags() uint16 {
+	return p.accessFlags
+}
+
+// ============================================================
+// Java 11+ nestmates
+// ============================================================
+// inner class and outer class are in the same nest, they can access each other's private members
+// without synthetic accessor (access$000).
+// - nest host:   NestMembers attribute list all members
+// - nest member: NestHost attribute pointing to host
+
+// NestHostAttribute (ClassFile attribute)
+type NestHostAttribute struct {
+	cp             ClassFileConstantPool
+	hostClassIndex uint16
+}
+
+func (n *NestHostAttribute) readInfo(reader *ClassReader) {
+	n.hostClassIndex = reader.readU2()
+}
+
+func (n *NestHostAttribute) HostClassName() string {
+	return n.cp.getClassName(n.hostClassIndex)
+}
+
+// NestMembersAttribute (ClassFile attribute)
+type NestMembersAttribute struct {
+	cp      ClassFileConstantPool
+	classes []uint16
+}
+
+func (n *NestMembersAttribute) readInfo(reader *ClassReader) {
+	n.classes = reader.readU2Table()
+}
+
+func (n *NestMembersAttribute) ClassNames() []string {
+	return classNames(n.cp, n.classes)
+}
+
+// ============================================================
+// Java 16+ records
+// ============================================================
+
+// RecordAttribute (ClassFile attribute)
+// record Point(int x, int y) -> components: [x:I, y:I]
+type RecordAttribute struct {
+	cp         ClassFileConstantPool
+	components []*RecordComponentInfo
+}
+
+// RecordComponentInfo one record component, attributes: Signature, annotations ...
+type RecordComponentInfo struct {
+	cp              ClassFileConstantPool
+	nameIndex       uint16
+	descriptorIndex uint16
+	attributes      []AttributeInfo
+}
+
+func (r *RecordAttribute) readInfo(reader *ClassReader) {
+	componentsCount := reader.readU2()
+	r.components = make([]*RecordComponentInfo, componentsCount)
+	for i := range r.components {
+		r.components[i] = &RecordComponentInfo{
+			cp:              r.cp,
+			nameIndex:       reader.readU2(),
+			descriptorIndex: reader.readU2(),
+			attributes:      readAttributes(reader, r.cp),
+		}
+	}
+}
+
+func (r *RecordAttribute) Components() []*RecordComponentInfo {
+	return r.components
+}
+
+func (rc *RecordComponentInfo) Name() string {
+	return getUtf8(rc.cp, rc.nameIndex)
+}
+
+func (rc *RecordComponentInfo) Descriptor() string {
+	return getUtf8(rc.cp, rc.descriptorIndex)
+}
+
+func (rc *RecordComponentInfo) Attributes() []AttributeInfo {
+	return rc.attributes
+}
+
+// SignatureAttribute (nil if component type is not generic)
+func (rc *RecordComponentInfo) SignatureAttribute() *SignatureAttribute {
+	for _, attr := range rc.attributes {
+		if sigAttr, ok := attr.(*SignatureAttribute); ok {
+			return sigAttr
+		}
+	}
+	return nil
+}
+
+// AnnotationsAttribute RuntimeVisibleAnnotations (visible = true) or RuntimeInvisibleAnnotations
+func (rc *RecordComponentInfo) AnnotationsAttribute(visible bool) *AnnotationsAttribute {
+	return findAnnotationsAttribute(rc.attributes, visible)
+}
+
+// TypeAnnotationsAttribute RuntimeVisibleTypeAnnotations (visible = true) or RuntimeInvisibleTypeAnnotations
+func (rc *RecordComponentInfo) TypeAnnotationsAttribute(visible bool) *TypeAnnotationsAttribute {
+	return findTypeAnnotationsAttribute(rc.attributes, visible)
+}
+
+// ============================================================
+// Java 17+ sealed classes
+// ============================================================
+
+// PermittedSubclassesAttribute (ClassFile attribute)
+// sealed class Shape permits Circle, Square -> [Circle, Square]
+type PermittedSubclassesAttribute struct {
+	cp      ClassFileConstantPool
+	classes []uint16
+}
+
+func (p *PermittedSubclassesAttribute) readInfo(reader *ClassReader) {
+	p.classes = reader.readU2Table()
+}
+
+func (p *PermittedSubclassesAttribute) ClassNames() []string {
+	return classNames(p.cp, p.classes)
+}
+
+// classNames CONSTANT_Class indexes -> class names
+func classNames(cp ClassFileConstantPool, classIndexes []uint16) []string {
+	names := make([]string, len(classIndexes))
+	for i, classIndex := range classIndexes {
+		names[i] = cp.getClassName(classIndex)
+	}
+	return names
 }
@@ -13,7 +13,7 @@ type MemberInfo struct {
 	accessFlags     uint16
 	nameIndex       uint16
 	descriptorIndex uint16
-	attributes      []AttributeInfo // could contains: Code, ConstantValue, Exceptions, SourceFile, LineNumberTable, LocalVariableTable
+	attributes      []AttributeInfo // could contains: Code, ConstantValue, Exceptions, Signature, MethodParameters, annotations ...
 }
 
 func readMembers(reader *ClassReader, cp ClassFileConstantPool) []*MemberInfo {
@@ -88,8 +88,17 @@ func (m *MemberInfo) IsAbstract() bool {
 	return m.accessFlags&common.ACC_ABSTRACT != 0
 }
 
+// IsSynthetic ACC_SYNTHETIC or Synthetic attribute (before Java 5)
 func (m *MemberInfo) IsSynthetic() bool {
-	return m.accessFlags&common.ACC_SYNTHETIC != 0
+	if m.accessFlags&common.ACC_SYNTHETIC != 0 {
+		return true
+	}
+	for _, attr := range m.attributes {
+		if _, ok := attr.(*SyntheticAttribute); ok {
+			return true
+		}
+	}
+	return false
 }
 
 func (m *MemberInfo) IsEnum() bool {
@@ -116,6 +125,66 @@ func (m *MemberInfo) ConstantValueAttribute() *ConstantValueAttribute {
 	return nil
 }
 
+// Attributes all member attributes (including UnparsedAttribute)
+func (m *MemberInfo) Attributes() []AttributeInfo {
+	return m.attributes
+}
+
+// ExceptionsAttribute method's `throws` clause (nil if no throws)
+func (m *MemberInfo) ExceptionsAttribute() *ExceptionsAttribute {
+	for _, attr := range m.attributes {
+		if exAttr, ok := attr.(*ExceptionsAttribute); ok {
+			return exAttr
+		}
+	}
+	return nil
+}
+
+// SignatureAttribute (Java 5+, nil if field type / method is not generic)
+func (m *MemberInfo) SignatureAttribute() *SignatureAttribute {
+	for _, attr := range m.attributes {
+		if sigAttr, ok := attr.(*SignatureAttribute); ok {
+			return sigAttr
+		}
+	}
+	return nil
+}
+
+// MethodParametersAttribute (Java 8+, only if compiled with `javac -parameters`)
+func (m *MemberInfo) MethodParametersAttribute() *MethodParametersAttribute {
+	for _, attr := range m.attributes {
+		if mpAttr, ok := attr.(*MethodParametersAttribute); ok {
+			return mpAttr
+		}
+	}
+	return nil
+}
+
+// IsDeprecated has Deprecated attribute
+func (m *MemberInfo) IsDeprecated() bool {
+	for _, attr := range m.attributes {
+		if _, ok := attr.(*DeprecatedAttribute); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AnnotationsAttribute RuntimeVisibleAnnotations (visible = true) or RuntimeInvisibleAnnotations
+func (m *MemberInfo) AnnotationsAttribute(visible bool) *AnnotationsAttribute {
+	return findAnnotationsAttribute(m.attributes, visible)
+}
+
+// ParameterAnnotationsAttribute RuntimeVisibleParameterAnnotations (visible = true) or RuntimeInvisibleParameterAnnotations
+func (m *MemberInfo) ParameterAnnotationsAttribute(visible bool) *ParameterAnnotationsAttribute {
+	return findParameterAnnotationsAttribute(m.attributes, visible)
+}
+
+// TypeAnnotationsAttribute RuntimeVisibleTypeAnnotations (visible = true) or RuntimeInvisibleTypeAnnotations
+func (m *MemberInfo) TypeAnnotationsAttribute(visible bool) *TypeAnnotationsAttribute {
+	return findTypeAnnotationsAttribute(m.attributes, visible)
+}
+
 // IsPSVM is public static void main([]String args) {...}
 func (m *MemberInfo) IsPSVM() bool {
 	return m.Name() == "main" && m.Descriptor() == "([Ljava/lang/String;)V" &&
@@ -41,6 +41,37 @@ func Debug(cf *ClassFile, printCP bool) {
 	if sourceFile := cf.SourceFileAttribute(); sourceFile != nil {
 		fmt.Printf("Source File: %s\n", sourceFile.FileName())
 	}
+	if signature := cf.SignatureAttribute(); signature != nil {
+		fmt.Printf("Signature: %s\n", signature.Signature())
+	}
+	if cf.IsDeprecated() {
+		fmt.Println("Deprecated: true")
+	}
+	if enclosing := cf.EnclosingMethodAttribute(); enclosing != nil {
+		name, descriptor := enclosing.MethodNameAndDescriptor()
+		fmt.Printf("Enclosing Method: %s.%s%s\n", enclosing.ClassName(), name, descriptor)
+	}
+	if innerClasses := cf.InnerClassesAttribute(); innerClasses != nil {
+		for _, inner := range innerClasses.Classes() {
+			fmt.Printf("Inner Class: %s (outer: %s, name: %s, flags: 0x%04X)\n",
+				inner.InnerClassName(), inner.OuterClassName(), inner.InnerName(), inner.AccessFlags())
+		}
+	}
+	if nestHost := cf.NestHostAttribute(); nestHost != nil {
+		fmt.Printf("Nest Host: %s\n", nestHost.HostClassName())
+	}
+	if nestMembers := cf.NestMembersAttribute(); nestMembers != nil {
+		fmt.Printf("Nest Members: %v\n", nestMembers.ClassNames())
+	}
+	if record := cf.RecordAttribute(); record != nil {
+		for _, component := range record.Components() {
+			fmt.Printf("Record Component: %s %s\n", component.Name(), component.Descriptor())
+		}
+	}
+	if permitted := cf.PermittedSubclassesAttribute(); permitted != nil {
+		fmt.Printf("Permitted Subclasses: %v\n", permitted.ClassNames())
+	}
+	printAnnotations("", cf.AnnotationsAttribute(true))
 
 	// print fields
 	fmt.Printf("\n=== FIELDS (%d) ===\n", len(cf.Fields()))
@@ -106,6 +137,27 @@ func printMemberInfo(memberType string, member *MemberInfo) {
 		fmt.Printf(" [%s]", join(flags, ", "))
 	}
 	fmt.Println()
+
+	if signature := member.SignatureAttribute(); signature != nil {
+		fmt.Printf("   - Signature: %s\n", signature.Signature())
+	}
+	if exceptions := member.ExceptionsAttribute(); exceptions != nil {
+		fmt.Printf("   - Throws: %v\n", exceptions.ExceptionClassNames())
+	}
+	if member.IsDeprecated() {
+		fmt.Println("   - Deprecated")
+	}
+	printAnnotations("   - ", member.AnnotationsAttribute(true))
+}
+
+// printAnnotations print RuntimeVisibleAnnotations type, ex: @Ljava/lang/FunctionalInterface;
+func printAnnotations(indent string, attr *AnnotationsAttribute) {
+	if attr == nil {
+		return
+	}
+	for _, annotation := range attr.Annotations() {
+		fmt.Printf("%sAnnotation: @%s\n", indent, annotation.TypeDescriptor())
+	}
 }
 
 func join(strs []string, sep string) string {
@@ -172,6 +224,30 @@ func printConstantPool(pool ClassFileConstantPool) {
 		case *ConstantNameAndTypeInfo:
 			printNameAndTypeInfo(c, pool)
 
+		case *ConstantMethodHandleInfo:
+			className, name, descriptor := c.MemberRef()
+			fmt.Printf("%-20s kind=%d, reference=#%-3d\n", "MethodHandle", c.referenceKind, c.referenceIndex)
+			fmt.Printf("%-20s └─> %s.%s:%s\n", "", className, name, descriptor)
+
+		case *ConstantMethodTypeInfo:
+			fmt.Printf("%-20s desc=#%-3d -> \"%s\"\n", "MethodType", c.descriptorIndex, c.Descriptor())
+
+		case *ConstantInvokeDynamicInfo:
+			name, descriptor := c.NameAndDescriptor()
+			fmt.Printf("%-20s bootstrap=%d, name_type=#%-3d\n", "InvokeDynamic", c.bootstrapMethodAttrIndex, c.nameAndTypeIndex)
+			fmt.Printf("%-20s └─> %s:%s\n", "", name, descriptor)
+
+		case *ConstantDynamicInfo:
+			name, descriptor := c.NameAndDescriptor()
+			fmt.Printf("%-20s bootstrap=%d, name_type=#%-3d\n", "Dynamic", c.bootstrapMethodAttrIndex, c.nameAndTypeIndex)
+			fmt.Printf("%-20s └─> %s:%s\n", "", name, descriptor)
+
+		case *ConstantModuleInfo:
+			fmt.Printf("%-20s name=#%-3d -> \"%s\"\n", "Module", c.nameIndex, c.Name())
+
+		case *ConstantPackageInfo:
+			fmt.Printf("%-20s name=#%-3d -> \"%s\"\n", "Package", c.nameIndex, c.Name())
+
 		default:
 			fmt.Printf("%-20s [Unknown constants type]\n", "Unknown")
 		}
@@ -340,6 +416,18 @@ func PrintConstantPoolSummary(pool ClassFileConstantPool) {
 			counts["InterfaceMethodref"]++
 		case *ConstantNameAndTypeInfo:
 			counts["NameAndType"]++
+		case *ConstantMethodHandleInfo:
+			counts["MethodHandle"]++
+		case *ConstantMethodTypeInfo:
+			counts["MethodType"]++
+		case *ConstantInvokeDynamicInfo:
+			counts["InvokeDynamic"]++
+		case *ConstantDynamicInfo:
+			counts["Dynamic"]++
+		case *ConstantModuleInfo:
+			counts["Module"]++
+		case *ConstantPackageInfo:
+			counts["Package"]++
 		}
 	}
 
@@ -393,6 +481,14 @@ func estimatePoolSize(pool ClassFileConstantPool) int {
 			size += 4
 		case *ConstantNameAndTypeInfo:
 			size += 4 // name_index(2) + descriptor_index(2)
+		case *ConstantMethodHandleInfo:
+			size += 3 // reference_kind(1) + reference_index(2)
+		case *ConstantMethodTypeInfo:
+			size += 2 // descriptor_index
+		case *ConstantInvokeDynamicInfo, *ConstantDynamicInfo:
+			size += 4 // bootstrap_method_attr_index(2) + name_and_type_index(2)
+		case *ConstantModuleInfo, *ConstantPackageInfo:
+			size += 2 // name_index
 		}
 	}
 
@@ -453,6 +549,27 @@ func GetConstantPoolEntryInfo(pool ClassFileConstantPool, index int) string {
 		descriptor := getUtf8(pool, c.descriptorIndex)
 		info.WriteString(fmt.Sprintf("NameAndType[%s:%s]", name, descriptor))
 
+	case *ConstantMethodHandleInfo:
+		className, name, descriptor := c.MemberRef()
+		info.WriteString(fmt.Sprintf("MethodHandle[kind=%d %s.%s:%s]", c.referenceKind, className, name, descriptor))
+
+	case *ConstantMethodTypeInfo:
+		info.WriteString(fmt.Sprintf("MethodType[%s]", c.Descriptor()))
+
+	case *ConstantInvokeDynamicInfo:
+		name, descriptor := c.NameAndDescriptor()
+		info.WriteString(fmt.Sprintf("InvokeDynamic[#%d:%s%s]", c.bootstrapMethodAttrIndex, name, descriptor))
+
+	case *ConstantDynamicInfo:
+		name, descriptor := c.NameAndDescriptor()
+		info.WriteString(fmt.Sprintf("Dynamic[#%d:%s:%s]", c.bootstrapMethodAttrIndex, name, descriptor))
+
+	case *ConstantModuleInfo:
+		info.WriteString(fmt.Sprintf("Module[%s]", c.Name()))
+
+	case *ConstantPackageInfo:
+		info.WriteString(fmt.Sprintf("Package[%s]", c.Name()))
+
 	default:
 		info.WriteString("Unknown")
 	}
@@ -0,0 +1,88 @@
+package classpath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================================
+// Classpath - where ClassLoader finds .class files
+// ============================================================
+// -classpath "target/classes:lib/*:app.jar"
+//
+//	target/classes  → DirEntry
+//	lib/*           → WildcardEntry (lib/a.jar, lib/b.jar)
+//	app.jar         → ZipEntry, + its manifest Class-Path jars right after it
+//
+// entries are searched in order, first match wins.
+
+type Classpath struct {
+	entries []Entry
+}
+
+// Parse parse path list separated by ':' (';' on windows), empty path list means current dir
+func Parse(pathList string) *Classpath {
+	cp := &Classpath{}
+	if strings.TrimSpace(pathList) == "" {
+		pathList = "."
+	}
+	seen := make(map[string]bool)
+	for _, path := range strings.Split(pathList, pathListSeparator) {
+		if path != "" {
+			cp.addEntry(newEntry(path), seen)
+		}
+	}
+	return cp
+}
+
+// addEntry add entry and jars referenced by its manifest Class-Path (recursively)
+func (cp *Classpath) addEntry(entry Entry, seen map[string]bool) {
+	if seen[entry.String()] {
+		return
+	}
+	seen[entry.String()] = true
+	cp.entries = append(cp.entries, entry)
+
+	var jars []*ZipEntry
+	switch e := entry.(type) {
+	case *ZipEntry:
+		jars = []*ZipEntry{e}
+	case *WildcardEntry:
+		jars = e.Entries()
+		for _, jar := range jars {
+			seen[jar.String()] = true
+		}
+	}
+	for _, jar := range jars {
+		manifestData, err := jar.readFile(MANIFEST_PATH)
+		if err != nil {
+			continue
+		}
+		for _, path := range ParseManifest(manifestData).ClassPath(jar.String()) {
+			cp.addEntry(newEntry(path), seen)
+		}
+	}
+}
+
+// ReadClass search class in all entries, return data and the entry where class is found
+// className format: java/lang/Object
+func (cp *Classpath) ReadClass(className string) ([]byte, Entry, error) {
+	for _, entry := range cp.entries {
+		if data, err := entry.ReadClass(className); err == nil {
+			return data, entry, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("class not found in classpath: %s", className)
+}
+
+func (cp *Classpath) Entries() []Entry {
+	return cp.entries
+}
+
+func (cp *Classpath) String() string {
+	paths := make([]string, len(cp.entries))
+	for i, entry := range cp.entries {
+		paths[i] = entry.String()
+	}
+	return strings.Join(paths, pathListSeparator)
+}
@@ -0,0 +1,67 @@
+package classpath
+
+import (
+	"archive/zip"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeJar(t *testing.T, path string, files map[string]string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		assert.NoError(t, err)
+		_, _ = fw.Write([]byte(content))
+	}
+	assert.NoError(t, w.Close())
+}
+
+func TestParseManifest(t *testing.T) {
+	m := ParseManifest([]byte("Manifest-Version: 1.0\r\nmain-class: com.example.Main\r\nClass-Path: lib/a.jar li\r\n b/b.jar\r\n\r\nName: com/example/\r\nMain-Class: Other\r\n"))
+	assert.Equal(t, "com.example.Main", m.MainClass())
+	assert.Equal(t, "lib/a.jar lib/b.jar", m.Get("Class-Path"))
+	assert.Equal(t, []string{filepath.Join("app", "lib/a.jar"), filepath.Join("app", "lib/b.jar")}, m.ClassPath("app/app.jar"))
+}
+
+func TestClasspath_ReadClass(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "classes", "com", "example"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "classes", "com", "example", "Dir.class"), []byte("dir"), 0644))
+	writeJar(t, filepath.Join(dir, "app.jar"), map[string]string{
+		"com/example/Main.class": "main",
+		MANIFEST_PATH:            "Manifest-Version: 1.0\nClass-Path: dep/dep.jar\n",
+	})
+	writeJar(t, filepath.Join(dir, "dep", "dep.jar"), map[string]string{"com/example/Dep.class": "dep"})
+	writeJar(t, filepath.Join(dir, "lib", "a.jar"), map[string]string{"com/example/Lib.class": "lib", "com/example/Dir.class": "shadowed"})
+	writeJar(t, filepath.Join(dir, "lib", "b.zip"), map[string]string{"com/example/Zip.class": "zip"})
+
+	cp := Parse(filepath.Join(dir, "classes") + pathListSeparator + filepath.Join(dir, "app.jar") + pathListSeparator + filepath.Join(dir, "lib", "*"))
+	assert.Len(t, cp.Entries(), 4) // classes, app.jar, dep.jar (manifest), lib/*
+
+	for className, expected := range map[string]string{
+		"com/example/Dir":  "dir", // first entry wins
+		"com/example/Main": "main",
+		"com/example/Dep":  "dep",
+		"com/example/Lib":  "lib",
+	} {
+		data, _, err := cp.ReadClass(className)
+		assert.NoError(t, err, className)
+		assert.Equal(t, expected, string(data), className)
+	}
+
+	// wildcard only match .jar
+	_, _, err := cp.ReadClass("com/example/Zip")
+	assert.Error(t, err)
+}
+
+func TestParse_empty(t *testing.T) {
+	cp := Parse("")
+	assert.Len(t, cp.Entries(), 1)
+	assert.IsType(t, &DirEntry{}, cp.Entries()[0])
+}
@@ -0,0 +1,40 @@
+package classpath
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ============================================================
+// Classpath Entry
+// ============================================================
+// one element of -classpath:
+//   - DirEntry:      dir of .class files      (target/classes)
+//   - ZipEntry:      .jar / .zip archive      (lib/app.jar)
+//   - WildcardEntry: all .jar files in a dir  (lib/*)
+
+// pathListSeparator ':' on unix, ';' on windows (same as java)
+const pathListSeparator = string(os.PathListSeparator)
+
+type Entry interface {
+	// ReadClass className format: java/lang/Object (no .class suffix)
+	ReadClass(className string) ([]byte, error)
+	String() string
+}
+
+// newEntry create entry by path format
+func newEntry(path string) Entry {
+	if path == "*" || strings.HasSuffix(path, string(os.PathSeparator)+"*") || strings.HasSuffix(path, "/*") {
+		return newWildcardEntry(path)
+	}
+	if isArchive(path) {
+		return newZipEntry(path)
+	}
+	return newDirEntry(path)
+}
+
+func isArchive(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".jar" || ext == ".zip"
+}
@@ -0,0 +1,26 @@
+package classpath
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DirEntry dir of .class files, java/lang/Object → <dir>/java/lang/Object.class
+type DirEntry struct {
+	dir string
+}
+
+func newDirEntry(path string) *DirEntry {
+	if absDir, err := filepath.Abs(path); err == nil {
+		path = absDir
+	}
+	return &DirEntry{dir: path}
+}
+
+func (e *DirEntry) ReadClass(className string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(e.dir, filepath.FromSlash(className)+".class"))
+}
+
+func (e *DirEntry) String() string {
+	return e.dir
+}
@@ -0,0 +1,51 @@
+package classpath
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WildcardEntry `dir/*`: every .jar file directly in dir (not recursive, same as java),
+// jars are expanded when classpath is parsed, in file name order.
+type WildcardEntry struct {
+	path    string
+	entries []*ZipEntry
+}
+
+func newWildcardEntry(path string) *WildcardEntry {
+	e := &WildcardEntry{path: path}
+	dir := strings.TrimSuffix(path, "*")
+	if dir == "" {
+		dir = "."
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return e
+	}
+	for _, file := range files {
+		if !file.IsDir() && strings.EqualFold(filepath.Ext(file.Name()), ".jar") {
+			e.entries = append(e.entries, newZipEntry(filepath.Join(dir, file.Name())))
+		}
+	}
+	return e
+}
+
+func (e *WildcardEntry) ReadClass(className string) ([]byte, error) {
+	for _, entry := range e.entries {
+		if data, err := entry.ReadClass(className); err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("class not found: %s in %s", className, e.path)
+}
+
+// Entries expanded jars
+func (e *WildcardEntry) Entries() []*ZipEntry {
+	return e.entries
+}
+
+func (e *WildcardEntry) String() string {
+	return e.path
+}
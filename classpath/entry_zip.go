@@ -0,0 +1,66 @@
+package classpath
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+)
+
+// ZipEntry .jar / .zip archive
+// archive is opened at first read and kept open, files are indexed by name for lookup.
+type ZipEntry struct {
+	path string
+
+	once  sync.Once
+	files map[string]*zip.File
+	err   error
+}
+
+func newZipEntry(path string) *ZipEntry {
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+	return &ZipEntry{path: path}
+}
+
+func (e *ZipEntry) open() error {
+	e.once.Do(func() {
+		reader, err := zip.OpenReader(e.path)
+		if err != nil {
+			e.err = err
+			return
+		}
+		e.files = make(map[string]*zip.File, len(reader.File))
+		for _, file := range reader.File {
+			e.files[file.Name] = file
+		}
+	})
+	return e.err
+}
+
+func (e *ZipEntry) ReadClass(className string) ([]byte, error) {
+	return e.readFile(className + ".class")
+}
+
+// readFile read file in archive, ex: META-INF/MANIFEST.MF
+func (e *ZipEntry) readFile(name string) ([]byte, error) {
+	if err := e.open(); err != nil {
+		return nil, err
+	}
+	file, ok := e.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in %s", name, e.path)
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func (e *ZipEntry) String() string {
+	return e.path
+}
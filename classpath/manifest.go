@@ -0,0 +1,99 @@
+package classpath
+
+import (
+	"bytes"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// ============================================================
+// META-INF/MANIFEST.MF (JAR File Specification)
+// ============================================================
+//
+//	Manifest-Version: 1.0
+//	Main-Class: com.example.Main
+//	Class-Path: lib/a.jar lib/b.jar
+//	 lib/c.jar                          ← continuation line (starts with one space)
+//
+//	Name: com/example/                  ← per-entry section (after blank line), ignored
+//
+// - line length is limited to 72 bytes, longer value is split into continuation lines
+// - header names are case-insensitive
+
+const MANIFEST_PATH = "META-INF/MANIFEST.MF"
+
+// Manifest main section attributes
+type Manifest struct {
+	attributes map[string]string // key: lower case header name
+}
+
+// ParseManifest parse main section of manifest
+func ParseManifest(data []byte) *Manifest {
+	m := &Manifest{attributes: make(map[string]string)}
+
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	data = bytes.ReplaceAll(data, []byte("\r"), []byte("\n"))
+
+	var lastKey string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			if len(m.attributes) > 0 {
+				break // end of main section
+			}
+			continue
+		}
+		if line[0] == ' ' {
+			// continuation of previous header value
+			if lastKey != "" {
+				m.attributes[lastKey] += line[1:]
+			}
+			continue
+		}
+		colon := strings.Index(line, ": ")
+		if colon <= 0 {
+			lastKey = ""
+			continue // malformed header
+		}
+		lastKey = strings.ToLower(line[:colon])
+		m.attributes[lastKey] = line[colon+2:]
+	}
+	return m
+}
+
+// ReadManifest read manifest of jar, nil if jar has no manifest
+func ReadManifest(jarPath string) (*Manifest, error) {
+	data, err := newZipEntry(jarPath).readFile(MANIFEST_PATH)
+	if err != nil {
+		return nil, err
+	}
+	return ParseManifest(data), nil
+}
+
+// Get header value, "" if absent
+func (m *Manifest) Get(name string) string {
+	return strings.TrimSpace(m.attributes[strings.ToLower(name)])
+}
+
+// MainClass binary name of Main-Class (com.example.Main)
+func (m *Manifest) MainClass() string {
+	return m.Get("Main-Class")
+}
+
+// ClassPath Class-Path entries as file paths, relative ones are resolved against dir of the jar
+func (m *Manifest) ClassPath(jarPath string) []string {
+	var paths []string
+	jarDir := filepath.Dir(jarPath)
+	for _, ref := range strings.Fields(m.Get("Class-Path")) {
+		ref = strings.TrimPrefix(ref, "file:")
+		if unescaped, err := url.PathUnescape(ref); err == nil {
+			ref = unescaped
+		}
+		path := filepath.FromSlash(ref)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(jarDir, path)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
@@ -2,29 +2,55 @@ package main
 
 import (
 	"fmt"
+	"github.com/Johnny1110/gogo_jvm/classfile"
+	"github.com/Johnny1110/gogo_jvm/classpath"
 	"github.com/Johnny1110/gogo_jvm/interpreter"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
 	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
 	"os"
+	goruntime "runtime"
 
 	// import native package for trigger init() (register all native methods)
 	_ "github.com/Johnny1110/gogo_jvm/native"
 )
 
 // ============================================================
-// Gogo JVM v0.2.7
+// Gogo JVM
 // ============================================================
+
+const VERSION = "0.4.1"
+
 func main() {
-	if len(os.Args) < 2 {
-		// first arg is java
+	options, err := parseOptions(os.Args[1:])
+	if err != nil {
+		fmt.Println("Error:", err)
+		printUsage()
+		os.Exit(1)
+	}
+	if options.help {
+		printUsage()
+		return
+	}
+	if options.version {
+		printVersion()
+		return
+	}
+	if options.mainClass == "" && options.jarPath == "" {
 		printUsage()
 		os.Exit(1)
 	}
-	classFilePath := os.Args[1]
-	debug := len(os.Args) > 2 && os.Args[2] == "-debug"
 
-	// get class path (.class file's dir）
-	classPath := getClassPath(classFilePath)
-	className := getClassName(classFilePath)
+	classPath, className, err := resolveMainClass(options)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	if err := applyOptions(options, classPath); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	debug := options.debug
 
 	if debug {
 		fmt.Println("============================================")
@@ -48,11 +74,61 @@ func main() {
 	}
 
 	// start run
-	interpreter.Interpret(mainMethod, debug)
+	interpreter.Interpret(mainMethod, options.args, debug)
 
 	fmt.Println("GOGO JVM exit")
 }
 
+// applyOptions wire options into subsystems: loader, thread stack, heap, system properties
+func applyOptions(options *Options, classPath string) error {
+	if options.verifyMode != "" {
+		if err := method_area.SetVerifyMode(options.verifyMode); err != nil {
+			return err
+		}
+	}
+	method_area.SetVerboseClass(options.verboseClass)
+
+	if options.stackSize > 0 {
+		runtime.SetDefaultStackSize(runtime.StackFramesOf(options.stackSize))
+	}
+
+	heap.SetVerboseGC(options.verboseGC)
+	if err := heap.SetHeapSize(options.initialHeapSize, options.maxHeapSize); err != nil {
+		return err
+	}
+
+	for _, assertion := range options.assertions {
+		if assertion.system {
+			runtime.SetSystemAssertionStatus(assertion.enabled)
+		} else {
+			runtime.SetAssertionStatus(assertion.target, assertion.enabled)
+		}
+	}
+
+	runtime.SetSystemProperty("java.class.path", classPath)
+	runtime.SetSystemProperty("java.vm.version", VERSION)
+	for _, prop := range options.properties {
+		runtime.SetSystemProperty(prop.key, prop.value)
+	}
+	return nil
+}
+
+// getJarMainClass read Main-Class from jar's META-INF/MANIFEST.MF
+func getJarMainClass(jarPath string) (string, error) {
+	if _, err := os.Stat(jarPath); err != nil {
+		return "", fmt.Errorf("Unable to access jarfile %s", jarPath)
+	}
+	manifest, err := classpath.ReadManifest(jarPath)
+	if err != nil {
+		return "", fmt.Errorf("no main manifest attribute, in %s", jarPath)
+	}
+	mainClass := manifest.MainClass()
+	if mainClass == "" {
+		return "", fmt.Errorf("no main manifest attribute, in %s", jarPath)
+	}
+	return mainClass, nil
+}
+
 // getClassPath
 func getClassPath(filePath string) string {
 	// find last '/' position
@@ -88,9 +164,41 @@ func getClassName(filePath string) string {
 func printUsage() {
 	fmt.Println("Gogo JVM - A simple JVM implementation in Go")
 	fmt.Println()
-	fmt.Println("Usage: gogo_jvm <classfile> [-debug]")
+	fmt.Println("Usage: gogo_jvm [options] <mainclass> [args...]")
+	fmt.Println("           (to execute a class, <mainclass> is binary name or .class file path)")
+	fmt.Println("   or  gogo_jvm [options] -jar <jarfile> [args...]")
+	fmt.Println("           (to execute a jar file)")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -cp <path>, -classpath <path>, --class-path <path>")
+	fmt.Println("                    dirs, jar/zip files and dir/* separated by " + string(os.PathListSeparator))
+	fmt.Println("  -D<name>=<value>  set a system property")
+	fmt.Println("  -verbose:[class|gc]")
+	fmt.Println("                    enable verbose output")
+	fmt.Println("  -ea[:<packagename>...|:<classname>], -enableassertions[...]")
+	fmt.Println("                    enable assertions")
+	fmt.Println("  -da[:<packagename>...|:<classname>], -disableassertions[...]")
+	fmt.Println("                    disable assertions")
+	fmt.Println("  -esa, -dsa        enable / disable system assertions")
+	fmt.Println("  -Xss<size>        thread stack size (1m = 1024 frames)")
+	fmt.Println("  -Xms<size>        initial heap size")
+	fmt.Println("  -Xmx<size>        maximum heap size")
+	fmt.Println("  -Xverify:none|remote|all")
+	fmt.Println("                    bytecode verification (default: remote)")
+	fmt.Println("  -version, --version")
+	fmt.Println("                    print version and exit")
+	fmt.Println("  -help, -h, -?     print this help message")
+	fmt.Println("  -debug            print gogo_jvm debug info")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  gogo_jvm SimpleAdd.class")
-	fmt.Println("  gogo_jvm SimpleAdd.class -debug")
+	fmt.Println("  gogo_jvm -debug SimpleAdd.class")
+	fmt.Println("  gogo_jvm -cp app.jar:lib/* -Dmode=dev com.example.Main arg1 arg2")
+	fmt.Println("  gogo_jvm -Xss512k -Xmx64m -jar app.jar")
+}
+
+func printVersion() {
+	fmt.Printf("gogo_jvm version \"%s\"\n", VERSION)
+	fmt.Printf("Gogo JVM (%s %s/%s), class file version up to %d.0\n",
+		goruntime.Version(), goruntime.GOOS, goruntime.GOARCH, classfile.MAX_SUPPORTED_MAJOR_VERSION)
 }
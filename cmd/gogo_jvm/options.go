@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// Launcher Options (java style)
+// ============================================================
+//
+//	gogo_jvm [options] <mainclass> [args...]
+//	gogo_jvm [options] -jar <jarfile> [args...]
+//
+// <mainclass>: binary name (com.foo.Main) found in classpath,
+// or path of .class file (build/Main.class), its dir is added in front of classpath.
+// everything after <mainclass> / <jarfile> is passed to main(String[] args), -debug included.
+
+type Options struct {
+	classPath  string // -cp / -classpath / --class-path, "" if not given
+	jarPath    string // -jar
+	mainClass  string // binary name or .class file path
+	args       []string
+	properties []property // -D<key>=<value>, in order
+
+	stackSize       uint64 // -Xss (bytes), 0: default
+	initialHeapSize uint64 // -Xms (bytes), 0: default
+	maxHeapSize     uint64 // -Xmx (bytes), 0: unlimited
+
+	verboseClass bool
+	verboseGC    bool
+	assertions   []assertionOption // -ea / -da / -esa / -dsa, in order
+	verifyMode   string            // -Xverify:<mode>
+
+	debug   bool
+	help    bool
+	version bool
+}
+
+type property struct {
+	key, value string
+}
+
+// assertionOption target: "" all classes, "pkg..." package tree, otherwise class name
+type assertionOption struct {
+	target  string
+	enabled bool
+	system  bool // -esa / -dsa
+}
+
+// parseOptions parse os.Args[1:]
+func parseOptions(args []string) (*Options, error) {
+	options := &Options{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		// after main class: program args
+		if options.mainClass != "" || options.jarPath != "" {
+			options.args = append(options.args, arg)
+			continue
+		}
+
+		switch {
+		case arg == "-cp" || arg == "-classpath" || arg == "--class-path":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires class path specification", arg)
+			}
+			i++
+			options.classPath = args[i]
+		case strings.HasPrefix(arg, "--class-path="):
+			options.classPath = strings.TrimPrefix(arg, "--class-path=")
+		case arg == "-jar":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("-jar requires jar file specification")
+			}
+			i++
+			options.jarPath = args[i]
+		case strings.HasPrefix(arg, "-D"):
+			key, value, _ := strings.Cut(strings.TrimPrefix(arg, "-D"), "=")
+			if key == "" {
+				return nil, fmt.Errorf("invalid system property: %s", arg)
+			}
+			options.properties = append(options.properties, property{key: key, value: value})
+		case strings.HasPrefix(arg, "-Xss"):
+			size, err := parseMemorySize(strings.TrimPrefix(arg, "-Xss"))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid thread stack size: %s", arg)
+			}
+			// less than 1 frame: JVM stack can not hold main()
+			if runtime.StackFramesOf(size) == 0 {
+				return nil, fmt.Errorf("The Java thread stack size specified is too small. Specify at least %dk", runtime.STACK_FRAME_BYTES>>10)
+			}
+			options.stackSize = size
+		case strings.HasPrefix(arg, "-Xmx"):
+			size, err := parseMemorySize(strings.TrimPrefix(arg, "-Xmx"))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid maximum heap size: %s", arg)
+			}
+			options.maxHeapSize = size
+		case strings.HasPrefix(arg, "-Xms"):
+			size, err := parseMemorySize(strings.TrimPrefix(arg, "-Xms"))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid initial heap size: %s", arg)
+			}
+			options.initialHeapSize = size
+		case strings.HasPrefix(arg, "-Xverify:"):
+			options.verifyMode = strings.TrimPrefix(arg, "-Xverify:")
+		case arg == "-verbose" || arg == "-verbose:class":
+			options.verboseClass = true
+		case arg == "-verbose:gc":
+			options.verboseGC = true
+		case isAssertionOption(arg):
+			options.assertions = append(options.assertions, parseAssertionOption(arg))
+		case arg == "-version" || arg == "--version":
+			options.version = true
+		case arg == "-help" || arg == "--help" || arg == "-h" || arg == "-?":
+			options.help = true
+		case arg == "-debug":
+			options.debug = true
+		case strings.HasPrefix(arg, "-"):
+			return nil, fmt.Errorf("Unrecognized option: %s", arg)
+		default:
+			options.mainClass = arg
+		}
+	}
+	return options, nil
+}
+
+// parseMemorySize 1024, 512k, 64m, 2g (case insensitive) → bytes
+func parseMemorySize(size string) (uint64, error) {
+	if size == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+	unit := uint64(1)
+	switch size[len(size)-1] {
+	case 'k', 'K':
+		unit = 1 << 10
+	case 'm', 'M':
+		unit = 1 << 20
+	case 'g', 'G':
+		unit = 1 << 30
+	case 't', 'T':
+		unit = 1 << 40
+	}
+	if unit != 1 {
+		size = size[:len(size)-1]
+	}
+	n, err := strconv.ParseUint(size, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n > ^uint64(0)/unit {
+		return 0, fmt.Errorf("size overflow")
+	}
+	return n * unit, nil
+}
+
+var assertionOptionNames = map[string]bool{
+	"-ea": true, "-enableassertions": true,
+	"-da": false, "-disableassertions": false,
+}
+
+func isAssertionOption(arg string) bool {
+	switch arg {
+	case "-esa", "-enablesystemassertions", "-dsa", "-disablesystemassertions":
+		return true
+	}
+	name, _, _ := strings.Cut(arg, ":")
+	_, ok := assertionOptionNames[name]
+	return ok
+}
+
+func parseAssertionOption(arg string) assertionOption {
+	switch arg {
+	case "-esa", "-enablesystemassertions":
+		return assertionOption{enabled: true, system: true}
+	case "-dsa", "-disablesystemassertions":
+		return assertionOption{enabled: false, system: true}
+	}
+	name, target, _ := strings.Cut(arg, ":")
+	return assertionOption{target: target, enabled: assertionOptionNames[name]}
+}
+
+// resolveMainClass return classpath and main class internal name (com/foo/Main)
+//   - -jar: classpath is the jar (manifest Class-Path is added by loader), -cp is ignored (same as java)
+//   - .class file path: its dir + -cp
+//   - binary name: -cp, or $CLASSPATH, or current dir
+func resolveMainClass(options *Options) (string, string, error) {
+	if options.jarPath != "" {
+		mainClass, err := getJarMainClass(options.jarPath)
+		if err != nil {
+			return "", "", err
+		}
+		return options.jarPath, toInternalName(mainClass), nil
+	}
+
+	mainClass := options.mainClass
+	if strings.HasSuffix(mainClass, ".class") || strings.ContainsAny(mainClass, "/\\") {
+		classPath := getClassPath(mainClass)
+		if options.classPath != "" {
+			classPath += string(os.PathListSeparator) + options.classPath
+		}
+		return classPath, getClassName(mainClass), nil
+	}
+
+	classPath := options.classPath
+	if classPath == "" {
+		classPath = os.Getenv("CLASSPATH")
+	}
+	if classPath == "" {
+		classPath = "."
+	}
+	return classPath, toInternalName(mainClass), nil
+}
+
+// toInternalName com.foo.Main → com/foo/Main
+func toInternalName(binaryName string) string {
+	return strings.ReplaceAll(binaryName, ".", "/")
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"archive/zip"
+	"github.com/Johnny1110/gogo_jvm/classpath"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_parseOptions(t *testing.T) {
+	options, err := parseOptions([]string{
+		"-cp", "lib/*:app.jar", "-Dmode=dev", "-Dflag", "-Xss512k", "-Xms16m", "-Xmx1g",
+		"-verbose:class", "-verbose:gc", "-ea", "-da:com.foo...", "-esa", "-Xverify:all", "-debug",
+		"com.example.Main", "a", "-debug", "-cp", "b",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "lib/*:app.jar", options.classPath)
+	assert.Equal(t, "com.example.Main", options.mainClass)
+	assert.Equal(t, []string{"a", "-debug", "-cp", "b"}, options.args) // program args are not parsed
+	assert.True(t, options.debug)
+	assert.Equal(t, []property{{"mode", "dev"}, {"flag", ""}}, options.properties)
+	assert.Equal(t, uint64(512<<10), options.stackSize)
+	assert.Equal(t, uint64(16<<20), options.initialHeapSize)
+	assert.Equal(t, uint64(1<<30), options.maxHeapSize)
+	assert.True(t, options.verboseClass)
+	assert.True(t, options.verboseGC)
+	assert.Equal(t, []assertionOption{{"", true, false}, {"com.foo...", false, false}, {"", true, true}}, options.assertions)
+	assert.Equal(t, "all", options.verifyMode)
+}
+
+func Test_parseOptions_jar(t *testing.T) {
+	options, err := parseOptions([]string{"--class-path=ignored", "-jar", "app.jar", "x", "y"})
+	assert.NoError(t, err)
+	assert.Equal(t, "app.jar", options.jarPath)
+	assert.Equal(t, []string{"x", "y"}, options.args)
+}
+
+func Test_parseOptions_error(t *testing.T) {
+	for _, args := range [][]string{{"-cp"}, {"-jar"}, {"-Xss"}, {"-Xmx1x"}, {"-Xss100"}, {"-Xss1023"}, {"-Xfoo"}, {"-D=v"}, {"--unknown"}} {
+		_, err := parseOptions(args)
+		assert.Error(t, err, args)
+	}
+}
+
+func Test_resolveMainClass(t *testing.T) {
+	classPath, className, err := resolveMainClass(&Options{mainClass: "test/class/TestAdd.class", classPath: "lib"})
+	assert.NoError(t, err)
+	assert.Equal(t, "test/class:lib", classPath)
+	assert.Equal(t, "TestAdd", className)
+
+	classPath, className, err = resolveMainClass(&Options{mainClass: "com.foo.Main", classPath: "app.jar"})
+	assert.NoError(t, err)
+	assert.Equal(t, "app.jar", classPath)
+	assert.Equal(t, "com/foo/Main", className)
+}
+
+func Test_parseMemorySize(t *testing.T) {
+	for size, expected := range map[string]uint64{"1024": 1024, "4k": 4 << 10, "64M": 64 << 20, "2g": 2 << 30} {
+		n, err := parseMemorySize(size)
+		assert.NoError(t, err, size)
+		assert.Equal(t, expected, n, size)
+	}
+	for _, size := range []string{"", "m", "-1m", "1.5g", "99999999999t"} {
+		_, err := parseMemorySize(size)
+		assert.Error(t, err, size)
+	}
+}
+
+func Test_parseOptions_debugAfterMainClass(t *testing.T) {
+	options, err := parseOptions([]string{"Main.class", "-debug"})
+	assert.NoError(t, err)
+	assert.False(t, options.debug)
+	assert.Equal(t, []string{"-debug"}, options.args)
+}
+
+// writeJar 建立 jar (zip), files: entry name → content
+func writeJar(t *testing.T, path string, files map[string]string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		assert.NoError(t, err)
+		_, _ = fw.Write([]byte(content))
+	}
+	assert.NoError(t, w.Close())
+}
+
+func Test_resolveMainClass_jar(t *testing.T) {
+	dir := t.TempDir()
+	jarPath := filepath.Join(dir, "app.jar")
+	writeJar(t, jarPath, map[string]string{
+		"com/example/Main.class": "main",
+		// Main-Class 跨行 (72 bytes 限制的續行), Class-Path 相對於 jar 所在目錄
+		classpath.MANIFEST_PATH: "Manifest-Version: 1.0\r\nMain-Class: com.example.Ma\r\n in\r\nClass-Path: lib/dep.jar\r\n\r\n",
+	})
+	writeJar(t, filepath.Join(dir, "lib", "dep.jar"), map[string]string{"com/example/Dep.class": "dep"})
+
+	classPath, mainClass, err := resolveMainClass(&Options{jarPath: jarPath, classPath: "ignored"})
+	assert.NoError(t, err)
+	assert.Equal(t, "com/example/Main", mainClass)
+	assert.Equal(t, jarPath, classPath) // -cp is ignored with -jar
+
+	// Class-Path of manifest is added by classpath
+	data, _, err := classpath.Parse(classPath).ReadClass("com/example/Dep")
+	assert.NoError(t, err)
+	assert.Equal(t, "dep", string(data))
+}
+
+func Test_resolveMainClass_jarError(t *testing.T) {
+	dir := t.TempDir()
+	noMain := filepath.Join(dir, "nomain.jar")
+	writeJar(t, noMain, map[string]string{classpath.MANIFEST_PATH: "Manifest-Version: 1.0\n"})
+	noManifest := filepath.Join(dir, "nomanifest.jar")
+	writeJar(t, noManifest, map[string]string{"com/example/Main.class": "main"})
+	missing := filepath.Join(dir, "missing.jar")
+
+	for jarPath, message := range map[string]string{
+		noMain:     "no main manifest attribute, in " + noMain,
+		noManifest: "no main manifest attribute, in " + noManifest,
+		missing:    "Unable to access jarfile " + missing,
+	} {
+		_, _, err := resolveMainClass(&Options{jarPath: jarPath})
+		assert.EqualError(t, err, message)
+	}
+}
+
+func Test_parseOptions_stackSizeTooSmall(t *testing.T) {
+	_, err := parseOptions([]string{"-Xss512", "Main"})
+	assert.EqualError(t, err, "The Java thread stack size specified is too small. Specify at least 1k")
+
+	options, err := parseOptions([]string{"-Xss1k", "Main"}) // 1 frame
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1024), options.stackSize)
+}
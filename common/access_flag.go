@@ -20,4 +20,5 @@ const (
 	ACC_STRICT       = 0x0800
 	ACC_VARARGS      = 0x0080
 	ACC_BRIDGE       = 0x0040
+	ACC_MODULE       = 0x8000 // module-info (Java 9+)
 )
@@ -1,8 +1,24 @@
 package common
 
+import "strings"
+
+// ============================================================
+// JavaException - VM raised java exception
+// ============================================================
+// runtime code without a Frame (heap, method_area, classfile, natives ...) can not create java exception object,
+// it panics *JavaException instead:
+//
+//	panic(common.NewJavaException("java/lang/NullPointerException", ""))
+//
+// interpreter recovers it, instantiates ClassName (Throwable subclass) with Message
+// and unwinds JVM stack by references.ThrowException, so java code can catch it.
+//
+// exception object already thrown by java code (VM upcall, ex: user-defined ClassLoader.loadClass)
+// is carried in Throwable and rethrown as it is.
 type JavaException struct {
-	ClassName string
+	ClassName string // internal name of Throwable subclass, ex: java/lang/NullPointerException
 	Message   string
+	Throwable interface{} // *heap.Object, nil: VM raised
 }
 
 func NewJavaException(className string, message string) *JavaException {
@@ -11,3 +27,20 @@ func NewJavaException(className string, message string) *JavaException {
 		Message:   message,
 	}
 }
+
+// NewThrownJavaException rethrow exception object thrown by java code
+func NewThrownJavaException(className string, throwable interface{}) *JavaException {
+	return &JavaException{
+		ClassName: className,
+		Throwable: throwable,
+	}
+}
+
+// Error java style: "java.lang.NullPointerException: message"
+func (e *JavaException) Error() string {
+	name := strings.ReplaceAll(e.ClassName, "/", ".")
+	if e.Message == "" {
+		return name
+	}
+	return name + ": " + e.Message
+}
@@ -10,57 +10,70 @@ import (
 // ============================================================
 // Java Common Exception Factory
 // ============================================================
+// native method return exception object (ex: `return exception.NewNullPointerException(frame)`),
+// invoker throws it in caller frame.
+
+// NewJavaExceptionObject create exception object of VM raised Throwable class (ex: java/lang/NullPointerException)
+// class not in classpath is defined by loader as synthetic class (see method_area/vm_throwable.go)
+func NewJavaExceptionObject(frame *runtime.Frame, className, message string) *heap.Object {
+	exClass := frame.Method().Class().Loader().LoadClass(className, false)
+	return heap.NewExceptionObject(exClass, message)
+}
 
 // NewArithmeticException
 func NewArithmeticException(frame *runtime.Frame, message string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/ArithmeticException", false)
-	return heap.NewExceptionObject(exClass, message)
+	return NewJavaExceptionObject(frame, "java/lang/ArithmeticException", message)
 }
 
 // NewNullPointerException
 func NewNullPointerException(frame *runtime.Frame) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/NullPointerException", false)
-	return heap.NewExceptionObject(exClass, "")
+	return NewJavaExceptionObject(frame, "java/lang/NullPointerException", "")
 }
 
 // NewArrayIndexOutOfBoundsException
 func NewArrayIndexOutOfBoundsException(frame *runtime.Frame, index int32) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/ArrayIndexOutOfBoundsException", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%d", index))
+	return NewJavaExceptionObject(frame, "java/lang/ArrayIndexOutOfBoundsException", fmt.Sprintf("%d", index))
 }
 
 // NewClassCastException
 func NewClassCastException(frame *runtime.Frame, from, to string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/ClassCastException", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%s cannot be cast to %s", from, to))
+	return NewJavaExceptionObject(frame, "java/lang/ClassCastException", fmt.Sprintf("%s cannot be cast to %s", from, to))
 }
 
 // NewNegativeArraySizeException
 func NewNegativeArraySizeException(frame *runtime.Frame, size int32) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/NegativeArraySizeException", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%d", size))
+	return NewJavaExceptionObject(frame, "java/lang/NegativeArraySizeException", fmt.Sprintf("%d", size))
 }
 
 func NewIncompatibleClassChangeError(frame *runtime.Frame, message string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/IncompatibleClassChangeError", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%s", message))
+	return NewJavaExceptionObject(frame, "java/lang/IncompatibleClassChangeError", message)
 }
 
 func NewAbstractMethodError(frame *runtime.Frame, className, methodName, descriptor string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/NewAbstractMethodError", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%s.%s%s", className, methodName, descriptor))
+	return NewJavaExceptionObject(frame, "java/lang/AbstractMethodError", fmt.Sprintf("%s.%s%s", className, methodName, descriptor))
 }
 
 func NewIllegalAccessError(frame *runtime.Frame, className, methodName, descriptor string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/IllegalAccessError", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%s.%s%s", className, methodName, descriptor))
+	return NewJavaExceptionObject(frame, "java/lang/IllegalAccessError", fmt.Sprintf("%s.%s%s", className, methodName, descriptor))
 }
 
 func NewCloneNotSupportedException(frame *runtime.Frame, classname string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/CloneNotSupportedException", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%s.%s", classname, "clone()"))
+	return NewJavaExceptionObject(frame, "java/lang/CloneNotSupportedException", fmt.Sprintf("%s.%s", classname, "clone()"))
 }
 
 func NewExceptionObject(exClass *method_area.Class, msg string) *heap.Object {
 	return heap.NewExceptionObject(exClass, msg)
 }
+
+func NewClassNotFoundException(frame *runtime.Frame, className string) *heap.Object {
+	return NewJavaExceptionObject(frame, "java/lang/ClassNotFoundException", className)
+}
+
+func NewInstantiationException(frame *runtime.Frame, message string) *heap.Object {
+	return NewJavaExceptionObject(frame, "java/lang/InstantiationException", message)
+}
+
+// NewInternalError VM limitation (ex: native method not implemented yet)
+func NewInternalError(frame *runtime.Frame, message string) *heap.Object {
+	return NewJavaExceptionObject(frame, "java/lang/InternalError", message)
+}
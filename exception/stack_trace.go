@@ -0,0 +1,174 @@
+package exception
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+	"io"
+)
+
+// ============================================================
+// Stack Trace
+// ============================================================
+// backtrace is captured once when exception is thrown (rethrow keeps the original one),
+// it is stored in ExceptionData.StackTrace, top frame first.
+
+// CaptureStackTrace record thread's JVM stack into exception object (if not captured yet)
+// must be called before handler search pops any frame
+func CaptureStackTrace(thread *runtime.Thread, exceptionObj *heap.Object) {
+	data := exceptionObj.InitExceptionData()
+	if data == nil || data.StackTrace != nil {
+		return
+	}
+	data.StackTrace = backtrace(thread.GetFrames())
+}
+
+// FillInStackTrace native Throwable.fillInStackTrace(int), (re)capture thread's JVM stack into exception object
+// frames of fillInStackTrace() and exception's constructors (<init> chain of Throwable subclasses) are skipped,
+// so trace starts at the frame doing `new`
+func FillInStackTrace(thread *runtime.Thread, exceptionObj *heap.Object) {
+	data := exceptionObj.InitExceptionData()
+	if data == nil {
+		return
+	}
+
+	frames := thread.GetFrames()
+	exClass, _ := exceptionObj.Class().(*method_area.Class)
+	skip := 0
+	for _, name := range []string{"fillInStackTrace", "<init>"} {
+		for skip < len(frames) && isThrowableFrame(frames[skip], exClass, name) {
+			skip++
+		}
+	}
+	data.StackTrace = backtrace(frames[skip:])
+}
+
+// isThrowableFrame frame is running method `name` of exception's class (or its super class)
+func isThrowableFrame(frame *runtime.Frame, exClass *method_area.Class, name string) bool {
+	method := frame.Method()
+	return method != nil && exClass != nil && method.Name() == name && method.Class().IsAssignableFrom(exClass)
+}
+
+// backtrace convert frames (top frame first) to stack trace elements
+func backtrace(frames []*runtime.Frame) []*heap.StackTraceElement {
+	trace := make([]*heap.StackTraceElement, 0, len(frames))
+	for _, frame := range frames {
+		method := frame.Method()
+		if method == nil {
+			continue
+		}
+		trace = append(trace, &heap.StackTraceElement{
+			ClassName:  method.Class().JavaName(),
+			MethodName: method.Name(),
+			FileName:   method.Class().SourceFile(),
+			LineNumber: method.GetLineNumber(frame.CurrentPC()),
+		})
+	}
+	return trace
+}
+
+// PrintStackTrace print exception like Throwable.printStackTrace():
+//
+//	java.lang.RuntimeException: boom
+//		at Foo.bar(Foo.java:10)
+//		at Foo.main(Foo.java:3)
+//	Caused by: java.lang.NullPointerException
+//		at Foo.baz(Foo.java:20)
+//		... 2 more
+func PrintStackTrace(w io.Writer, exceptionObj *heap.Object) {
+	fmt.Fprintln(w, ExceptionString(exceptionObj))
+	trace := GetStackTrace(exceptionObj)
+	for _, element := range trace {
+		fmt.Fprintf(w, "\tat %s\n", element)
+	}
+
+	// cause chain, guard against circular reference
+	seen := map[*heap.Object]bool{exceptionObj: true}
+	enclosingTrace := trace
+	for cause := GetCause(exceptionObj); cause != nil; cause = GetCause(cause) {
+		if seen[cause] {
+			fmt.Fprintf(w, "\t[CIRCULAR REFERENCE: %s]\n", ExceptionString(cause))
+			break
+		}
+		seen[cause] = true
+
+		causeTrace := GetStackTrace(cause)
+		inCommon := framesInCommon(causeTrace, enclosingTrace)
+		fmt.Fprintf(w, "Caused by: %s\n", ExceptionString(cause))
+		for _, element := range causeTrace[:len(causeTrace)-inCommon] {
+			fmt.Fprintf(w, "\tat %s\n", element)
+		}
+		if inCommon > 0 {
+			fmt.Fprintf(w, "\t... %d more\n", inCommon)
+		}
+		enclosingTrace = causeTrace
+	}
+}
+
+// framesInCommon count identical frames at the bottom of both traces (elided as "... N more")
+func framesInCommon(trace, enclosingTrace []*heap.StackTraceElement) int {
+	m, n := len(trace)-1, len(enclosingTrace)-1
+	for m >= 0 && n >= 0 && *trace[m] == *enclosingTrace[n] {
+		m--
+		n--
+	}
+	return len(trace) - 1 - m
+}
+
+// ExceptionString same as Throwable.toString(): "java.lang.ArithmeticException: / by zero"
+func ExceptionString(exceptionObj *heap.Object) string {
+	name := "java.lang.Throwable"
+	if exClass, ok := exceptionObj.Class().(*method_area.Class); ok && exClass != nil {
+		name = exClass.JavaName()
+	}
+	message := GetMessage(exceptionObj)
+	if message == "" {
+		return name
+	}
+	return name + ": " + message
+}
+
+// GetStackTrace captured backtrace, nil if exception is never thrown
+func GetStackTrace(exceptionObj *heap.Object) []*heap.StackTraceElement {
+	if data := exceptionObj.GetExceptionData(); data != nil {
+		return data.StackTrace
+	}
+	return nil
+}
+
+// GetMessage VM raised message first, then Throwable.detailMessage (exception created by java code)
+func GetMessage(exceptionObj *heap.Object) string {
+	if data := exceptionObj.GetExceptionData(); data != nil && data.Message != "" {
+		return data.Message
+	}
+	if message, ok := getThrowableField(exceptionObj, "detailMessage", "Ljava/lang/String;").(*heap.Object); ok && message != nil {
+		return heap.GoString(message)
+	}
+	return ""
+}
+
+// GetCause VM raised cause first, then Throwable.cause (cause == this means not initialized)
+func GetCause(exceptionObj *heap.Object) *heap.Object {
+	if data := exceptionObj.GetExceptionData(); data != nil && data.Cause != nil {
+		return data.Cause
+	}
+	if cause, ok := getThrowableField(exceptionObj, "cause", "Ljava/lang/Throwable;").(*heap.Object); ok && cause != nil && cause != exceptionObj {
+		return cause
+	}
+	return nil
+}
+
+// getThrowableField read ref field of Throwable, nil if class doesn't declare it (minimal rt classes)
+// or object has no field slots (VM raised exception, see heap.NewExceptionObject)
+func getThrowableField(exceptionObj *heap.Object, name, descriptor string) interface{} {
+	exClass, ok := exceptionObj.Class().(*method_area.Class)
+	if !ok || exClass == nil {
+		return nil
+	}
+	field := exClass.GetField(name, descriptor, false)
+	if field == nil || field.SlotId() >= uint(len(exceptionObj.Fields())) {
+		return nil
+	}
+	return exceptionObj.GetRefField(field.SlotId())
+}
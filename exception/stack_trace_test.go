@@ -0,0 +1,40 @@
+package exception
+
+import (
+	"bytes"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func element(method string, line int) *heap.StackTraceElement {
+	return &heap.StackTraceElement{ClassName: "Foo", MethodName: method, FileName: "Foo.java", LineNumber: line}
+}
+
+func TestPrintStackTrace_causedBy(t *testing.T) {
+	loader := method_area.NewClassLoader("../test/class")
+	runtimeEx := heap.NewExceptionObject(loader.LoadClass("java/lang/RuntimeException", false), "boom")
+	npe := heap.NewExceptionObject(loader.LoadClass("java/lang/NullPointerException", false), "")
+
+	runtimeEx.GetExceptionData().StackTrace = []*heap.StackTraceElement{element("wrap", 12), element("main", 3)}
+	runtimeEx.GetExceptionData().Cause = npe
+	npe.GetExceptionData().StackTrace = []*heap.StackTraceElement{element("deref", 20), element("wrap", 10), element("main", 3)}
+
+	var out bytes.Buffer
+	PrintStackTrace(&out, runtimeEx)
+	assert.Equal(t, "java.lang.RuntimeException: boom\n"+
+		"\tat Foo.wrap(Foo.java:12)\n"+
+		"\tat Foo.main(Foo.java:3)\n"+
+		"Caused by: java.lang.NullPointerException\n"+
+		"\tat Foo.deref(Foo.java:20)\n"+
+		"\tat Foo.wrap(Foo.java:10)\n"+
+		"\t... 1 more\n", out.String())
+}
+
+func TestStackTraceElement_String(t *testing.T) {
+	assert.Equal(t, "Foo.main(Foo.java:3)", element("main", 3).String())
+	assert.Equal(t, "Foo.main(Foo.java)", element("main", -1).String())
+	assert.Equal(t, "Foo.hash(Native Method)", element("hash", -2).String())
+	assert.Equal(t, "Foo.main(Unknown Source)", (&heap.StackTraceElement{ClassName: "Foo", MethodName: "main", LineNumber: 3}).String())
+}
@@ -1,6 +1,7 @@
 package arrays
 
 import (
+	"github.com/Johnny1110/gogo_jvm/common"
 	"github.com/Johnny1110/gogo_jvm/instructions/base"
 	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
 	"github.com/Johnny1110/gogo_jvm/runtime"
@@ -144,7 +145,7 @@ func (i *IASTORE) Execute(frame *runtime.Frame) {
 	arrRef := stack.PopRef()
 
 	if arrRef == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 
 	arr := arrRef.(*heap.Object)
@@ -164,7 +165,7 @@ func (l *LASTORE) Execute(frame *runtime.Frame) {
 	arrRef := stack.PopRef()
 
 	if arrRef == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 
 	arr := arrRef.(*heap.Object)
@@ -184,7 +185,7 @@ func (f *FASTORE) Execute(frame *runtime.Frame) {
 	arrRef := stack.PopRef()
 
 	if arrRef == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 
 	arr := arrRef.(*heap.Object)
@@ -204,7 +205,7 @@ func (d *DASTORE) Execute(frame *runtime.Frame) {
 	arrRef := stack.PopRef()
 
 	if arrRef == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 
 	arr := arrRef.(*heap.Object)
@@ -224,7 +225,7 @@ func (a *AASTORE) Execute(frame *runtime.Frame) {
 	arrRef := stack.PopRef()
 
 	if arrRef == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 
 	arr := arrRef.(*heap.Object)
@@ -249,7 +250,7 @@ func (b *BASTORE) Execute(frame *runtime.Frame) {
 	arrRef := stack.PopRef()
 
 	if arrRef == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 
 	arr := arrRef.(*heap.Object)
@@ -269,7 +270,7 @@ func (c *CASTORE) Execute(frame *runtime.Frame) {
 	arrRef := stack.PopRef()
 
 	if arrRef == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 
 	arr := arrRef.(*heap.Object)
@@ -289,7 +290,7 @@ func (s *SASTORE) Execute(frame *runtime.Frame) {
 	arrRef := stack.PopRef()
 
 	if arrRef == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 
 	arr := arrRef.(*heap.Object)
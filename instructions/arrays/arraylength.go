@@ -1,6 +1,7 @@
 package arrays
 
 import (
+	"github.com/Johnny1110/gogo_jvm/common"
 	"github.com/Johnny1110/gogo_jvm/instructions/base"
 	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
 	"github.com/Johnny1110/gogo_jvm/runtime"
@@ -20,7 +21,7 @@ func (a *ARRAYLENGTH) Execute(frame *runtime.Frame) {
 
 	// check null
 	if arrRef == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 
 	arr := arrRef.(*heap.Object)
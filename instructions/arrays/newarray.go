@@ -48,13 +48,13 @@ func (n *NEWARRAY) Execute(frame *runtime.Frame) {
 	count := stack.PopInt()
 
 	if count < 0 {
-		panic("java.lang.NegativeArraySizeException")
+		panic(common.NewJavaException("java/lang/NegativeArraySizeException", fmt.Sprintf("%d", count)))
 	}
 
 	classLoader := frame.Method().Class().Loader()
 	if classLoader == nil {
 		fmt.Printf("NEWARRAY error, classLoader not found.")
-		panic("java.lang.ClassLoaderException")
+		panic("NEWARRAY error, classLoader not found")
 	}
 
 	var arr *heap.Object
@@ -112,7 +112,7 @@ func (a *ANEWARRAY) Execute(frame *runtime.Frame) {
 	stack := frame.OperandStack()
 	count := stack.PopInt() // array size
 	if count < 0 {
-		panic(common.NewJavaException("", "java.lang.NegativeArraySizeException"))
+		panic(common.NewJavaException("java/lang/NegativeArraySizeException", fmt.Sprintf("%d", count)))
 	}
 
 	// get type from rtcp
@@ -183,8 +183,7 @@ func popAndCheckCounts(stack *runtime.OperandStack, dimensions int) []int32 {
 	for i := dimensions - 1; i >= 0; i-- {
 		counts[i] = stack.PopInt()
 		if counts[i] < 0 {
-			// should not happen
-			panic("java.lang.NegativeArraySizeException")
+			panic(common.NewJavaException("java/lang/NegativeArraySizeException", fmt.Sprintf("%d", counts[i])))
 		}
 	}
 
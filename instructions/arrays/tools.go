@@ -1,6 +1,7 @@
 package arrays
 
 import (
+	"github.com/Johnny1110/gogo_jvm/common"
 	"github.com/Johnny1110/gogo_jvm/runtime"
 	"github.com/Johnny1110/gogo_jvm/runtime/heap"
 )
@@ -11,7 +12,7 @@ func checkArrayAndIndex(stack *runtime.OperandStack) (*heap.Object, int32) {
 	arrRef := stack.PopRef()
 
 	if arrRef == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 
 	arr := arrRef.(*heap.Object)
@@ -1,5 +1,10 @@
 package base
 
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+)
+
 type BytecodeReader struct {
 	code []byte // bytecode (from Code Attribute)
 	pc   int    // program counter
@@ -68,6 +73,58 @@ func (br *BytecodeReader) ReadInt32s(count int32) []int32 {
 // SkipPadding skip padding bytes
 // tableswitch and lookupswitch need align 4 bytes, this is for efficiency (access aligned data is fast)
 // ex: if opcodes at index 5, we need skip 3 bytes, start from index 8.
+// warning: alignment is relative to the start of method code (code[0]), not the class file,
+// so br.code must be the whole Code attribute and br.pc the offset inside it.
 func (br *BytecodeReader) SkipPadding() {
 	br.pc += (4 - br.pc%4) % 4
 }
+
+// ============ Jump Table (tableswitch / lookupswitch) ============
+
+// ReadJumpTable read tableswitch operands (after opcode)
+// layout: <0-3 padding> default low high offsets[high-low+1]
+// all offsets are relative to the address of the tableswitch opcode
+func (br *BytecodeReader) ReadJumpTable() (defaultOffset, low, high int32, offsets []int32) {
+	br.SkipPadding()
+	defaultOffset = br.ReadInt32()
+	low = br.ReadInt32()
+	high = br.ReadInt32()
+	if low > high {
+		panic(common.NewJavaException("java/lang/VerifyError", fmt.Sprintf("tableswitch low(%d) > high(%d)", low, high)))
+	}
+	// high - low + 1 overflows int32 (ex: low = MIN_VALUE, high = MAX_VALUE), count in int64
+	count := int64(high) - int64(low) + 1
+	br.checkRemaining("tableswitch", count, 4)
+	offsets = br.ReadInt32s(int32(count))
+	return
+}
+
+// ReadLookupTable read lookupswitch operands (after opcode)
+// layout: <0-3 padding> default npairs [match offset]*npairs
+// matches are sorted ascending (JVMS §6.5 lookupswitch), so caller could binary search.
+func (br *BytecodeReader) ReadLookupTable() (defaultOffset int32, matches, offsets []int32) {
+	br.SkipPadding()
+	defaultOffset = br.ReadInt32()
+	npairs := br.ReadInt32()
+	if npairs < 0 {
+		panic(common.NewJavaException("java/lang/VerifyError", fmt.Sprintf("lookupswitch npairs(%d) < 0", npairs)))
+	}
+	br.checkRemaining("lookupswitch", int64(npairs), 8)
+	matches = make([]int32, npairs)
+	offsets = make([]int32, npairs)
+	for i := int32(0); i < npairs; i++ {
+		matches[i] = br.ReadInt32()
+		offsets[i] = br.ReadInt32()
+	}
+	return
+}
+
+// checkRemaining count entries (size bytes each) must be inside code, check before allocating the table,
+// otherwise a broken count (up to 2^32) makes a huge allocation
+func (br *BytecodeReader) checkRemaining(inst string, count, size int64) {
+	remaining := int64(len(br.code) - br.pc)
+	if count*size > remaining {
+		panic(common.NewJavaException("java/lang/VerifyError",
+			fmt.Sprintf("%s has %d entries, but only %d bytes left in code", inst, count, remaining)))
+	}
+}
@@ -0,0 +1,60 @@
+package base
+
+import (
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func int32Bytes(values ...int32) []byte {
+	var b []byte
+	for _, v := range values {
+		b = append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+	return b
+}
+
+// expectVerifyError 表格大小超過剩下的 code 要在配置記憶體之前拋出 VerifyError
+func expectVerifyError(t *testing.T, read func()) {
+	t.Helper()
+	defer func() {
+		ex, ok := recover().(*common.JavaException)
+		if assert.True(t, ok) {
+			assert.Equal(t, "java/lang/VerifyError", ex.ClassName)
+		}
+	}()
+	read()
+}
+
+func Test_ReadJumpTable(t *testing.T) {
+	// opcode at 0, 3 bytes padding, default low high offsets[2]
+	code := append([]byte{0xaa, 0, 0, 0}, int32Bytes(30, 5, 6, 10, 20)...)
+	reader := &BytecodeReader{}
+	reader.Reset(code, 1)
+	defaultOffset, low, high, offsets := reader.ReadJumpTable()
+	assert.Equal(t, []int32{30, 5, 6}, []int32{defaultOffset, low, high})
+	assert.Equal(t, []int32{10, 20}, offsets)
+	assert.Equal(t, len(code), reader.PC())
+
+	// high - low + 1 在 int32 溢位
+	reader.Reset(append([]byte{0xaa, 0, 0, 0}, int32Bytes(0, -2147483648, 2147483647)...), 1)
+	expectVerifyError(t, func() { reader.ReadJumpTable() })
+
+	// offsets 超出 code
+	reader.Reset(append([]byte{0xaa, 0, 0, 0}, int32Bytes(0, 0, 1000000, 1, 2)...), 1)
+	expectVerifyError(t, func() { reader.ReadJumpTable() })
+}
+
+func Test_ReadLookupTable(t *testing.T) {
+	code := append([]byte{0, 0xab, 0, 0}, int32Bytes(30, 2, -1, 10, 7, 20)...)
+	reader := &BytecodeReader{}
+	reader.Reset(code, 2)
+	defaultOffset, matches, offsets := reader.ReadLookupTable()
+	assert.Equal(t, int32(30), defaultOffset)
+	assert.Equal(t, []int32{-1, 7}, matches)
+	assert.Equal(t, []int32{10, 20}, offsets)
+
+	// npairs 超出 code
+	reader.Reset(append([]byte{0xab, 0, 0, 0}, int32Bytes(0, 2147483647)...), 1)
+	expectVerifyError(t, func() { reader.ReadLookupTable() })
+}
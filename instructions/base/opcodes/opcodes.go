@@ -134,8 +134,43 @@ const (
 	DNEG = 0x77
 	IINC = 0x84
 
+	// ============ Bitwise / Shift ============
+	ISHL  = 0x78
+	LSHL  = 0x79
+	ISHR  = 0x7A
+	LSHR  = 0x7B
+	IUSHR = 0x7C
+	LUSHR = 0x7D
+	IAND  = 0x7E
+	LAND  = 0x7F
+	IOR   = 0x80
+	LOR   = 0x81
+	IXOR  = 0x82
+	LXOR  = 0x83
+
+	// ============ Conversions ============
+	I2L = 0x85
+	I2F = 0x86
+	I2D = 0x87
+	L2I = 0x88
+	L2F = 0x89
+	L2D = 0x8A
+	F2I = 0x8B
+	F2L = 0x8C
+	F2D = 0x8D
+	D2I = 0x8E
+	D2L = 0x8F
+	D2F = 0x90
+	I2B = 0x91
+	I2C = 0x92
+	I2S = 0x93
+
 	// ============ Comparisons / Branch ============
 	LCMP      = 0x94
+	FCMPL     = 0x95
+	FCMPG     = 0x96
+	DCMPL     = 0x97
+	DCMPG     = 0x98
 	IFEQ      = 0x99
 	IFNE      = 0x9A
 	IFLT      = 0x9B
@@ -154,6 +189,17 @@ const (
 	IFNULL    = 0xC6
 	IFNONNULL = 0xC7
 
+	// ============ Switch ============
+	TABLESWITCH  = 0xAA
+	LOOKUPSWITCH = 0xAB
+
+	// ============ Subroutine / Extended ============
+	JSR    = 0xA8
+	RET    = 0xA9
+	WIDE   = 0xC4
+	GOTO_W = 0xC8
+	JSR_W  = 0xC9
+
 	// ============ Return ============
 	IRETURN = 0xAC
 	LRETURN = 0xAD
@@ -171,6 +217,7 @@ const (
 	INVOKESPECIAL   = 0xB7 // v0.2.5
 	INVOKESTATIC    = 0xB8
 	INVOKEINTERFACE = 0xB9 // Future
+	INVOKEDYNAMIC   = 0xBA
 	NEW             = 0xBB
 	NEWARRAY        = 0xBC // v0.2.6
 	ANEWARRAY       = 0xBD // v0.2.8
@@ -179,6 +226,8 @@ const (
 	INSTANCEOF      = 0xC1 // v0.2.8
 	MULTIANEWARRAY  = 0xC5 // TODO
 	ATHROW          = 0xBF // v0.2.10
+	MONITORENTER    = 0xC2
+	MONITOREXIT     = 0xC3
 
 	// ============ Extended ============
 	LDC    = 0x12
@@ -235,13 +284,31 @@ var OpcodeNames = map[uint8]string{
 	INEG: "ineg", LNEG: "lneg", FNEG: "fneg", DNEG: "dneg",
 	IINC: "iinc",
 
+	// Bitwise / Shift
+	ISHL: "ishl", LSHL: "lshl", ISHR: "ishr", LSHR: "lshr", IUSHR: "iushr", LUSHR: "lushr",
+	IAND: "iand", LAND: "land", IOR: "ior", LOR: "lor", IXOR: "ixor", LXOR: "lxor",
+
+	// Conversions
+	I2L: "i2l", I2F: "i2f", I2D: "i2d",
+	L2I: "l2i", L2F: "l2f", L2D: "l2d",
+	F2I: "f2i", F2L: "f2l", F2D: "f2d",
+	D2I: "d2i", D2L: "d2l", D2F: "d2f",
+	I2B: "i2b", I2C: "i2c", I2S: "i2s",
+
 	// Comparisons / Branch
-	LCMP: "lcmp", IFEQ: "ifeq", IFNE: "ifne", IFLT: "iflt", IFGE: "ifge", IFGT: "ifgt", IFLE: "ifle",
+	LCMP: "lcmp", FCMPL: "fcmpl", FCMPG: "fcmpg", DCMPL: "dcmpl", DCMPG: "dcmpg",
+	IFEQ: "ifeq", IFNE: "ifne", IFLT: "iflt", IFGE: "ifge", IFGT: "ifgt", IFLE: "ifle",
 	IF_ICMPEQ: "if_icmpeq", IF_ICMPNE: "if_icmpne", IF_ICMPLT: "if_icmplt",
 	IF_ICMPGE: "if_icmpge", IF_ICMPGT: "if_icmpgt", IF_ICMPLE: "if_icmple",
 	IF_ACMPEQ: "if_acmpeq", IF_ACMPNE: "if_acmpne",
 	GOTO: "goto", IFNULL: "ifnull", IFNONNULL: "ifnonnull",
 
+	// Switch
+	TABLESWITCH: "tableswitch", LOOKUPSWITCH: "lookupswitch",
+
+	// Subroutine / Extended
+	JSR: "jsr", RET: "ret", WIDE: "wide", GOTO_W: "goto_w", JSR_W: "jsr_w",
+
 	// Return
 	IRETURN: "ireturn", LRETURN: "lreturn", FRETURN: "freturn",
 	DRETURN: "dreturn", ARETURN: "areturn", RETURN: "return",
@@ -250,11 +317,11 @@ var OpcodeNames = map[uint8]string{
 	GETSTATIC: "getstatic", PUTSTATIC: "putstatic",
 	GETFIELD: "getfield", PUTFIELD: "putfield",
 	INVOKEVIRTUAL: "invokevirtual", INVOKESPECIAL: "invokespecial",
-	INVOKESTATIC: "invokestatic", INVOKEINTERFACE: "invokeinterface",
+	INVOKESTATIC: "invokestatic", INVOKEINTERFACE: "invokeinterface", INVOKEDYNAMIC: "invokedynamic",
 	NEW:      "new",
 	NEWARRAY: "newarray", ANEWARRAY: "anewarray", MULTIANEWARRAY: "multianewarray", ARRAYLENGTH: "arraylength",
 	INSTANCEOF: "instanceof", CHECKCAST: "checkcast",
-	ATHROW: "athrow",
+	ATHROW: "athrow", MONITORENTER: "monitorenter", MONITOREXIT: "monitorexit",
 
 	// Extended
 	LDC: "ldc", LDC_W: "ldc_w", LDC2_W: "ldc2_w",
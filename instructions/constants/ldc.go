@@ -71,8 +71,11 @@ func (l *LDC2_W) Execute(frame *runtime.Frame) {
 		stack.PushLong(val)
 	case float64:
 		stack.PushDouble(val)
+	case *method_area.DynamicConstantRef:
+		// Java 11+ condy (long / double typed)
+		panic(val.UnsupportedError())
 	default:
-		panic(common.NewJavaException(frame.Method().Class().Name(), "unknown constant value: "+fmt.Sprint(val)))
+		panic(common.NewJavaException("java/lang/ClassFormatError", "ldc2_w with unknown constant value: "+fmt.Sprint(val)))
 	}
 }
 
@@ -88,6 +91,7 @@ func (l *LDC2_W) Opcode() uint8 {
 // - float32 -> push
 // - string -> v0.2.9 supported, using internString() create String Object
 // - *ClassRef -> for Foo.class TODO: Reflection
+// - *DynamicConstantRef -> Java 11+ condy, not supported (UnsupportedClassVersionError)
 func _ldc(frame *runtime.Frame, index uint) {
 	rtcp := frame.Method().Class().ConstantPool()
 	classLoader := frame.Method().Class().Loader()
@@ -122,7 +126,10 @@ func _ldc(frame *runtime.Frame, index uint) {
 		}
 
 		stack.PushRef(jClass)
+	case *method_area.DynamicConstantRef:
+		// Java 11+ condy: bootstrap method result can not be computed by gogo-jvm
+		panic(val.UnsupportedError())
 	default:
-		panic("java.lang.ClassFormatError: ldc with unknown constant type")
+		panic(common.NewJavaException("java/lang/ClassFormatError", "ldc with unknown constant type"))
 	}
 }
@@ -24,6 +24,26 @@ func (g *GOTO) Opcode() uint8 {
 	return 0xA7
 }
 
+// GOTO_W jump without condition (wide offset)
+// opcodes = 0xC8
+// operands: 4 bytes (signed)
+// javac only emit it when method is too large for 2 bytes offset
+type GOTO_W struct {
+	offset int
+}
+
+func (g *GOTO_W) FetchOperands(reader *base.BytecodeReader) {
+	g.offset = int(reader.ReadInt32())
+}
+
+func (g *GOTO_W) Execute(frame *runtime.Frame) {
+	branch(frame, g.offset)
+}
+
+func (g *GOTO_W) Opcode() uint8 {
+	return opcodes.GOTO_W
+}
+
 // ============================================================
 // IF_ICMP: compare 2 int and jump
 // ============================================================
@@ -330,3 +350,92 @@ func (i *LCMP) Execute(frame *runtime.Frame) {
 func (i *LCMP) Opcode() uint8 {
 	return opcodes.LCMP
 }
+
+// ============================================================
+// FCMP / DCMP: float & double compare
+// ============================================================
+// push 1 / 0 / -1 like LCMP, the difference is NaN (unordered):
+// - xCMPL: NaN -> -1
+// - xCMPG: NaN -> 1
+// javac choose the variant that makes NaN fail the condition:
+//   if (a < b)  ->  fcmpg + ifge   (NaN -> 1 -> jump over if block)
+//   if (a > b)  ->  fcmpl + ifle   (NaN -> -1 -> jump over if block)
+// +0.0 and -0.0 are equal (Go `==` on float follows IEEE 754 too)
+
+// FCMPL compare 2 float, NaN -> -1
+// opcodes = 0x95
+type FCMPL struct{ base.NoOperandsInstruction }
+
+func (f *FCMPL) Execute(frame *runtime.Frame) {
+	_fcmp(frame, false)
+}
+
+func (f *FCMPL) Opcode() uint8 {
+	return opcodes.FCMPL
+}
+
+// FCMPG compare 2 float, NaN -> 1
+// opcodes = 0x96
+type FCMPG struct{ base.NoOperandsInstruction }
+
+func (f *FCMPG) Execute(frame *runtime.Frame) {
+	_fcmp(frame, true)
+}
+
+func (f *FCMPG) Opcode() uint8 {
+	return opcodes.FCMPG
+}
+
+// DCMPL compare 2 double, NaN -> -1
+// opcodes = 0x97
+type DCMPL struct{ base.NoOperandsInstruction }
+
+func (d *DCMPL) Execute(frame *runtime.Frame) {
+	_dcmp(frame, false)
+}
+
+func (d *DCMPL) Opcode() uint8 {
+	return opcodes.DCMPL
+}
+
+// DCMPG compare 2 double, NaN -> 1
+// opcodes = 0x98
+type DCMPG struct{ base.NoOperandsInstruction }
+
+func (d *DCMPG) Execute(frame *runtime.Frame) {
+	_dcmp(frame, true)
+}
+
+func (d *DCMPG) Opcode() uint8 {
+	return opcodes.DCMPG
+}
+
+func _fcmp(frame *runtime.Frame, gFlag bool) {
+	opstack := frame.OperandStack()
+	v2 := opstack.PopFloat()
+	v1 := opstack.PopFloat()
+	opstack.PushInt(_cmpFloating(float64(v1), float64(v2), gFlag))
+}
+
+func _dcmp(frame *runtime.Frame, gFlag bool) {
+	opstack := frame.OperandStack()
+	v2 := opstack.PopDouble()
+	v1 := opstack.PopDouble()
+	opstack.PushInt(_cmpFloating(v1, v2, gFlag))
+}
+
+// _cmpFloating float32 -> float64 is exact, so fcmp can share it
+func _cmpFloating(v1, v2 float64, gFlag bool) int32 {
+	if v1 > v2 {
+		return 1
+	} else if v1 == v2 {
+		return 0
+	} else if v1 < v2 {
+		return -1
+	} else if gFlag {
+		// at least one is NaN
+		return 1
+	} else {
+		return -1
+	}
+}
@@ -2,6 +2,7 @@ package control
 
 import (
 	"github.com/Johnny1110/gogo_jvm/instructions/base"
+	"github.com/Johnny1110/gogo_jvm/instructions/references"
 	"github.com/Johnny1110/gogo_jvm/runtime"
 )
 
@@ -11,9 +12,14 @@ import (
 type RETURN struct{ base.NoOperandsInstruction }
 
 func (r *RETURN) Execute(frame *runtime.Frame) {
+	if !exitSynchronized(frame) {
+		return
+	}
 	currentThread := frame.Thread()
 	// void method don't have control value, just pop frame
 	currentThread.PopFrame()
+	// <clinit> returned: class initialized
+	references.FinishClinit(frame)
 }
 
 func (r *RETURN) Opcode() uint8 {
@@ -28,6 +34,9 @@ type IRETURN struct{ base.NoOperandsInstruction }
 // Execute IRETURN
 // move int from current frame's stack to caller frame's stack
 func (i *IRETURN) Execute(frame *runtime.Frame) {
+	if !exitSynchronized(frame) {
+		return
+	}
 	currentThread := frame.Thread()
 	currentFrame := currentThread.PopFrame()       // pop current frame
 	callerFrame := currentThread.TopFrame()        // peek caller/invoker frame
@@ -44,6 +53,9 @@ func (r *IRETURN) Opcode() uint8 {
 type LRETURN struct{ base.NoOperandsInstruction }
 
 func (l *LRETURN) Execute(frame *runtime.Frame) {
+	if !exitSynchronized(frame) {
+		return
+	}
 	thread := frame.Thread()
 	currentFrame := thread.PopFrame()
 	invokerFrame := thread.TopFrame()
@@ -60,6 +72,9 @@ func (r *LRETURN) Opcode() uint8 {
 type FRETURN struct{ base.NoOperandsInstruction }
 
 func (f *FRETURN) Execute(frame *runtime.Frame) {
+	if !exitSynchronized(frame) {
+		return
+	}
 	thread := frame.Thread()
 	currentFrame := thread.PopFrame()
 	invokerFrame := thread.TopFrame()
@@ -76,6 +91,9 @@ func (r *FRETURN) Opcode() uint8 {
 type DRETURN struct{ base.NoOperandsInstruction }
 
 func (d *DRETURN) Execute(frame *runtime.Frame) {
+	if !exitSynchronized(frame) {
+		return
+	}
 	thread := frame.Thread()
 	currentFrame := thread.PopFrame()
 	invokerFrame := thread.TopFrame()
@@ -92,6 +110,9 @@ func (r *DRETURN) Opcode() uint8 {
 type ARETURN struct{ base.NoOperandsInstruction }
 
 func (a *ARETURN) Execute(frame *runtime.Frame) {
+	if !exitSynchronized(frame) {
+		return
+	}
 	thread := frame.Thread()
 	currentFrame := thread.PopFrame()
 	invokerFrame := thread.TopFrame()
@@ -102,3 +123,14 @@ func (a *ARETURN) Execute(frame *runtime.Frame) {
 func (r *ARETURN) Opcode() uint8 {
 	return 0xB0
 }
+
+// exitSynchronized release ACC_SYNCHRONIZED method's monitor before return
+// if current thread doesn't hold the monitor anymore -> IllegalMonitorStateException (JVMS §6.5 ireturn)
+// return false if exception thrown, caller should stop returning
+func exitSynchronized(frame *runtime.Frame) bool {
+	if frame.ExitSyncMonitor() {
+		return true
+	}
+	frame.JavaThrow(references.NewIllegalMonitorStateException(frame, "current thread is not owner"))
+	return false
+}
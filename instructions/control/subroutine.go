@@ -0,0 +1,81 @@
+package control
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+
+	"github.com/Johnny1110/gogo_jvm/instructions/base"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/rtcore"
+)
+
+// ============================================================
+// JSR / RET: subroutine (legacy finally)
+// ============================================================
+// class file version < 50 (before Java 6) compile `finally` like:
+//   jsr L_finally      // push returnAddress, jump
+//   ...
+//   L_finally:
+//   astore_n           // save returnAddress to local var
+//   ...                // finally block
+//   ret n              // jump back to returnAddress
+//
+// Java 7+ (version >= 51) forbid jsr/ret, javac inline finally block instead.
+// returnAddress is rtcore.ReturnAddress, it goes through astore as a Ref.
+
+// JSR jump to subroutine
+// opcodes = 0xA8
+// operands: 2 bytes (signed)
+type JSR struct{ base.BranchInstruction }
+
+func (j *JSR) Execute(frame *runtime.Frame) {
+	_jsr(frame, j.Offset)
+}
+
+func (j *JSR) Opcode() uint8 {
+	return opcodes.JSR
+}
+
+// JSR_W jump to subroutine (wide offset)
+// opcodes = 0xC9
+// operands: 4 bytes (signed)
+type JSR_W struct {
+	offset int
+}
+
+func (j *JSR_W) FetchOperands(reader *base.BytecodeReader) {
+	j.offset = int(reader.ReadInt32())
+}
+
+func (j *JSR_W) Execute(frame *runtime.Frame) {
+	_jsr(frame, j.offset)
+}
+
+func (j *JSR_W) Opcode() uint8 {
+	return opcodes.JSR_W
+}
+
+// RET return from subroutine
+// opcodes = 0xA9
+// operands: 1 byte local var index (2 bytes with wide prefix)
+type RET struct{ base.Index8Instruction }
+
+func (r *RET) Execute(frame *runtime.Frame) {
+	ref := frame.LocalVars().GetRef(r.Index)
+	addr, ok := ref.(rtcore.ReturnAddress)
+	if !ok {
+		panic(common.NewJavaException("java/lang/VerifyError", fmt.Sprintf("ret on local[%d] is not a returnAddress (%T)", r.Index, ref)))
+	}
+	frame.SetNextPC(int(addr))
+}
+
+func (r *RET) Opcode() uint8 {
+	return opcodes.RET
+}
+
+// _jsr returnAddress = the instruction right after jsr/jsr_w, which is nextPC now
+func _jsr(frame *runtime.Frame, offset int) {
+	frame.OperandStack().PushRef(rtcore.ReturnAddress(frame.NextPC()))
+	branch(frame, offset)
+}
@@ -0,0 +1,79 @@
+package control
+
+import (
+	"sort"
+
+	"github.com/Johnny1110/gogo_jvm/instructions/base"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+)
+
+// ============================================================
+// SWITCH: tableswitch / lookupswitch
+// ============================================================
+// javac choose by case density:
+// - cases continuous (or nearly)  -> tableswitch  (O(1) index)
+// - cases sparse                  -> lookupswitch (sorted pairs, O(log n))
+// String switch = lookupswitch on hashCode() + equals() + tableswitch
+// enum switch   = tableswitch on $SwitchMap$[ordinal()]
+//
+// both have variable length operands with 0~3 padding bytes,
+// offsets are relative to the switch opcode address (same as branch).
+
+// TABLESWITCH jump by index
+// opcodes = 0xAA
+// operands: <0-3 padding> default(int32) low(int32) high(int32) offsets(int32 * (high-low+1))
+type TABLESWITCH struct {
+	defaultOffset int32
+	low           int32
+	high          int32
+	jumpOffsets   []int32
+}
+
+func (t *TABLESWITCH) FetchOperands(reader *base.BytecodeReader) {
+	t.defaultOffset, t.low, t.high, t.jumpOffsets = reader.ReadJumpTable()
+}
+
+func (t *TABLESWITCH) Execute(frame *runtime.Frame) {
+	index := frame.OperandStack().PopInt()
+
+	var offset int
+	if index >= t.low && index <= t.high {
+		offset = int(t.jumpOffsets[index-t.low])
+	} else {
+		offset = int(t.defaultOffset)
+	}
+	branch(frame, offset)
+}
+
+func (t *TABLESWITCH) Opcode() uint8 {
+	return opcodes.TABLESWITCH
+}
+
+// LOOKUPSWITCH jump by match key
+// opcodes = 0xAB
+// operands: <0-3 padding> default(int32) npairs(int32) [match(int32) offset(int32)] * npairs
+type LOOKUPSWITCH struct {
+	defaultOffset int32
+	matches       []int32 // sorted ascending
+	jumpOffsets   []int32
+}
+
+func (l *LOOKUPSWITCH) FetchOperands(reader *base.BytecodeReader) {
+	l.defaultOffset, l.matches, l.jumpOffsets = reader.ReadLookupTable()
+}
+
+func (l *LOOKUPSWITCH) Execute(frame *runtime.Frame) {
+	key := frame.OperandStack().PopInt()
+
+	offset := int(l.defaultOffset)
+	i := sort.Search(len(l.matches), func(i int) bool { return l.matches[i] >= key })
+	if i < len(l.matches) && l.matches[i] == key {
+		offset = int(l.jumpOffsets[i])
+	}
+	branch(frame, offset)
+}
+
+func (l *LOOKUPSWITCH) Opcode() uint8 {
+	return opcodes.LOOKUPSWITCH
+}
@@ -0,0 +1,293 @@
+package conversions
+
+import (
+	"math"
+
+	"github.com/Johnny1110/gogo_jvm/instructions/base"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+)
+
+// ============================================================
+// Type Conversion Instructions (JVMS §6.5 i2l ~ i2s)
+// ============================================================
+// widening:  i2l, i2f, i2d, l2f, l2d, f2d     (never throw, may lose precision on l2f/l2d/i2f)
+// narrowing: l2i, f2i, f2l, d2i, d2l, d2f     (NaN -> 0, out of range -> saturate to MIN/MAX)
+// int narrowing: i2b, i2c, i2s                (truncate then sign/zero extend back to int)
+//
+// Why not just int32(f) in Go?
+// Go spec: converting an out of range float to int is implementation-defined,
+// but JVMS requires round toward zero and saturate, so we handle edge cases manually.
+
+// ============================================================
+// int -> X
+// ============================================================
+
+// I2L int to long
+// opcodes = 0x85
+type I2L struct{ base.NoOperandsInstruction }
+
+func (i *I2L) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopInt()
+	stack.PushLong(int64(val))
+}
+
+func (i *I2L) Opcode() uint8 {
+	return opcodes.I2L
+}
+
+// I2F int to float
+// opcodes = 0x86
+type I2F struct{ base.NoOperandsInstruction }
+
+func (i *I2F) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopInt()
+	stack.PushFloat(float32(val))
+}
+
+func (i *I2F) Opcode() uint8 {
+	return opcodes.I2F
+}
+
+// I2D int to double
+// opcodes = 0x87
+type I2D struct{ base.NoOperandsInstruction }
+
+func (i *I2D) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopInt()
+	stack.PushDouble(float64(val))
+}
+
+func (i *I2D) Opcode() uint8 {
+	return opcodes.I2D
+}
+
+// ============================================================
+// long -> X
+// ============================================================
+
+// L2I long to int
+// opcodes = 0x88
+// keep low 32 bits only
+type L2I struct{ base.NoOperandsInstruction }
+
+func (l *L2I) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopLong()
+	stack.PushInt(int32(val))
+}
+
+func (l *L2I) Opcode() uint8 {
+	return opcodes.L2I
+}
+
+// L2F long to float
+// opcodes = 0x89
+type L2F struct{ base.NoOperandsInstruction }
+
+func (l *L2F) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopLong()
+	stack.PushFloat(float32(val))
+}
+
+func (l *L2F) Opcode() uint8 {
+	return opcodes.L2F
+}
+
+// L2D long to double
+// opcodes = 0x8A
+type L2D struct{ base.NoOperandsInstruction }
+
+func (l *L2D) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopLong()
+	stack.PushDouble(float64(val))
+}
+
+func (l *L2D) Opcode() uint8 {
+	return opcodes.L2D
+}
+
+// ============================================================
+// float -> X
+// ============================================================
+
+// F2I float to int
+// opcodes = 0x8B
+type F2I struct{ base.NoOperandsInstruction }
+
+func (f *F2I) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopFloat()
+	stack.PushInt(toJInt(float64(val)))
+}
+
+func (f *F2I) Opcode() uint8 {
+	return opcodes.F2I
+}
+
+// F2L float to long
+// opcodes = 0x8C
+type F2L struct{ base.NoOperandsInstruction }
+
+func (f *F2L) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopFloat()
+	stack.PushLong(toJLong(float64(val)))
+}
+
+func (f *F2L) Opcode() uint8 {
+	return opcodes.F2L
+}
+
+// F2D float to double
+// opcodes = 0x8D
+type F2D struct{ base.NoOperandsInstruction }
+
+func (f *F2D) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopFloat()
+	stack.PushDouble(float64(val))
+}
+
+func (f *F2D) Opcode() uint8 {
+	return opcodes.F2D
+}
+
+// ============================================================
+// double -> X
+// ============================================================
+
+// D2I double to int
+// opcodes = 0x8E
+type D2I struct{ base.NoOperandsInstruction }
+
+func (d *D2I) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopDouble()
+	stack.PushInt(toJInt(val))
+}
+
+func (d *D2I) Opcode() uint8 {
+	return opcodes.D2I
+}
+
+// D2L double to long
+// opcodes = 0x8F
+type D2L struct{ base.NoOperandsInstruction }
+
+func (d *D2L) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopDouble()
+	stack.PushLong(toJLong(val))
+}
+
+func (d *D2L) Opcode() uint8 {
+	return opcodes.D2L
+}
+
+// D2F double to float
+// opcodes = 0x90
+// IEEE 754 round to nearest, too large -> Infinity, NaN -> NaN
+type D2F struct{ base.NoOperandsInstruction }
+
+func (d *D2F) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopDouble()
+	stack.PushFloat(float32(val))
+}
+
+func (d *D2F) Opcode() uint8 {
+	return opcodes.D2F
+}
+
+// ============================================================
+// int -> byte / char / short
+// ============================================================
+// result is still an int on the op-stack:
+// - i2b: keep low 8 bits, sign extend  (200 -> -56)
+// - i2c: keep low 16 bits, zero extend (-1 -> 65535)
+// - i2s: keep low 16 bits, sign extend (40000 -> -25536)
+
+// I2B int to byte
+// opcodes = 0x91
+type I2B struct{ base.NoOperandsInstruction }
+
+func (i *I2B) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopInt()
+	stack.PushInt(int32(int8(val)))
+}
+
+func (i *I2B) Opcode() uint8 {
+	return opcodes.I2B
+}
+
+// I2C int to char
+// opcodes = 0x92
+type I2C struct{ base.NoOperandsInstruction }
+
+func (i *I2C) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopInt()
+	stack.PushInt(int32(uint16(val)))
+}
+
+func (i *I2C) Opcode() uint8 {
+	return opcodes.I2C
+}
+
+// I2S int to short
+// opcodes = 0x93
+type I2S struct{ base.NoOperandsInstruction }
+
+func (i *I2S) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	val := stack.PopInt()
+	stack.PushInt(int32(int16(val)))
+}
+
+func (i *I2S) Opcode() uint8 {
+	return opcodes.I2S
+}
+
+// ============================================================
+// Helpers (JVMS §2.8.3 narrowing rules)
+// ============================================================
+
+// toJInt convert float/double to int with JVMS semantics
+// - NaN -> 0
+// - >= 2^31 -> Integer.MAX_VALUE, <= -2^31 -> Integer.MIN_VALUE
+// - otherwise round toward zero
+// float32 -> float64 is exact, so f2i shares this helper
+func toJInt(val float64) int32 {
+	switch {
+	case math.IsNaN(val):
+		return 0
+	case val >= math.MaxInt32:
+		return math.MaxInt32
+	case val <= math.MinInt32:
+		return math.MinInt32
+	default:
+		return int32(val)
+	}
+}
+
+// toJLong convert float/double to long with JVMS semantics
+// float64(math.MaxInt64) is exactly 2^63, so `>=` catches every value out of range
+func toJLong(val float64) int64 {
+	switch {
+	case math.IsNaN(val):
+		return 0
+	case val >= math.MaxInt64:
+		return math.MaxInt64
+	case val <= math.MinInt64:
+		return math.MinInt64
+	default:
+		return int64(val)
+	}
+}
@@ -0,0 +1,94 @@
+package extended
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+
+	"github.com/Johnny1110/gogo_jvm/instructions/base"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/instructions/control"
+	"github.com/Johnny1110/gogo_jvm/instructions/loads"
+	"github.com/Johnny1110/gogo_jvm/instructions/math"
+	"github.com/Johnny1110/gogo_jvm/instructions/stores"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+)
+
+// ============================================================
+// WIDE: extend local var index to 2 bytes
+// ============================================================
+// when a method has more than 256 local vars, 1 byte index is not enough:
+//   wide iload  <index16>
+//   wide iinc   <index16> <const16>
+//
+// WIDE decode the next opcode itself, and build the modified instruction
+// with wider operands, then delegate Execute to it.
+
+// WIDE opcodes = 0xC4
+type WIDE struct {
+	modifiedInstruction base.Instruction
+}
+
+func (w *WIDE) FetchOperands(reader *base.BytecodeReader) {
+	opcode := reader.ReadUint8()
+	switch opcode {
+	case opcodes.ILOAD:
+		inst := &loads.ILOAD{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.LLOAD:
+		inst := &loads.LLOAD{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.FLOAD:
+		inst := &loads.FLOAD{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.DLOAD:
+		inst := &loads.DLOAD{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.ALOAD:
+		inst := &loads.ALOAD{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.ISTORE:
+		inst := &stores.ISTORE{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.LSTORE:
+		inst := &stores.LSTORE{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.FSTORE:
+		inst := &stores.FSTORE{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.DSTORE:
+		inst := &stores.DSTORE{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.ASTORE:
+		inst := &stores.ASTORE{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.RET:
+		inst := &control.RET{}
+		inst.Index = uint(reader.ReadUint16())
+		w.modifiedInstruction = inst
+	case opcodes.IINC:
+		inst := &math.IINC{}
+		inst.Index = uint(reader.ReadUint16())
+		inst.Const = int32(reader.ReadInt16())
+		w.modifiedInstruction = inst
+	default:
+		panic(common.NewJavaException("java/lang/VerifyError", fmt.Sprintf("wide can not modify opcode 0x%02X", opcode)))
+	}
+}
+
+func (w *WIDE) Execute(frame *runtime.Frame) {
+	w.modifiedInstruction.Execute(frame)
+}
+
+func (w *WIDE) Opcode() uint8 {
+	return opcodes.WIDE
+}
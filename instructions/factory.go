@@ -7,6 +7,8 @@ import (
 	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
 	"github.com/Johnny1110/gogo_jvm/instructions/constants"
 	"github.com/Johnny1110/gogo_jvm/instructions/control"
+	"github.com/Johnny1110/gogo_jvm/instructions/conversions"
+	"github.com/Johnny1110/gogo_jvm/instructions/extended"
 	"github.com/Johnny1110/gogo_jvm/instructions/loads"
 	"github.com/Johnny1110/gogo_jvm/instructions/math"
 	"github.com/Johnny1110/gogo_jvm/instructions/references"
@@ -120,8 +122,41 @@ var (
 	fneg = &math.FNEG{}
 	dneg = &math.DNEG{}
 
+	// ============ Bitwise / Shift ============
+	ishl  = &math.ISHL{}
+	lshl  = &math.LSHL{}
+	ishr  = &math.ISHR{}
+	lshr  = &math.LSHR{}
+	iushr = &math.IUSHR{}
+	lushr = &math.LUSHR{}
+	iand  = &math.IAND{}
+	land  = &math.LAND{}
+	ior   = &math.IOR{}
+	lor   = &math.LOR{}
+	ixor  = &math.IXOR{}
+	lxor  = &math.LXOR{}
+
+	// ============ Conversions ============
+	i2l = &conversions.I2L{}
+	i2f = &conversions.I2F{}
+	i2d = &conversions.I2D{}
+	l2i = &conversions.L2I{}
+	l2f = &conversions.L2F{}
+	l2d = &conversions.L2D{}
+	f2i = &conversions.F2I{}
+	f2l = &conversions.F2L{}
+	f2d = &conversions.F2D{}
+	d2i = &conversions.D2I{}
+	d2l = &conversions.D2L{}
+	d2f = &conversions.D2F{}
+	i2b = &conversions.I2B{}
+	i2c = &conversions.I2C{}
+	i2s = &conversions.I2S{}
+
 	// ============ Reference ============
-	athrow = &references.ATHROW{}
+	athrow       = &references.ATHROW{}
+	monitorenter = &references.MONITORENTER{}
+	monitorexit  = &references.MONITOREXIT{}
 
 	// ============ Control / Return ============
 	ireturn = &control.IRETURN{}
@@ -151,7 +186,11 @@ var (
 	arraylength = &arrays.ARRAYLENGTH{}
 
 	// ============ Compare ============
-	lcmp = &control.LCMP{}
+	lcmp  = &control.LCMP{}
+	fcmpl = &control.FCMPL{}
+	fcmpg = &control.FCMPG{}
+	dcmpl = &control.DCMPL{}
+	dcmpg = &control.DCMPG{}
 )
 
 // NewInstruction return instruction based on input opcodes
@@ -308,6 +347,10 @@ func NewInstruction(opcode byte) (base.Instruction, error) {
 	// reference
 	case opcodes.ATHROW:
 		return athrow, nil
+	case opcodes.MONITORENTER:
+		return monitorenter, nil
+	case opcodes.MONITOREXIT:
+		return monitorexit, nil
 
 	// Array Load Instructions
 	case opcodes.IALOAD:
@@ -416,9 +459,75 @@ func NewInstruction(opcode byte) (base.Instruction, error) {
 	case opcodes.IINC:
 		return &math.IINC{}, nil
 
+	// bitwise / shift instructions
+	case opcodes.ISHL:
+		return ishl, nil
+	case opcodes.LSHL:
+		return lshl, nil
+	case opcodes.ISHR:
+		return ishr, nil
+	case opcodes.LSHR:
+		return lshr, nil
+	case opcodes.IUSHR:
+		return iushr, nil
+	case opcodes.LUSHR:
+		return lushr, nil
+	case opcodes.IAND:
+		return iand, nil
+	case opcodes.LAND:
+		return land, nil
+	case opcodes.IOR:
+		return ior, nil
+	case opcodes.LOR:
+		return lor, nil
+	case opcodes.IXOR:
+		return ixor, nil
+	case opcodes.LXOR:
+		return lxor, nil
+
+	// conversion instructions
+	case opcodes.I2L:
+		return i2l, nil
+	case opcodes.I2F:
+		return i2f, nil
+	case opcodes.I2D:
+		return i2d, nil
+	case opcodes.L2I:
+		return l2i, nil
+	case opcodes.L2F:
+		return l2f, nil
+	case opcodes.L2D:
+		return l2d, nil
+	case opcodes.F2I:
+		return f2i, nil
+	case opcodes.F2L:
+		return f2l, nil
+	case opcodes.F2D:
+		return f2d, nil
+	case opcodes.D2I:
+		return d2i, nil
+	case opcodes.D2L:
+		return d2l, nil
+	case opcodes.D2F:
+		return d2f, nil
+	case opcodes.I2B:
+		return i2b, nil
+	case opcodes.I2C:
+		return i2c, nil
+	case opcodes.I2S:
+		return i2s, nil
+
 	// compare instructions
 	case opcodes.LCMP:
 		return lcmp, nil
+	case opcodes.FCMPL:
+		return fcmpl, nil
+	case opcodes.FCMPG:
+		return fcmpg, nil
+	case opcodes.DCMPL:
+		return dcmpl, nil
+	case opcodes.DCMPG:
+		return dcmpg, nil
 	case opcodes.IFEQ:
 		return &control.IFEQ{}, nil
 	case opcodes.IFNE:
@@ -454,6 +563,24 @@ func NewInstruction(opcode byte) (base.Instruction, error) {
 	case opcodes.IFNONNULL:
 		return &control.IFNONNULL{}, nil
 
+	// switch instructions
+	case opcodes.TABLESWITCH:
+		return &control.TABLESWITCH{}, nil
+	case opcodes.LOOKUPSWITCH:
+		return &control.LOOKUPSWITCH{}, nil
+
+	// subroutine / extended instructions
+	case opcodes.JSR:
+		return &control.JSR{}, nil
+	case opcodes.RET:
+		return &control.RET{}, nil
+	case opcodes.WIDE:
+		return &extended.WIDE{}, nil
+	case opcodes.GOTO_W:
+		return &control.GOTO_W{}, nil
+	case opcodes.JSR_W:
+		return &control.JSR_W{}, nil
+
 	// return instructions
 	case opcodes.IRETURN:
 		return ireturn, nil
@@ -505,6 +632,8 @@ func NewInstruction(opcode byte) (base.Instruction, error) {
 		return &references.INVOKESPECIAL{}, nil
 	case opcodes.INVOKEINTERFACE:
 		return &references.INVOKEINTERFACE{}, nil
+	case opcodes.INVOKEDYNAMIC:
+		return &references.INVOKEDYNAMIC{}, nil
 
 	default:
 		return nop, fmt.Errorf("unsupported opcodes: 0x%02X", opcode)
@@ -0,0 +1,218 @@
+package math
+
+import (
+	"github.com/Johnny1110/gogo_jvm/instructions/base"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+)
+
+// ============================================================
+// SHIFT Series
+// ============================================================
+// stack: [..., value, shiftDistance] -> [..., result]
+// shiftDistance is always int (even for long shift)
+//
+// JVMS: only low bits of shiftDistance are used
+// - int:  low 5 bits (0x1F) -> 1 << 33 == 1 << 1
+// - long: low 6 bits (0x3F) -> 1L << 65 == 1L << 1
+// Go shifts by a count >= width give 0, so masking is required.
+
+// ISHL int shift left
+// opcodes = 0x78
+type ISHL struct{ base.NoOperandsInstruction }
+
+func (i *ISHL) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopInt()
+	v1 := stack.PopInt()
+	s := uint32(v2) & 0x1F
+	stack.PushInt(v1 << s)
+}
+
+func (i *ISHL) Opcode() uint8 {
+	return opcodes.ISHL
+}
+
+// LSHL long shift left
+// opcodes = 0x79
+type LSHL struct{ base.NoOperandsInstruction }
+
+func (l *LSHL) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopInt()
+	v1 := stack.PopLong()
+	s := uint32(v2) & 0x3F
+	stack.PushLong(v1 << s)
+}
+
+func (l *LSHL) Opcode() uint8 {
+	return opcodes.LSHL
+}
+
+// ISHR int arithmetic shift right (keep sign bit)
+// opcodes = 0x7A
+type ISHR struct{ base.NoOperandsInstruction }
+
+func (i *ISHR) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopInt()
+	v1 := stack.PopInt()
+	s := uint32(v2) & 0x1F
+	stack.PushInt(v1 >> s)
+}
+
+func (i *ISHR) Opcode() uint8 {
+	return opcodes.ISHR
+}
+
+// LSHR long arithmetic shift right (keep sign bit)
+// opcodes = 0x7B
+type LSHR struct{ base.NoOperandsInstruction }
+
+func (l *LSHR) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopInt()
+	v1 := stack.PopLong()
+	s := uint32(v2) & 0x3F
+	stack.PushLong(v1 >> s)
+}
+
+func (l *LSHR) Opcode() uint8 {
+	return opcodes.LSHR
+}
+
+// IUSHR int logical shift right (fill 0), Java `>>>`
+// opcodes = 0x7C
+// Go has no `>>>`, convert to unsigned then shift
+type IUSHR struct{ base.NoOperandsInstruction }
+
+func (i *IUSHR) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopInt()
+	v1 := stack.PopInt()
+	s := uint32(v2) & 0x1F
+	stack.PushInt(int32(uint32(v1) >> s))
+}
+
+func (i *IUSHR) Opcode() uint8 {
+	return opcodes.IUSHR
+}
+
+// LUSHR long logical shift right (fill 0), Java `>>>`
+// opcodes = 0x7D
+type LUSHR struct{ base.NoOperandsInstruction }
+
+func (l *LUSHR) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopInt()
+	v1 := stack.PopLong()
+	s := uint32(v2) & 0x3F
+	stack.PushLong(int64(uint64(v1) >> s))
+}
+
+func (l *LUSHR) Opcode() uint8 {
+	return opcodes.LUSHR
+}
+
+// ============================================================
+// AND Series
+// ============================================================
+
+// IAND int bitwise and
+// opcodes = 0x7E
+type IAND struct{ base.NoOperandsInstruction }
+
+func (i *IAND) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopInt()
+	v1 := stack.PopInt()
+	stack.PushInt(v1 & v2)
+}
+
+func (i *IAND) Opcode() uint8 {
+	return opcodes.IAND
+}
+
+// LAND long bitwise and
+// opcodes = 0x7F
+type LAND struct{ base.NoOperandsInstruction }
+
+func (l *LAND) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopLong()
+	v1 := stack.PopLong()
+	stack.PushLong(v1 & v2)
+}
+
+func (l *LAND) Opcode() uint8 {
+	return opcodes.LAND
+}
+
+// ============================================================
+// OR Series
+// ============================================================
+
+// IOR int bitwise or
+// opcodes = 0x80
+type IOR struct{ base.NoOperandsInstruction }
+
+func (i *IOR) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopInt()
+	v1 := stack.PopInt()
+	stack.PushInt(v1 | v2)
+}
+
+func (i *IOR) Opcode() uint8 {
+	return opcodes.IOR
+}
+
+// LOR long bitwise or
+// opcodes = 0x81
+type LOR struct{ base.NoOperandsInstruction }
+
+func (l *LOR) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopLong()
+	v1 := stack.PopLong()
+	stack.PushLong(v1 | v2)
+}
+
+func (l *LOR) Opcode() uint8 {
+	return opcodes.LOR
+}
+
+// ============================================================
+// XOR Series
+// ============================================================
+// javac also use `ixor` with -1 for `~x` (there is no inot opcode)
+
+// IXOR int bitwise xor
+// opcodes = 0x82
+type IXOR struct{ base.NoOperandsInstruction }
+
+func (i *IXOR) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopInt()
+	v1 := stack.PopInt()
+	stack.PushInt(v1 ^ v2)
+}
+
+func (i *IXOR) Opcode() uint8 {
+	return opcodes.IXOR
+}
+
+// LXOR long bitwise xor
+// opcodes = 0x83
+type LXOR struct{ base.NoOperandsInstruction }
+
+func (l *LXOR) Execute(frame *runtime.Frame) {
+	stack := frame.OperandStack()
+	v2 := stack.PopLong()
+	v1 := stack.PopLong()
+	stack.PushLong(v1 ^ v2)
+}
+
+func (l *LXOR) Opcode() uint8 {
+	return opcodes.LXOR
+}
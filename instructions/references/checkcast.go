@@ -1,7 +1,6 @@
 package references
 
 import (
-	"github.com/Johnny1110/gogo_jvm/common"
 	"github.com/Johnny1110/gogo_jvm/instructions/base"
 	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
 	"github.com/Johnny1110/gogo_jvm/runtime"
@@ -42,7 +41,8 @@ func (c *CHECKCAST) Execute(frame *runtime.Frame) {
 	object := objectref.(*heap.Object)
 
 	if !isInstanceOf(object, targetClass) {
-		panic(common.NewJavaException("", "java.lang.ClassCastException"))
+		frame.JavaThrow(NewClassCastException(frame, object.Class().(*method_area.Class).JavaName(), targetClass.JavaName()))
+		return
 	}
 
 	// push back
@@ -0,0 +1,151 @@
+package references
+
+import (
+	"github.com/Johnny1110/gogo_jvm/exception"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+)
+
+// ============================================================
+// Class Initialization (JVMS 5.5)
+// ============================================================
+// <clinit> runs as normal frame on JVM stack, so initialization is driven by frames:
+//  1. trigger instruction (new, getstatic, putstatic, invokestatic) finds class not initialized,
+//     reverts its PC and calls InitClass
+//  2. class is marked being initialized by current thread, superclass & superinterfaces are initialized first:
+//     when one of them pushes its <clinit> frame, class waits for it (initWaiter)
+//  3. all dependencies are initialized: push class's <clinit> frame
+//  4. <clinit> returns: FinishClinit marks class initialized and continues the waiting class
+//     <clinit> throws: failClinit marks class (and waiting classes) erroneous,
+//     exception (not Error) is wrapped in ExceptionInInitializerError
+//  5. trigger instruction executes again, class is initialized now
+//
+// erroneous class can not be used anymore: NoClassDefFoundError
+
+// ensureInitialized return true if class can be used by instruction now,
+// otherwise init frames are pushed (instruction is executed again after them) or exception is thrown
+func ensureInitialized(frame *runtime.Frame, class *method_area.Class) bool {
+	if class.IsInitialized() {
+		return true
+	}
+
+	nextPC := frame.NextPC()
+	frame.RevertNextPC() // do it again after init
+	if InitClass(frame.Thread(), class) {
+		// initialized without <clinit> frame (or recursive request by current thread)
+		frame.SetNextPC(nextPC)
+		return true
+	}
+	return false
+}
+
+// InitClass JVMS 5.5 initialization procedure
+// return true if class is initialized (or being initialized by current thread),
+// false: <clinit> frame is pushed or exception is thrown
+func InitClass(thread *runtime.Thread, class *method_area.Class) bool {
+	switch class.StartInit(thread) {
+	case method_area.INIT_DONE:
+		return true
+	case method_area.INIT_ERRONEOUS:
+		throwNoClassDefFoundError(thread, class)
+		return false
+	}
+
+	if continueInit(thread, class) {
+		class.FinishInit()
+		return true
+	}
+	return false
+}
+
+// continueInit JVMS 5.5 step 7~9, class is being initialized by thread
+// return true if class is ready to be marked initialized (no <clinit> to run),
+// false: class is waiting for a <clinit> frame, or failed (exception is thrown)
+func continueInit(thread *runtime.Thread, class *method_area.Class) bool {
+	// step 7: superclass and superinterfaces (with default methods) first
+	for _, dep := range class.InitDependencies() {
+		switch dep.StartInit(thread) {
+		case method_area.INIT_DONE:
+			continue
+		case method_area.INIT_ERRONEOUS:
+			failInit(class)
+			throwNoClassDefFoundError(thread, dep)
+			return false
+		}
+
+		dep.SetInitWaiter(class)
+		if !continueInit(thread, dep) {
+			return false // continue after dep's <clinit> returns (FinishClinit)
+		}
+		dep.FinishInit()
+	}
+
+	// step 9: execute <clinit>
+	clinit := class.GetClinitMethod()
+	if clinit == nil {
+		return true
+	}
+
+	// no room for <clinit> frame: StackOverflowError in current frame
+	if thread.IsStackFull() {
+		failInit(class)
+		frame := thread.CurrentFrame()
+		ThrowException(frame, NewStackOverflowError(frame))
+		return false
+	}
+
+	newFrame := thread.NewFrameWithMethodAndExHandler(clinit, ThrowException)
+	thread.PushFrame(newFrame)
+	return false
+}
+
+// FinishClinit JVMS 5.5 step 10, <clinit> frame returned normally
+// usage: return instruction
+func FinishClinit(frame *runtime.Frame) {
+	method := frame.Method()
+	if method == nil || method.Name() != "<clinit>" {
+		return
+	}
+
+	thread := frame.Thread()
+	for waiter := method.Class().FinishInit(); waiter != nil; waiter = waiter.FinishInit() {
+		if !continueInit(thread, waiter) {
+			return
+		}
+	}
+}
+
+// failClinit JVMS 5.5 step 11~12, <clinit> frame completed abruptly (already popped)
+// return exception to be propagated: exceptionObj if it is an Error, otherwise ExceptionInInitializerError
+func failClinit(frame *runtime.Frame, exceptionObj *heap.Object) *heap.Object {
+	failInit(frame.Method().Class())
+
+	if isError(getExceptionClass(exceptionObj)) {
+		return exceptionObj
+	}
+	eiie := NewExceptionInInitializerError(frame, exceptionObj)
+	exception.CaptureStackTrace(frame.Thread(), eiie)
+	return eiie
+}
+
+// failInit mark class and classes waiting for it erroneous
+func failInit(class *method_area.Class) {
+	for c := class; c != nil; c = c.FailInit() {
+	}
+}
+
+func throwNoClassDefFoundError(thread *runtime.Thread, class *method_area.Class) {
+	frame := thread.CurrentFrame()
+	ThrowException(frame, NewNoClassDefFoundError(frame, "Could not initialize class "+class.JavaName()))
+}
+
+// isError class is java/lang/Error or its subclass
+func isError(class *method_area.Class) bool {
+	for c := class; c != nil; c = c.SuperClass() {
+		if c.Name() == "java/lang/Error" {
+			return true
+		}
+	}
+	return false
+}
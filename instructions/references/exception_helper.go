@@ -2,6 +2,8 @@ package references
 
 import (
 	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/Johnny1110/gogo_jvm/exception"
 	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
 	"github.com/Johnny1110/gogo_jvm/runtime"
 	"github.com/Johnny1110/gogo_jvm/runtime/heap"
@@ -16,8 +18,10 @@ import (
 // usage: idiv, aaload, checkcast ...
 func ThrowException(frame *runtime.Frame, exceptionObj *heap.Object) {
 	thread := frame.Thread()
+	// 1. capture backtrace before unwinding
+	exception.CaptureStackTrace(thread, exceptionObj)
 	// 2. find ex handler and process exception
-	if !handleException(thread, exceptionObj) {
+	if exceptionObj, caught := handleException(thread, exceptionObj); !caught {
 		// can not find handler to process exception -> UncaughtException
 		handleUncaughtException(thread, exceptionObj)
 	}
@@ -33,11 +37,17 @@ func ThrowException(frame *runtime.Frame, exceptionObj *heap.Object) {
 //   - exceptionObj: target exception
 //
 // return:
+//   - exception finally propagated (<clinit> failure replaces it by ExceptionInInitializerError)
 //   - true: found handler and goto target PC
 //   - false: can not find handler until searched all JVMStack
-func handleException(currentThread *runtime.Thread, exceptionObj *heap.Object) bool {
+func handleException(currentThread *runtime.Thread, exceptionObj *heap.Object) (*heap.Object, bool) {
 	for {
 		frame := currentThread.CurrentFrame()
+		// VM call stub (no method, see interpreter.InvokeMethod): exception returns to Go caller of java method
+		if frame.Method() == nil {
+			frame.LocalVars().SetRef(0, exceptionObj)
+			return exceptionObj, true
+		}
 		pc := frame.CurrentPC() // the pc where the error thrown
 
 		fmt.Printf("@@ DEBUG - handleException frame.CurrentPC() -> %v, opcode: 0x%02X (%s) \n", pc, frame.Method().Code()[pc], opcodes.OpcodeNames[frame.Method().Code()[pc]])
@@ -47,13 +57,19 @@ func handleException(currentThread *runtime.Thread, exceptionObj *heap.Object) b
 
 		if handlerPC >= 0 { // found handler in current frame (method)
 			handleCatch(frame, exceptionObj, handlerPC)
-			return true
+			return exceptionObj, true
 		} else { // no handler found in current frame (method)
+			// abrupt completion: release synchronized method's monitor
+			frame.ExitSyncMonitor()
 			currentThread.PopFrame()
+			// class initialization failed: class becomes erroneous
+			if frame.Method().Name() == "<clinit>" {
+				exceptionObj = failClinit(frame, exceptionObj)
+			}
 			if currentThread.IsStackEmpty() {
 				// handler not found until popped all frames (method)
 				fmt.Println("@@ DEBUG - Warning! handleException not found matching catch until popped all JVMFrameStack.")
-				return false
+				return exceptionObj, false
 			}
 		}
 	}
@@ -91,24 +107,20 @@ func handleCatch(frame *runtime.Frame, exceptionObj *heap.Object, handlerPC int)
 }
 
 // handleUncaughtException handle uncaught exception
-// print ex and os.exit()
+// print ex with stack trace and os.exit()
 func handleUncaughtException(thread *runtime.Thread, exceptionObj *heap.Object) {
-	className := getExceptionClassName(exceptionObj)
-	message := getExceptionMessage(exceptionObj)
-
-	if message != "" {
-		fmt.Fprintf(os.Stderr, "Exception in thread \"main\" %s: %s\n", className, message)
-	} else {
-		fmt.Fprintf(os.Stderr, "Exception in thread \"main\" %s\n", className)
-	}
-
-	// TODO:
-	// printStackTrace(thread, exceptionObj)
+	fmt.Fprint(os.Stderr, "Exception in thread \"main\" ")
+	exception.PrintStackTrace(os.Stderr, exceptionObj)
 
 	// 終止程式
 	os.Exit(1)
 }
 
+// printUncaughtException exception object can not be created, print class name and message only
+func printUncaughtException(className, message string) {
+	fmt.Fprintf(os.Stderr, "Exception in thread \"main\" %s\n", common.NewJavaException(className, message).Error())
+}
+
 // ============================================================
 // Tools
 // ============================================================
@@ -122,73 +134,142 @@ func getExceptionClass(exceptionObj *heap.Object) *method_area.Class {
 	return nil
 }
 
-// getExceptionClassName get ex class name TODO: this is MVP simplify
-func getExceptionClassName(exceptionObj *heap.Object) string {
-	exClass := getExceptionClass(exceptionObj)
-	if exClass != nil {
-		return exClass.Name()
+// ============================================================
+// VM Raised Exception (panic *common.JavaException)
+// ============================================================
+
+// ThrowPanic throw recovered panic of instruction as java exception in frame
+//   - *common.JavaException: its Throwable class (ex: java/lang/NullPointerException),
+//     or its Throwable object if it is thrown by java code already
+//   - others (stray panic, Go runtime error): java/lang/InternalError
+//
+// frames pushed by the instruction before panic (not started yet) are discarded first
+func ThrowPanic(frame *runtime.Frame, r interface{}) {
+	javaErr, ok := r.(*common.JavaException)
+	if !ok {
+		javaErr = common.NewJavaException("java/lang/InternalError", fmt.Sprint(r))
 	}
+	thrown, _ := javaErr.Throwable.(*heap.Object)
 
-	fmt.Println("@@ DEBUG - getExceptionClassName can not find ex class.")
+	thread := frame.Thread()
+	if isFrameInStack(thread, frame) {
+		for thread.CurrentFrame() != frame {
+			thread.PopFrame().ExitSyncMonitor()
+		}
+	} else if !thread.IsStackEmpty() {
+		frame = thread.CurrentFrame()
+	} else {
+		printUncaughtException(javaErr.ClassName, javaErr.Message)
+		os.Exit(1)
+	}
 
-	return "java/lang/Throwable"
+	// exception thrown by java code (VM upcall) is rethrown as it is
+	if thrown != nil {
+		ThrowException(frame, thrown)
+		return
+	}
+	exceptionObj := newJavaExceptionObjectOrExit(frame, javaErr)
+	ThrowException(frame, exceptionObj)
 }
 
-// getExceptionMessage get ex message
-func getExceptionMessage(exceptionObj *heap.Object) string {
-	if extra := exceptionObj.Extra(); extra != nil {
-		if info, ok := extra.(*heap.ExceptionData); ok {
-			return info.Message
+// newJavaExceptionObjectOrExit exception class can not be loaded (no Throwable in classpath): report it as uncaught
+func newJavaExceptionObjectOrExit(frame *runtime.Frame, javaErr *common.JavaException) (exceptionObj *heap.Object) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("@@ DEBUG - can not create exception %s: %v\n", javaErr.ClassName, r)
+			printUncaughtException(javaErr.ClassName, javaErr.Message)
+			os.Exit(1)
+		}
+	}()
+	return NewJavaExceptionObject(frame, javaErr.ClassName, javaErr.Message)
+}
+
+func isFrameInStack(thread *runtime.Thread, frame *runtime.Frame) bool {
+	for _, f := range thread.GetFrames() {
+		if f == frame {
+			return true
 		}
 	}
-	return ""
+	return false
 }
 
 // ============================================================
 // Java Common Exception Factory
 // ============================================================
 
+// NewJavaExceptionObject create exception object of VM raised Throwable class (ex: java/lang/NullPointerException)
+func NewJavaExceptionObject(frame *runtime.Frame, className, message string) *heap.Object {
+	return exception.NewJavaExceptionObject(frame, className, message)
+}
+
 // NewArithmeticException
 func NewArithmeticException(frame *runtime.Frame, message string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/ArithmeticException", false)
-	return heap.NewExceptionObject(exClass, message)
+	return NewJavaExceptionObject(frame, "java/lang/ArithmeticException", message)
 }
 
 // NewNullPointerException
 func NewNullPointerException(frame *runtime.Frame) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/NullPointerException", false)
-	return heap.NewExceptionObject(exClass, "")
+	return NewJavaExceptionObject(frame, "java/lang/NullPointerException", "")
 }
 
 // NewArrayIndexOutOfBoundsException
 func NewArrayIndexOutOfBoundsException(frame *runtime.Frame, index int32) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/ArrayIndexOutOfBoundsException", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%d", index))
+	return NewJavaExceptionObject(frame, "java/lang/ArrayIndexOutOfBoundsException", fmt.Sprintf("%d", index))
 }
 
 // NewClassCastException
 func NewClassCastException(frame *runtime.Frame, from, to string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/ClassCastException", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%s cannot be cast to %s", from, to))
+	return NewJavaExceptionObject(frame, "java/lang/ClassCastException", fmt.Sprintf("%s cannot be cast to %s", from, to))
 }
 
 // NewNegativeArraySizeException
 func NewNegativeArraySizeException(frame *runtime.Frame, size int32) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/NegativeArraySizeException", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%d", size))
+	return NewJavaExceptionObject(frame, "java/lang/NegativeArraySizeException", fmt.Sprintf("%d", size))
 }
 
 func NewIncompatibleClassChangeError(frame *runtime.Frame, message string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/IncompatibleClassChangeError", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%s", message))
+	return NewJavaExceptionObject(frame, "java/lang/IncompatibleClassChangeError", message)
 }
 
 func NewAbstractMethodError(frame *runtime.Frame, className, methodName, descriptor string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/NewAbstractMethodError", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%s.%s%s", className, methodName, descriptor))
+	return NewJavaExceptionObject(frame, "java/lang/AbstractMethodError", fmt.Sprintf("%s.%s%s", className, methodName, descriptor))
+}
+
+// NewIllegalMonitorStateException
+func NewIllegalMonitorStateException(frame *runtime.Frame, message string) *heap.Object {
+	return NewJavaExceptionObject(frame, "java/lang/IllegalMonitorStateException", message)
+}
+
+// NewStackOverflowError
+func NewStackOverflowError(frame *runtime.Frame) *heap.Object {
+	return NewJavaExceptionObject(frame, "java/lang/StackOverflowError", "")
 }
 
 func NewIllegalAccessError(frame *runtime.Frame, className, methodName, descriptor string) *heap.Object {
-	exClass := frame.Method().Class().Loader().LoadClass("java/lang/IllegalAccessError", false)
-	return heap.NewExceptionObject(exClass, fmt.Sprintf("%s.%s%s", className, methodName, descriptor))
+	return NewJavaExceptionObject(frame, "java/lang/IllegalAccessError", fmt.Sprintf("%s.%s%s", className, methodName, descriptor))
+}
+
+func NewNoSuchMethodError(frame *runtime.Frame, className, methodName, descriptor string) *heap.Object {
+	return NewJavaExceptionObject(frame, "java/lang/NoSuchMethodError", fmt.Sprintf("%s.%s%s", className, methodName, descriptor))
+}
+
+func NewUnsatisfiedLinkError(frame *runtime.Frame, className, methodName, descriptor string) *heap.Object {
+	return NewJavaExceptionObject(frame, "java/lang/UnsatisfiedLinkError", fmt.Sprintf("%s.%s%s", className, methodName, descriptor))
+}
+
+// NewNoClassDefFoundError
+func NewNoClassDefFoundError(frame *runtime.Frame, message string) *heap.Object {
+	return NewJavaExceptionObject(frame, "java/lang/NoClassDefFoundError", message)
+}
+
+// NewExceptionInInitializerError exception thrown by <clinit> as cause
+func NewExceptionInInitializerError(frame *runtime.Frame, cause *heap.Object) *heap.Object {
+	eiie := NewJavaExceptionObject(frame, "java/lang/ExceptionInInitializerError", "")
+	eiie.GetExceptionData().Cause = cause
+	return eiie
+}
+
+// NewInstantiationError
+func NewInstantiationError(frame *runtime.Frame, className string) *heap.Object {
+	return NewJavaExceptionObject(frame, "java/lang/InstantiationError", className)
 }
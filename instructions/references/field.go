@@ -39,14 +39,13 @@ func (g *GETSTATIC) Execute(frame *runtime.Frame) {
 	// 4. get class (if field resolved, class should already be resolved also)
 	class := field.Class()
 	// 5. check class <clinit>
-	if !class.InitStarted() {
-		frame.RevertNextPC() // do it again after initClass.
-		initClass(frame.Thread(), class)
-		return
+	if !ensureInitialized(frame, class) {
+		return // do it again after init
 	}
 	// 6. check static
 	if !field.IsStatic() {
-		panic("java.lang.IncompatibleClassChangeError")
+		frame.JavaThrow(NewIncompatibleClassChangeError(frame, "Expected static field "+class.Name()+"."+field.Name()))
+		return
 	}
 
 	// 7. get slot and desc
@@ -100,15 +99,14 @@ func (p *PUTSTATIC) Execute(frame *runtime.Frame) {
 	class := field.Class()
 
 	// 5. do <clinit> if required
-	if !class.InitStarted() {
-		frame.RevertNextPC()
-		initClass(frame.Thread(), class)
+	if !ensureInitialized(frame, class) {
 		return
 	}
 
 	// 6. check status
 	if !field.IsStatic() {
-		panic("java.lang.IncompatibleClassChangeError")
+		frame.JavaThrow(NewIncompatibleClassChangeError(frame, "Expected static field "+class.Name()+"."+field.Name()))
+		return
 	}
 
 	// 7. get field's slotId and descriptor
@@ -141,7 +139,8 @@ func (g *GETFIELD) Execute(frame *runtime.Frame) {
 	field := fieldRef.ResolvedField()
 	// 1. not static
 	if field.IsStatic() {
-		panic("java.lang.IncompatibleClassChangeError")
+		frame.JavaThrow(NewIncompatibleClassChangeError(frame, "Expected non-static field "+field.Class().Name()+"."+field.Name()))
+		return
 	}
 
 	stack := frame.OperandStack()
@@ -181,7 +180,8 @@ func (p *PUTFIELD) Execute(frame *runtime.Frame) {
 	fieldRef := cp.GetConstant(p.Index).(*method_area.FieldRef)
 	field := fieldRef.ResolvedField()
 	if field.IsStatic() {
-		panic("java.lang.IncompatibleClassChangeError")
+		frame.JavaThrow(NewIncompatibleClassChangeError(frame, "Expected non-static field "+field.Class().Name()+"."+field.Name()))
+		return
 	}
 
 	slotId := field.SlotId()
@@ -13,10 +13,22 @@ func hacked_invoke_native(frame *runtime.Frame, methodRef *method_area.MethodRef
 
 	if nativeMethod := runtime.FindNativeMethod(className, methodName, descriptor); nativeMethod != nil {
 		// call native() directly
-		invokeNativeMethod(frame, nativeMethod, descriptor)
+		invokeNativeMethod(frame, nativeMethod, descriptor, false)
 		return true
 	}
 
 	// not in native method registry
 	return false
 }
+
+// hacked_invoke_static_native temp solution for invokestatic on class which can not be loaded (java/lang/System)
+func hacked_invoke_static_native(frame *runtime.Frame, methodRef *method_area.MethodRef) bool {
+	if methodRef.ClassName() != "java/lang/System" {
+		return false
+	}
+	if nativeMethod := runtime.FindNativeMethod(methodRef.ClassName(), methodRef.Name(), methodRef.Descriptor()); nativeMethod != nil {
+		invokeNativeMethod(frame, nativeMethod, methodRef.Descriptor(), true)
+		return true
+	}
+	return false
+}
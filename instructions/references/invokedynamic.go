@@ -0,0 +1,61 @@
+package references
+
+import (
+	"github.com/Johnny1110/gogo_jvm/instructions/base"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+)
+
+// ============================================================
+// INVOKEDYNAMIC - invoke dynamically-computed call site (Java 7+)
+// ============================================================
+// opcode: 0xBA
+// operands: 2 bytes (constant pool index, pointing to InvokeDynamicRef) + 2 bytes (must be 0)
+// stack: [arg1, arg2, ...] → [result]
+//
+// first execution: call bootstrap method -> CallSite (linked and cached in InvokeDynamicRef)
+// every execution: invoke CallSite target (static, descriptor same as invokedynamic)
+// target may be a native method (bootstrap implemented in Go, no bytecode)
+//
+// used by: lambda / method reference (LambdaMetafactory), string concat (StringConcatFactory)
+
+type INVOKEDYNAMIC struct {
+	base.Index16Instruction
+}
+
+func (i *INVOKEDYNAMIC) FetchOperands(reader *base.BytecodeReader) {
+	i.Index = uint(reader.ReadUint16())
+	_ = reader.ReadUint8() // must be 0
+	_ = reader.ReadUint8() // must be 0
+}
+
+func (i *INVOKEDYNAMIC) Execute(frame *runtime.Frame) {
+	rtcp := frame.Method().Class().ConstantPool()
+	indyRef := rtcp.GetConstant(i.Index).(*method_area.InvokeDynamicRef)
+
+	// 1. link call site (only first time)
+	callSite, err := indyRef.ResolvedCallSite()
+	if err != nil {
+		ThrowException(frame, err)
+		return
+	}
+
+	// 2. invoke target, args are already on stack
+	target := callSite.Target()
+	if target.IsNative() {
+		// call site implemented in Go (ex: StringConcatFactory)
+		nativeMethod := runtime.FindNativeMethod(target.Class().Name(), target.Name(), target.Descriptor())
+		if nativeMethod == nil {
+			ThrowException(frame, NewUnsatisfiedLinkError(frame, target.Class().Name(), target.Name(), target.Descriptor()))
+			return
+		}
+		invokeNativeMethod(frame, nativeMethod, target.Descriptor(), true)
+		return
+	}
+	invokeMethod(frame, target)
+}
+
+func (i *INVOKEDYNAMIC) Opcode() uint8 {
+	return opcodes.INVOKEDYNAMIC
+}
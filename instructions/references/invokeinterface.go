@@ -56,6 +56,12 @@ func (i *INVOKEINTERFACE) Execute(frame *runtime.Frame) {
 		return
 	}
 
+	// Java 11+ nestmates: private interface method is invoked directly (no dynamic binding)
+	if resolvedMethod.IsPrivate() {
+		invokeMethod(frame, resolvedMethod)
+		return
+	}
+
 	// 7. get object's actual class
 	object := objectref.(*heap.Object)
 	actualClass := object.Class().(*method_area.Class)
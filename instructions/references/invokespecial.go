@@ -31,6 +31,7 @@ func (i *INVOKESPECIAL) Execute(frame *runtime.Frame) {
 	resolvedMethod, err := methodRef.ResolvedMethod()
 	if err != nil {
 		frame.JavaThrow(err)
+		return
 	}
 
 	// ============================================================
@@ -42,7 +43,8 @@ func (i *INVOKESPECIAL) Execute(frame *runtime.Frame) {
 			return
 		} else {
 			fmt.Printf("@@ DEBUG - INVOKESPECIAL hacked_invoke_native failed, method: %s\n", resolvedMethod.Name())
-			panic("INVOKESPECIAL Hacked invoke method failed")
+			frame.JavaThrow(NewUnsatisfiedLinkError(frame, methodRef.ClassName(), methodRef.Name(), methodRef.Descriptor()))
+			return
 		}
 	}
 
@@ -55,18 +57,21 @@ func (i *INVOKESPECIAL) Execute(frame *runtime.Frame) {
 		// currentClass -> rtcp -> methodRef -> resolvedMethod (parent's method)
 		// methodRef -> resolvedClass (this.lang)
 		// this.lang != parent's method.Class()
-		panic("java.lang.NoSuchMethodError")
+		frame.JavaThrow(NewNoSuchMethodError(frame, resolvedClass.Name(), resolvedMethod.Name(), resolvedMethod.Descriptor()))
+		return
 	}
 
 	// 5. check can not call static method
 	if resolvedMethod.IsStatic() {
-		panic("java.lang.IncompatibleClassChangeError")
+		frame.JavaThrow(NewIncompatibleClassChangeError(frame, "Expecting non-static method "+methodRef.ClassName()+"."+methodRef.Name()+methodRef.Descriptor()))
+		return
 	}
 
 	// 6. get objectref(this) from stack, objectref is under args, like stack: [objectref, arg1, arg2, ...]
 	objectref := frame.OperandStack().PeekRefFromTop(resolvedMethod.ArgSlotCount() - 1)
 	if objectref == nil {
-		panic("java.lang.NullPointerException")
+		frame.JavaThrow(NewNullPointerException(frame))
+		return
 	}
 
 	// ============================================================
@@ -93,7 +98,8 @@ func (i *INVOKESPECIAL) Execute(frame *runtime.Frame) {
 	}
 
 	if methodToCall == nil {
-		panic("java.lang.AbstractMethodError")
+		frame.JavaThrow(NewAbstractMethodError(frame, currentClass.SuperClass().Name(), resolvedMethod.Name(), resolvedMethod.Descriptor()))
+		return
 	}
 
 	// 9. invoke method
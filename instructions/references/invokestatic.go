@@ -19,20 +19,43 @@ func (i *INVOKE_STATIC) Execute(frame *runtime.Frame) {
 	// 2. get method reference, index is target methodRef index which is already loaded in RuntimeConstantPool.
 	methodRef := cp.GetConstant(i.Index).(*method_area.MethodRef)
 
+	// ============================================================
+	// Hack: java/lang/System can not be loaded (no rt.jar),
+	// call its registered natives (System.getProperty ...) directly
+	// ============================================================
+	if hacked_invoke_static_native(frame, methodRef) {
+		return
+	}
+	// ============================================================
+
 	// 3. parse method ref, get target method
 	resolvedMethod, err := methodRef.ResolvedMethod()
 	if err != nil {
 		frame.JavaThrow(err)
+		return
 	}
 
 	// 4. make sure it's a static method
 	if !resolvedMethod.IsStatic() {
-		panic("java.lang.IncompatibleClassChangeError")
+		frame.JavaThrow(NewIncompatibleClassChangeError(frame, "Expected static method "+methodRef.ClassName()+"."+methodRef.Name()+methodRef.Descriptor()))
+		return
 	}
 
-	// 5. TODO: 類初始化（<clinit>，如果類還沒初始化，需要先執行 <clinit> MVP 階段暫時跳過
+	// 5. 類初始化: 如果類還沒初始化，需要先執行 <clinit> (rerun this invokestatic after init)
+	if !ensureInitialized(frame, resolvedMethod.Class()) {
+		return
+	}
 
-	// 6. call method
+	// 6. call method (native: call registered Go func)
+	if resolvedMethod.IsNative() {
+		nativeMethod := runtime.FindNativeMethod(resolvedMethod.Class().Name(), resolvedMethod.Name(), resolvedMethod.Descriptor())
+		if nativeMethod == nil {
+			frame.JavaThrow(NewUnsatisfiedLinkError(frame, resolvedMethod.Class().Name(), resolvedMethod.Name(), resolvedMethod.Descriptor()))
+			return
+		}
+		invokeNativeMethod(frame, nativeMethod, resolvedMethod.Descriptor(), true)
+		return
+	}
 	invokeMethod(frame, resolvedMethod)
 }
 
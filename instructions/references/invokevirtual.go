@@ -33,11 +33,13 @@ func (i *INVOKEVIRTUAL) Execute(frame *runtime.Frame) {
 	resolvedMethod, err := methodRef.ResolvedMethod()
 	if err != nil {
 		frame.JavaThrow(err)
+		return
 	}
 
 	// 4. check is static (no static)
 	if resolvedMethod.IsStatic() {
-		panic("java.lang.IncompatibleClassChangeError")
+		frame.JavaThrow(NewIncompatibleClassChangeError(frame, "Expecting non-static method "+methodRef.ClassName()+"."+methodRef.Name()+methodRef.Descriptor()))
+		return
 	}
 
 	// ============================================================
@@ -50,7 +52,8 @@ func (i *INVOKEVIRTUAL) Execute(frame *runtime.Frame) {
 			return
 		} else {
 			fmt.Printf("@@ DEBUG - INVOKEVIRTUAL hacked_invoke_native failed, method: %s\n", resolvedMethod.Name())
-			panic("INVOKEVIRTUAL Hacked invoke method failed")
+			frame.JavaThrow(NewUnsatisfiedLinkError(frame, methodRef.ClassName(), methodRef.Name(), methodRef.Descriptor()))
+			return
 		}
 	}
 	// ============================================================
@@ -58,7 +61,15 @@ func (i *INVOKEVIRTUAL) Execute(frame *runtime.Frame) {
 	// 5. get objectref
 	objectref := frame.OperandStack().PeekRefFromTop(resolvedMethod.ArgSlotCount() - 1)
 	if objectref == nil {
-		panic("java.lang.NullPointerException")
+		frame.JavaThrow(NewNullPointerException(frame))
+		return
+	}
+
+	// Java 11+ nestmates: javac emit invokevirtual for private method of nestmate,
+	// private method is not overridable, invoke it directly (no dynamic binding)
+	if resolvedMethod.IsPrivate() {
+		invokeMethod(frame, resolvedMethod)
+		return
 	}
 
 	// 6. get object and object's class
@@ -69,7 +80,8 @@ func (i *INVOKEVIRTUAL) Execute(frame *runtime.Frame) {
 	methodToCall := actualClass.GetMethod(resolvedMethod.Name(), resolvedMethod.Descriptor())
 
 	if methodToCall == nil || methodToCall.IsAbstract() {
-		panic("java.lang.AbstractMethodError")
+		frame.JavaThrow(NewAbstractMethodError(frame, actualClass.Name(), resolvedMethod.Name(), resolvedMethod.Descriptor()))
+		return
 	}
 
 	// 8. invoke method
@@ -0,0 +1,69 @@
+package references
+
+import (
+	"github.com/Johnny1110/gogo_jvm/instructions/base"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+)
+
+// ============================================================
+// MONITORENTER / MONITOREXIT - synchronized block
+// ============================================================
+// synchronized (lock) { ... } compiled:
+//   aload_1
+//   dup
+//   astore_2          // keep lock object for exit
+//   monitorenter
+//   ...               // block
+//   aload_2
+//   monitorexit
+//   goto END
+//   HANDLER:          // any exception (catch_type = 0)
+//   astore_3
+//   aload_2
+//   monitorexit       // make sure released on exception
+//   aload_3
+//   athrow
+//
+// lock implementation (thin lock / inflation / reentrant) see heap/monitor.go
+// owner is current thread.
+
+// MONITORENTER opcode = 0xC2
+// stack: ..., objectref -> ...
+type MONITORENTER struct{ base.NoOperandsInstruction }
+
+func (m *MONITORENTER) Execute(frame *runtime.Frame) {
+	ref := frame.OperandStack().PopRef()
+	if ref == nil {
+		frame.JavaThrow(NewNullPointerException(frame))
+		return
+	}
+
+	ref.(*heap.Object).MonitorEnter(frame.Thread())
+}
+
+func (m *MONITORENTER) Opcode() uint8 {
+	return opcodes.MONITORENTER
+}
+
+// MONITOREXIT opcode = 0xC3
+// stack: ..., objectref -> ...
+// throw IllegalMonitorStateException if current thread is not the owner (unbalanced exit)
+type MONITOREXIT struct{ base.NoOperandsInstruction }
+
+func (m *MONITOREXIT) Execute(frame *runtime.Frame) {
+	ref := frame.OperandStack().PopRef()
+	if ref == nil {
+		frame.JavaThrow(NewNullPointerException(frame))
+		return
+	}
+
+	if !ref.(*heap.Object).MonitorExit(frame.Thread()) {
+		frame.JavaThrow(NewIllegalMonitorStateException(frame, "current thread is not owner"))
+	}
+}
+
+func (m *MONITOREXIT) Opcode() uint8 {
+	return opcodes.MONITOREXIT
+}
@@ -30,16 +30,13 @@ func (n *NEW) Execute(frame *runtime.Frame) {
 
 	// 3. check class (not interface or abs)
 	if class.IsInterface() || class.IsAbstract() {
-		panic("java.lang.InstantiationError")
+		frame.JavaThrow(NewInstantiationError(frame, class.Name()))
+		return
 	}
 
 	// 4. make sure already inited
-	// 如果類別還沒初始化，需要先執行 <clinit>
-	if !class.InitStarted() {
-		// revert PC, rerun this new inst
-		frame.RevertNextPC()
-		// start init class
-		initClass(frame.Thread(), class)
+	// 如果類別還沒初始化，需要先執行 <clinit> (rerun this new inst after init)
+	if !ensureInitialized(frame, class) {
 		return
 	}
 
@@ -53,29 +50,3 @@ func (n *NEW) Execute(frame *runtime.Frame) {
 func (n *NEW) Opcode() uint8 {
 	return 0xBB
 }
-
-// ============================================================
-// Class Init
-// ============================================================
-
-// scheduleClinit settle <clinit> (if exist) method to a new Frame
-func scheduleClinit(thread *runtime.Thread, class *method_area.Class) {
-	clinit := class.GetClinitMethod()
-	if clinit != nil {
-		newFrame := thread.NewFrameWithMethodAndExHandler(clinit, ThrowException)
-		thread.PushFrame(newFrame)
-	}
-}
-
-// initSuperClass
-// JVM standards: before init class, all parent should be init
-func initSuperClass(thread *runtime.Thread, class *method_area.Class) {
-	if class.IsInterface() { // skip interface
-		return
-	}
-
-	superClass := class.SuperClass()
-	if superClass != nil && !superClass.InitStarted() {
-		initClass(thread, superClass)
-	}
-}
@@ -8,26 +8,6 @@ import (
 	"github.com/Johnny1110/gogo_jvm/runtime/rtcore"
 )
 
-// initClass
-// execute <clinit> method (if class have it)
-//
-// init order：
-// 1. parent class's <clinit>
-// 2. this class's <clinit>
-//
-// this func will create a new Frame to execute <clinit>
-// we need call RevertNextPC() let interpreor do this `new` again after init
-func initClass(thread *runtime.Thread, class *method_area.Class) {
-	// mark class is doing init
-	class.StartInit()
-
-	// call <clinit>
-	scheduleClinit(thread, class)
-
-	// init parents (recursive until all parents are init)
-	initSuperClass(thread, class)
-}
-
 // invokeMethod call method common func
 // usage: invokestatic, invokevirtual
 func invokeMethod(invokerFrame *runtime.Frame, method *method_area.Method) {
@@ -36,6 +16,23 @@ func invokeMethod(invokerFrame *runtime.Frame, method *method_area.Method) {
 
 	//fmt.Printf("@@ DEBUG - invokeMethod: %s \n", method.Name())
 
+	// stack depth reached -Xss: throw StackOverflowError in invoker, it can be caught
+	if thread.IsStackFull() {
+		ThrowException(invokerFrame, NewStackOverflowError(invokerFrame))
+		return
+	}
+
+	// native method selected by dynamic binding (ex: BuiltinClassLoader.loadClass overrides ClassLoader.loadClass)
+	if method.IsNative() {
+		nativeMethod := runtime.FindNativeMethod(method.Class().Name(), method.Name(), method.Descriptor())
+		if nativeMethod == nil {
+			ThrowException(invokerFrame, NewUnsatisfiedLinkError(invokerFrame, method.Class().Name(), method.Name(), method.Descriptor()))
+			return
+		}
+		invokeNativeMethod(invokerFrame, nativeMethod, method.Descriptor(), method.IsStatic())
+		return
+	}
+
 	// 2. create a new frame (represent new method)
 	newFrame := thread.NewFrameWithMethodAndExHandler(method, ThrowException)
 	thread.PushFrame(newFrame)
@@ -54,6 +51,15 @@ func invokeMethod(invokerFrame *runtime.Frame, method *method_area.Method) {
 		}
 	}
 
+	// 4. synchronized method: lock `this` (instance method) or class object (static method)
+	if method.IsSynchronized() {
+		if method.IsStatic() {
+			newFrame.EnterSyncMonitor(method.Class().JClass())
+		} else {
+			newFrame.EnterSyncMonitor(newFrame.LocalVars().GetThis().(*heap.Object))
+		}
+	}
+
 	// no need to reset PC, new frame nextPC will be default 0
 }
 
@@ -62,9 +68,12 @@ func invokeMethod(invokerFrame *runtime.Frame, method *method_area.Method) {
 // 2. put args into a temp LocalVars
 // 3. pass args to native Go func
 // no need a read frame to do native method.
-func invokeNativeMethod(callerFrame *runtime.Frame, callNativeMethod runtime.NativeMethod, descriptor string) {
+func invokeNativeMethod(callerFrame *runtime.Frame, callNativeMethod runtime.NativeMethod, descriptor string, isStatic bool) {
 	// calculate args slot count including this.
-	argSlotCount := calcArgSlotCount(descriptor) + 1 // LocalVars[0] = this, so we need + 1
+	argSlotCount := calcArgSlotCount(descriptor)
+	if !isStatic {
+		argSlotCount++ // LocalVars[0] = this, so we need + 1
+	}
 
 	// parsing return type
 	returnType := parseReturnType(descriptor)
@@ -77,7 +86,7 @@ func invokeNativeMethod(callerFrame *runtime.Frame, callNativeMethod runtime.Nat
 	localVars := tempFrame.LocalVars()  // localVars: [this, arg1, ... argN-1, argN]
 
 	// push args into temp LocalVars
-	for i := argSlotCount - 1; i >= 0; i-- { // i := argSlotCount - 1 -> skip this
+	for i := argSlotCount - 1; i >= 0; i-- {
 		slot := stack.PopSlot()
 		localVars.SetSlot(uint(i), slot)
 	}
@@ -199,7 +208,7 @@ func calcArgSlotCount(descriptor string) int {
 				i++
 			}
 		default:
-			panic(common.NewJavaException("", "Calculate method arg slot count failed, unknown descriptor type: "+string(descriptor[i])))
+			panic("Calculate method arg slot count failed, unknown descriptor type: " + string(descriptor[i]))
 		}
 	}
 	return slotCount
@@ -254,7 +263,7 @@ func popAndSetFieldValue(stack *runtime.OperandStack, slots rtcore.Slots, slotId
 // checkNotNull check ref is not null
 func checkNotNull(ref interface{}) {
 	if ref == nil {
-		panic("java.class.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 }
 
@@ -330,3 +339,8 @@ func isArrayInstanceOf(arrObject *heap.Object, targetClass *method_area.Class) b
 	return false
 
 }
+
+// InvokeMethod invoke method with args on invokerFrame's op-stack, for VM upcall (see interpreter.InvokeMethod)
+func InvokeMethod(invokerFrame *runtime.Frame, method *method_area.Method) {
+	invokeMethod(invokerFrame, method)
+}
@@ -0,0 +1,134 @@
+package interpreter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+)
+
+// TestIntShift 測試 ishl / ishr / iushr: shift 距離只取低 5 bits (負數也一樣)
+func TestIntShift(t *testing.T) {
+	tests := []struct {
+		opcode   byte
+		value    int32
+		distance int32
+		want     int32
+	}{
+		{opcodes.ISHL, 1, 3, 8},
+		{opcodes.ISHL, 1, 33, 2},             // 33 & 0x1F = 1
+		{opcodes.ISHL, 1, 32, 1},             // 32 & 0x1F = 0
+		{opcodes.ISHL, 1, -1, math.MinInt32}, // -1 & 0x1F = 31
+		{opcodes.ISHL, 1, -31, 2},            // -31 & 0x1F = 1
+		{opcodes.ISHL, 0x40000000, 1, math.MinInt32},
+		{opcodes.ISHR, -16, 2, -4},            // 符號擴展
+		{opcodes.ISHR, math.MinInt32, 31, -1}, // 符號擴展
+		{opcodes.ISHR, -1, 28, -1},
+		{opcodes.ISHR, 16, 34, 4},   // 34 & 0x1F = 2
+		{opcodes.ISHR, 16, -30, 4},  // -30 & 0x1F = 2
+		{opcodes.IUSHR, -1, 28, 15}, // 補 0
+		{opcodes.IUSHR, -16, 2, 0x3FFFFFFC},
+		{opcodes.IUSHR, math.MinInt32, 31, 1},
+		{opcodes.IUSHR, -1, 32, -1}, // 32 & 0x1F = 0
+		{opcodes.IUSHR, -1, -4, 15}, // -4 & 0x1F = 28
+	}
+
+	for _, tt := range tests {
+		stack := executeSingle(t, tt.opcode, func(s *runtime.OperandStack) {
+			s.PushInt(tt.value)
+			s.PushInt(tt.distance)
+		})
+		if got := stack.PopInt(); got != tt.want {
+			t.Errorf("%s(%d, %d) = %d, want %d", opcodes.OpcodeNames[tt.opcode], tt.value, tt.distance, got, tt.want)
+		}
+	}
+}
+
+// TestLongShift 測試 lshl / lshr / lushr: 值是 long, shift 距離是 int, 只取低 6 bits
+func TestLongShift(t *testing.T) {
+	tests := []struct {
+		opcode   byte
+		value    int64
+		distance int32
+		want     int64
+	}{
+		{opcodes.LSHL, 1, 3, 8},
+		{opcodes.LSHL, 1, 65, 2},             // 65 & 0x3F = 1
+		{opcodes.LSHL, 1, 64, 1},             // 64 & 0x3F = 0
+		{opcodes.LSHL, 1, 33, 1 << 33},       // long 不受 int 的 5 bits 限制
+		{opcodes.LSHL, 1, -1, math.MinInt64}, // -1 & 0x3F = 63
+		{opcodes.LSHR, -16, 2, -4},           // 符號擴展
+		{opcodes.LSHR, math.MinInt64, 63, -1},
+		{opcodes.LSHR, 16, 66, 4},   // 66 & 0x3F = 2
+		{opcodes.LSHR, 16, -62, 4},  // -62 & 0x3F = 2
+		{opcodes.LUSHR, -1, 60, 15}, // 補 0
+		{opcodes.LUSHR, math.MinInt64, 63, 1},
+		{opcodes.LUSHR, -1, 64, -1}, // 64 & 0x3F = 0
+		{opcodes.LUSHR, -1, -4, 15}, // -4 & 0x3F = 60
+	}
+
+	for _, tt := range tests {
+		stack := executeSingle(t, tt.opcode, func(s *runtime.OperandStack) {
+			s.PushLong(tt.value)
+			s.PushInt(tt.distance)
+		})
+		if got := stack.PopLong(); got != tt.want {
+			t.Errorf("%s(%d, %d) = %d, want %d", opcodes.OpcodeNames[tt.opcode], tt.value, tt.distance, got, tt.want)
+		}
+	}
+}
+
+// TestIntBitwise 測試 iand / ior / ixor
+func TestIntBitwise(t *testing.T) {
+	tests := []struct {
+		opcode byte
+		v1, v2 int32
+		want   int32
+	}{
+		{opcodes.IAND, 0x0F0F, 0x00FF, 0x000F},
+		{opcodes.IAND, -1, math.MinInt32, math.MinInt32},
+		{opcodes.IAND, 12345, 0, 0},
+		{opcodes.IOR, 0x0F00, 0x00F0, 0x0FF0},
+		{opcodes.IOR, math.MinInt32, 1, math.MinInt32 + 1},
+		{opcodes.IXOR, 0x0FF0, 0x00FF, 0x0F0F},
+		{opcodes.IXOR, 12345, -1, ^int32(12345)}, // ~x 由 ixor -1 實作
+		{opcodes.IXOR, 7, 7, 0},
+	}
+
+	for _, tt := range tests {
+		stack := executeSingle(t, tt.opcode, func(s *runtime.OperandStack) {
+			s.PushInt(tt.v1)
+			s.PushInt(tt.v2)
+		})
+		if got := stack.PopInt(); got != tt.want {
+			t.Errorf("%s(%d, %d) = %d, want %d", opcodes.OpcodeNames[tt.opcode], tt.v1, tt.v2, got, tt.want)
+		}
+	}
+}
+
+// TestLongBitwise 測試 land / lor / lxor (高 32 bits 也要參與運算)
+func TestLongBitwise(t *testing.T) {
+	tests := []struct {
+		opcode byte
+		v1, v2 int64
+		want   int64
+	}{
+		{opcodes.LAND, 0x0F0F_0000_0F0F, 0x00FF_0000_00FF, 0x000F_0000_000F},
+		{opcodes.LAND, -1, math.MinInt64, math.MinInt64},
+		{opcodes.LOR, 0x1_0000_0000, 1, 0x1_0000_0001},
+		{opcodes.LOR, math.MinInt64, 1, math.MinInt64 + 1},
+		{opcodes.LXOR, 0x0FF0_0000_0000, 0x00FF_0000_0000, 0x0F0F_0000_0000},
+		{opcodes.LXOR, 12345, -1, ^int64(12345)},
+	}
+
+	for _, tt := range tests {
+		stack := executeSingle(t, tt.opcode, func(s *runtime.OperandStack) {
+			s.PushLong(tt.v1)
+			s.PushLong(tt.v2)
+		})
+		if got := stack.PopLong(); got != tt.want {
+			t.Errorf("%s(%d, %d) = %d, want %d", opcodes.OpcodeNames[tt.opcode], tt.v1, tt.v2, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package interpreter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Johnny1110/gogo_jvm/instructions"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+)
+
+// executeSingle 建立一個 frame，用 push 準備 operand，執行單一 opcode 後回傳 operand stack
+func executeSingle(t *testing.T, opcode byte, push func(stack *runtime.OperandStack)) *runtime.OperandStack {
+	thread := runtime.NewThread()
+	frame := thread.NewFrame(0, 4)
+	thread.PushFrame(frame)
+
+	push(frame.OperandStack())
+
+	inst, err := instructions.NewInstruction(opcode)
+	if err != nil {
+		t.Fatalf("decode %s failed: %v", opcodes.OpcodeNames[opcode], err)
+	}
+	inst.Execute(frame)
+	return frame.OperandStack()
+}
+
+// TestIntNarrowing 測試 i2b / i2c / i2s 的截斷與符號擴展
+func TestIntNarrowing(t *testing.T) {
+	tests := []struct {
+		opcode byte
+		input  int32
+		want   int32
+	}{
+		{opcodes.I2B, 127, 127},
+		{opcodes.I2B, 128, -128},
+		{opcodes.I2B, 200, -56},
+		{opcodes.I2B, -129, 127},
+		{opcodes.I2B, 0x1FF, -1},
+		{opcodes.I2C, -1, 65535},
+		{opcodes.I2C, 65536, 0},
+		{opcodes.I2C, 'A', 'A'},
+		{opcodes.I2C, math.MinInt32, 0},
+		{opcodes.I2S, 32767, 32767},
+		{opcodes.I2S, 32768, -32768},
+		{opcodes.I2S, 40000, -25536},
+		{opcodes.I2S, -32769, 32767},
+	}
+
+	for _, tt := range tests {
+		stack := executeSingle(t, tt.opcode, func(s *runtime.OperandStack) { s.PushInt(tt.input) })
+		if got := stack.PopInt(); got != tt.want {
+			t.Errorf("%s(%d) = %d, want %d", opcodes.OpcodeNames[tt.opcode], tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestFloatingToInt 測試 f2i / d2i: NaN -> 0, 超出範圍飽和, 其餘向 0 截斷
+func TestFloatingToInt(t *testing.T) {
+	tests := []struct {
+		opcode byte
+		input  float64
+		want   int32
+	}{
+		{opcodes.D2I, 3.7, 3},
+		{opcodes.D2I, -3.7, -3},
+		{opcodes.D2I, math.NaN(), 0},
+		{opcodes.D2I, math.Inf(1), math.MaxInt32},
+		{opcodes.D2I, math.Inf(-1), math.MinInt32},
+		{opcodes.D2I, 2147483647.9, math.MaxInt32},
+		{opcodes.D2I, 1e10, math.MaxInt32},
+		{opcodes.D2I, -1e10, math.MinInt32},
+		{opcodes.D2I, -2147483648.9, math.MinInt32},
+		{opcodes.D2I, math.Copysign(0, -1), 0},
+		{opcodes.F2I, 3.7, 3},
+		{opcodes.F2I, -0.5, 0},
+		{opcodes.F2I, math.NaN(), 0},
+		{opcodes.F2I, 3e9, math.MaxInt32},
+		{opcodes.F2I, -3e9, math.MinInt32},
+		{opcodes.F2I, math.Inf(1), math.MaxInt32},
+	}
+
+	for _, tt := range tests {
+		stack := executeSingle(t, tt.opcode, func(s *runtime.OperandStack) {
+			if tt.opcode == opcodes.F2I {
+				s.PushFloat(float32(tt.input))
+			} else {
+				s.PushDouble(tt.input)
+			}
+		})
+		if got := stack.PopInt(); got != tt.want {
+			t.Errorf("%s(%v) = %d, want %d", opcodes.OpcodeNames[tt.opcode], tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestFloatingToLong 測試 f2l / d2l 的 NaN 與飽和
+func TestFloatingToLong(t *testing.T) {
+	tests := []struct {
+		opcode byte
+		input  float64
+		want   int64
+	}{
+		{opcodes.D2L, 1e18, 1000000000000000000},
+		{opcodes.D2L, -2.9, -2},
+		{opcodes.D2L, math.NaN(), 0},
+		{opcodes.D2L, 9.3e18, math.MaxInt64},
+		{opcodes.D2L, -9.3e18, math.MinInt64},
+		{opcodes.D2L, math.Inf(1), math.MaxInt64},
+		{opcodes.D2L, math.Inf(-1), math.MinInt64},
+		{opcodes.F2L, 1.5, 1},
+		{opcodes.F2L, math.NaN(), 0},
+		{opcodes.F2L, 1e19, math.MaxInt64},
+		{opcodes.F2L, -1e19, math.MinInt64},
+	}
+
+	for _, tt := range tests {
+		stack := executeSingle(t, tt.opcode, func(s *runtime.OperandStack) {
+			if tt.opcode == opcodes.F2L {
+				s.PushFloat(float32(tt.input))
+			} else {
+				s.PushDouble(tt.input)
+			}
+		})
+		if got := stack.PopLong(); got != tt.want {
+			t.Errorf("%s(%v) = %d, want %d", opcodes.OpcodeNames[tt.opcode], tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestWidening 測試 i2l / i2f / i2d / l2i / l2f / l2d / f2d / d2f
+func TestWidening(t *testing.T) {
+	// i2l keeps sign
+	stack := executeSingle(t, opcodes.I2L, func(s *runtime.OperandStack) { s.PushInt(-5) })
+	if got := stack.PopLong(); got != -5 {
+		t.Errorf("i2l(-5) = %d, want -5", got)
+	}
+
+	// l2i keeps low 32 bits
+	stack = executeSingle(t, opcodes.L2I, func(s *runtime.OperandStack) { s.PushLong(0x1_0000_0005) })
+	if got := stack.PopInt(); got != 5 {
+		t.Errorf("l2i(0x100000005) = %d, want 5", got)
+	}
+	stack = executeSingle(t, opcodes.L2I, func(s *runtime.OperandStack) { s.PushLong(0xFFFF_FFFF) })
+	if got := stack.PopInt(); got != -1 {
+		t.Errorf("l2i(0xFFFFFFFF) = %d, want -1", got)
+	}
+
+	// i2f rounds to nearest (16777217 is not representable in float)
+	stack = executeSingle(t, opcodes.I2F, func(s *runtime.OperandStack) { s.PushInt(16777217) })
+	if got := stack.PopFloat(); got != 16777216 {
+		t.Errorf("i2f(16777217) = %v, want 16777216", got)
+	}
+
+	stack = executeSingle(t, opcodes.I2D, func(s *runtime.OperandStack) { s.PushInt(math.MinInt32) })
+	if got := stack.PopDouble(); got != math.MinInt32 {
+		t.Errorf("i2d(MIN) = %v, want %v", got, float64(math.MinInt32))
+	}
+
+	stack = executeSingle(t, opcodes.L2F, func(s *runtime.OperandStack) { s.PushLong(math.MaxInt64) })
+	if got := stack.PopFloat(); got != 9.223372e18 {
+		t.Errorf("l2f(MAX) = %v, want 9.223372e18", got)
+	}
+
+	stack = executeSingle(t, opcodes.L2D, func(s *runtime.OperandStack) { s.PushLong(-42) })
+	if got := stack.PopDouble(); got != -42 {
+		t.Errorf("l2d(-42) = %v, want -42", got)
+	}
+
+	stack = executeSingle(t, opcodes.F2D, func(s *runtime.OperandStack) { s.PushFloat(float32(math.NaN())) })
+	if got := stack.PopDouble(); !math.IsNaN(got) {
+		t.Errorf("f2d(NaN) = %v, want NaN", got)
+	}
+
+	// d2f overflow -> Infinity, underflow -> 0
+	stack = executeSingle(t, opcodes.D2F, func(s *runtime.OperandStack) { s.PushDouble(1e300) })
+	if got := stack.PopFloat(); !math.IsInf(float64(got), 1) {
+		t.Errorf("d2f(1e300) = %v, want +Inf", got)
+	}
+	stack = executeSingle(t, opcodes.D2F, func(s *runtime.OperandStack) { s.PushDouble(1e-300) })
+	if got := stack.PopFloat(); got != 0 {
+		t.Errorf("d2f(1e-300) = %v, want 0", got)
+	}
+}
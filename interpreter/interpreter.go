@@ -14,18 +14,39 @@ import (
 )
 
 // Interpret Bytecode interpret
-func Interpret(method *method_area.Method, debug bool) {
+// args: command-line arguments passed to main(String[] args)
+func Interpret(method *method_area.Method, args []string, debug bool) {
 	// 1. create thread
 	thread := runtime.NewThread()
+	// VM upcall (ex: user-defined ClassLoader.loadClass) runs on this thread
+	method_area.SetJavaCaller(func(method *method_area.Method, args ...interface{}) (interface{}, *heap.Object) {
+		return InvokeMethod(thread, method, args...)
+	})
+
+	// 2. initialize main class before main() (JVMS 5.5: invokestatic is an init trigger), run its <clinit> frames first
+	if !references.InitClass(thread, method.Class()) {
+		loop(thread, debug)
+	}
 
-	// 2. create frame
+	// 3. create frame, main(String[] args): args → LocalVars[0]
 	frame := thread.NewFrameWithMethodAndExHandler(method, references.ThrowException)
+	frame.LocalVars().SetRef(0, createArgsArray(method.Class().Loader(), args))
 	thread.PushFrame(frame)
 
-	// 3. start execute
+	// 4. start execute
 	loop(thread, debug)
 }
 
+// createArgsArray go []string → java String[]
+func createArgsArray(loader *method_area.ClassLoader, args []string) *heap.Object {
+	arrayClass := loader.LoadClass("[Ljava/lang/String;", false)
+	argsArray := heap.NewRefArray(arrayClass, int32(len(args)))
+	for i, arg := range args {
+		argsArray.SetArrayRef(int32(i), heap.InternString(arg, loader))
+	}
+	return argsArray
+}
+
 // loop interpreter main logic
 // Fetch -> Decode -> Execute -> Fetch ...
 func loop(thread *runtime.Thread, debug bool) {
@@ -37,36 +58,7 @@ func loop(thread *runtime.Thread, debug bool) {
 	// when func returned, stack will be empty (for main method)
 	// or current frame is not origin frame (for not main method)
 	for !thread.IsStackEmpty() {
-		// get current frame
-		frame := thread.CurrentFrame()
-
-		// get bytecode from frame's method
-		bytecode := frame.Method().Code()
-
-		// calculate PC
-		pc := frame.NextPC()
-		thread.SetPC(pc)
-
-		// Fetch: 1 byte opcodes
-		reader.Reset(bytecode, pc)
-		opcode := reader.ReadUint8()
-
-		// Decode:
-		instruction, err := instructions.NewInstruction(opcode)
-		if err != nil {
-			fmt.Printf("Error parsing instruction: %s\n", err)
-			os.Exit(1)
-		}
-		instruction.FetchOperands(reader) // fetch (index, offset) if required
-		frame.SetNextPC(reader.PC())      // update PC (to next instruction)
-
-		if debug {
-			fmt.Println("<--------------------------------------------------------------------------------->")
-			printDebug(pc, instruction, frame)
-		}
-
-		// Execute: perform instruction
-		instruction.Execute(frame)
+		step(thread, reader, debug)
 	}
 
 	if debug {
@@ -75,7 +67,9 @@ func loop(thread *runtime.Thread, debug bool) {
 		for i, slot := range mainMethodFrame.LocalVars() {
 			fmt.Printf("* Slot - %d:\n", i)
 
-			if slot.Ref != nil {
+			if addr, ok := slot.Ref.(rtcore.ReturnAddress); ok {
+				fmt.Printf("\t <RETURN_ADDRESS>: %d \n", addr)
+			} else if slot.Ref != nil {
 				fmt.Printf("\t <REF>: %v \n", slot.Ref)
 				obj := slot.Ref.(*heap.Object)
 				if obj.IsArray() {
@@ -91,6 +85,50 @@ func loop(thread *runtime.Thread, debug bool) {
 	}
 }
 
+// step Fetch -> Decode -> Execute one instruction of current frame
+// VM raised java exception (panic *common.JavaException) is thrown in current frame, so java code can catch it,
+// any other panic (Go runtime error ...) is thrown as java/lang/InternalError
+func step(thread *runtime.Thread, reader *base.BytecodeReader, debug bool) {
+	// get current frame
+	frame := thread.CurrentFrame()
+	defer func() {
+		if r := recover(); r != nil {
+			if debug {
+				fmt.Printf("@@ DEBUG - recovered panic in %s.%s: %v\n", frame.Method().Class().Name(), frame.Method().Name(), r)
+			}
+			references.ThrowPanic(frame, r)
+		}
+	}()
+
+	// get bytecode from frame's method
+	bytecode := frame.Method().Code()
+
+	// calculate PC
+	pc := frame.NextPC()
+	thread.SetPC(pc)
+
+	// Fetch: 1 byte opcodes
+	reader.Reset(bytecode, pc)
+	opcode := reader.ReadUint8()
+
+	// Decode:
+	instruction, err := instructions.NewInstruction(opcode)
+	if err != nil {
+		fmt.Printf("Error parsing instruction: %s\n", err)
+		os.Exit(1)
+	}
+	instruction.FetchOperands(reader) // fetch (index, offset) if required
+	frame.SetNextPC(reader.PC())      // update PC (to next instruction)
+
+	if debug {
+		fmt.Println("<--------------------------------------------------------------------------------->")
+		printDebug(pc, instruction, frame)
+	}
+
+	// Execute: perform instruction
+	instruction.Execute(frame)
+}
+
 // printDebug print debug info
 func printDebug(pc int, inst base.Instruction, frame *runtime.Frame) {
 	opName := opcodes.OpcodeNames[inst.Opcode()]
@@ -1,12 +1,22 @@
 package interpreter
 
 import (
+	"bufio"
 	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
 	"github.com/Johnny1110/gogo_jvm/instructions"
 	"github.com/Johnny1110/gogo_jvm/instructions/base"
 	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
 	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+	"os"
+	"strings"
 	"testing"
+	"time"
+
+	// register native methods (PrintStream.println)
+	_ "github.com/Johnny1110/gogo_jvm/native"
 )
 
 // executeAndGetLocal0 執行字節碼並返回 locals[0] 的值
@@ -292,3 +302,421 @@ func TestSimpleLoop(t *testing.T) {
 	}
 	t.Log("✓ sum(1..3) = 6")
 }
+
+// TestWide 測試 wide 前綴: local var index > 255
+func TestWide(t *testing.T) {
+	code := []byte{
+		opcodes.BIPUSH, 7, // 0: bipush 7
+		opcodes.WIDE, opcodes.ISTORE, 0x01, 0x00, // 2: wide istore 256
+		opcodes.WIDE, opcodes.IINC, 0x01, 0x00, 0x03, 0xE8, // 6: wide iinc 256, 1000
+		opcodes.WIDE, opcodes.ILOAD, 0x01, 0x00, // 12: wide iload 256
+		opcodes.ISTORE_0, // 16: istore_0
+		opcodes.RETURN,   // 17: return
+	}
+
+	result := executeAndGetLocal0(code, 257, 1, false)
+	if result != 1007 {
+		t.Errorf("Expected 1007, got %d", result)
+	}
+	t.Log("✓ wide istore/iinc/iload 256 = 1007")
+}
+
+// TestJsrRet 測試 jsr/ret (Java 6 以前的 finally)
+// x = 1; jsr { x = x * 2 }; x += 10  -> 12 (順序錯誤會得到 22)
+func TestJsrRet(t *testing.T) {
+	code := []byte{
+		opcodes.ICONST_1,        // 0: iconst_1
+		opcodes.ISTORE_0,        // 1: istore_0   x = 1
+		opcodes.JSR, 0x00, 0x07, // 2: jsr +7     跳到 9, push returnAddress 5
+		opcodes.IINC, 0x00, 0x0A, // 5: iinc 0, 10
+		opcodes.RETURN, // 8: return
+
+		// subroutine
+		opcodes.ASTORE_1,  // 9: astore_1    保存 returnAddress
+		opcodes.ILOAD_0,   // 10: iload_0
+		opcodes.ICONST_2,  // 11: iconst_2
+		opcodes.IMUL,      // 12: imul
+		opcodes.ISTORE_0,  // 13: istore_0   x = x * 2
+		opcodes.RET, 0x01, // 14: ret 1     回到 5
+	}
+
+	result := executeAndGetLocal0(code, 2, 2, false)
+	if result != 12 {
+		t.Errorf("Expected 12, got %d", result)
+	}
+	t.Log("✓ jsr/ret = 12")
+}
+
+func TestStackOverflowCaught(t *testing.T) {
+	runtime.SetDefaultStackSize(64)
+	defer runtime.SetDefaultStackSize(runtime.DEFAULT_STACK_SIZE)
+
+	loader := method_area.NewClassLoader("../test/class")
+	class := loader.LoadClass("TestStackOverflow", false)
+	Interpret(class.GetMainMethod(), nil, false)
+
+	// main + 63 recurse frames, the 64th invoke overflows and is caught in main
+	depth := class.StaticVars().GetInt(class.GetField("depth", "I", true).SlotId())
+	if depth != 63 {
+		t.Errorf("Expected depth 63, got %d", depth)
+	}
+}
+
+func TestVMExceptionCaught(t *testing.T) {
+	loader := method_area.NewClassLoader("../test/class")
+	class := loader.LoadClass("TestVMException", false)
+	Interpret(class.GetMainMethod(), nil, false)
+
+	// ArrayIndexOutOfBoundsException(1) + NullPointerException(2) + NegativeArraySizeException(4)
+	caught := class.StaticVars().GetInt(class.GetField("caught", "I", true).SlotId())
+	if caught != 7 {
+		t.Errorf("Expected caught 7, got %d", caught)
+	}
+}
+
+func TestClassInitialization(t *testing.T) {
+	loader := method_area.NewClassLoader("../test/class")
+	class := loader.LoadClass("TestClassInit", false)
+	Interpret(class.GetMainMethod(), nil, false)
+
+	// InitBase(1) → InitIface(2, default method) → InitSub(3), InitNoDefault(9) not initialized
+	order := class.StaticVars().GetInt(class.GetField("order", "I", true).SlotId())
+	if order != 123 {
+		t.Errorf("Expected init order 123, got %d", order)
+	}
+	// ExceptionInInitializerError(1) + NoClassDefFoundError(2)
+	caught := class.StaticVars().GetInt(class.GetField("caught", "I", true).SlotId())
+	if caught != 3 {
+		t.Errorf("Expected caught 3, got %d", caught)
+	}
+	if state := loader.LoadClass("InitBad", false).InitState(); state != method_area.CLASS_ERRONEOUS {
+		t.Errorf("Expected InitBad erroneous, got %v", state)
+	}
+}
+
+// runMainOutput 執行 test/class 下的 main class (args 傳入 main), 回傳 java 程式的輸出 (過濾 VM 的 debug log)
+func runMainOutput(t *testing.T, className string, args ...string) []string {
+	t.Helper()
+	loader := method_area.NewClassLoader("../test/class")
+	class := loader.LoadClass(className, false)
+
+	var output []string
+	for _, line := range captureLines(t, &os.Stdout, func() { Interpret(class.GetMainMethod(), args, false) }) {
+		if !strings.HasPrefix(line, "@@") && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			output = append(output, line)
+		}
+	}
+	return output
+}
+
+// captureLines 執行 run 期間把 *file (os.Stdout / os.Stderr) 導到 pipe, 回傳寫入的每一行
+func captureLines(t *testing.T, file **os.File, run func()) []string {
+	t.Helper()
+	origin := *file
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	*file = w
+	done := make(chan []string)
+	go func() {
+		var lines []string
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		done <- lines
+	}()
+
+	defer func() {
+		*file = origin
+	}()
+	run()
+	w.Close()
+	return <-done
+}
+
+func TestStringConcat(t *testing.T) {
+	output := runMainOutput(t, "TestStringConcat")
+	expected := []string{
+		"a=1, b=true, c=X",
+		"long=9000000000, float=1.5, double=0.25",
+		"big=1.0E7, small=1.0E-4",
+		"null=null",
+		"boxed=7, char=Y", // boxed 參數印出值
+		"point=(1, 2)",    // 覆寫的 toString() (upcall)
+		"Hello, GoGo JVM!",
+	}
+	if strings.Join(output, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(output, "\n"))
+	}
+}
+
+// switchCode [nop * pad] sipush key, <switch>, 每個 target: bipush result, istore_0, return
+// 前面的 nop 讓 switch opcode 位於 pc%4 = 0~3, 測試 0~3 bytes 的 padding
+func switchCode(pad int, key int16, opcode byte, operands func(targets []int32) []int32, results []int8) []byte {
+	code := make([]byte, pad)
+	code = append(code, opcodes.SIPUSH, byte(key>>8), byte(key))
+	switchPC := len(code)
+	code = append(code, opcode)
+	for len(code)%4 != 0 {
+		code = append(code, 0)
+	}
+
+	// operands 的長度與 target 位置無關, 先用假 offset 算出長度
+	operandsLen := 4 * len(operands(make([]int32, len(results))))
+	targets := make([]int32, len(results))
+	for i := range results {
+		targets[i] = int32(len(code) + operandsLen + i*4 - switchPC)
+	}
+	for _, v := range operands(targets) {
+		code = append(code, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+	for _, result := range results {
+		code = append(code, opcodes.BIPUSH, byte(result), opcodes.ISTORE_0, opcodes.RETURN)
+	}
+	return code
+}
+
+func TestTableSwitchPadding(t *testing.T) {
+	// switch (key) { case 0: 10; case 1: 11; case 2: 12; default: -1 }
+	// targets: [default, case 0, case 1, case 2]
+	operands := func(targets []int32) []int32 {
+		return []int32{targets[0], 0, 2, targets[1], targets[2], targets[3]}
+	}
+	cases := map[int16]int32{0: 10, 1: 11, 2: 12, 3: -1, -1: -1}
+
+	for pad := 0; pad < 4; pad++ {
+		for key, expected := range cases {
+			code := switchCode(pad, key, opcodes.TABLESWITCH, operands, []int8{-1, 10, 11, 12})
+			if result := executeAndGetLocal0(code, 1, 1, false); result != expected {
+				t.Errorf("tableswitch at pc%%4=%d, key %d: expected %d, got %d", (pad+3)%4, key, expected, result)
+			}
+		}
+	}
+}
+
+func TestLookupSwitchPadding(t *testing.T) {
+	// switch (key) { case -1000: 100; case 7: 20; case 30000: 30; default: -1 }
+	// targets: [default, case -1000, case 7, case 30000]
+	operands := func(targets []int32) []int32 {
+		return []int32{targets[0], 3, -1000, targets[1], 7, targets[2], 30000, targets[3]}
+	}
+	cases := map[int16]int32{-1000: 100, 7: 20, 30000: 30, 8: -1, -32768: -1}
+
+	for pad := 0; pad < 4; pad++ {
+		for key, expected := range cases {
+			code := switchCode(pad, key, opcodes.LOOKUPSWITCH, operands, []int8{-1, 100, 20, 30})
+			if result := executeAndGetLocal0(code, 1, 1, false); result != expected {
+				t.Errorf("lookupswitch at pc%%4=%d, key %d: expected %d, got %d", (pad+3)%4, key, expected, result)
+			}
+		}
+	}
+}
+
+func TestModernClassFile(t *testing.T) {
+	output := runMainOutput(t, "TestModernClassFile")
+	// nestmate private field / method, record accessor, sealed subclasses
+	expected := []string{"10", "14", "3", "4", "12", "25"}
+	if strings.Join(output, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected %v, got %v", expected, output)
+	}
+}
+
+func TestSynchronized(t *testing.T) {
+	output := runMainOutput(t, "TestSynchronized")
+	// monitorenter/exit (含重入), ACC_SYNCHRONIZED, 例外離開 synchronized block 後鎖仍可再進入
+	expected := []string{"1", "2", "3", "4", "5", "6"}
+	if strings.Join(output, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(output, "\n"))
+	}
+}
+
+// TestUnbalancedMonitorExit javac 不會產生不成對的 monitorexit, 直接用 synthetic class 組 bytecode
+func TestUnbalancedMonitorExit(t *testing.T) {
+	loader := method_area.NewClassLoader("../test/class")
+	builder := method_area.NewSyntheticClassBuilder(loader, "UnbalancedMonitor", "java/lang/Object", nil)
+	// static void exit(Object o) { monitorexit o } : 沒有 monitorenter
+	exit := builder.AddMethod(common.ACC_STATIC, "exit", "(Ljava/lang/Object;)V", 1, 1,
+		[]byte{opcodes.ALOAD_0, opcodes.MONITOREXIT, opcodes.RETURN})
+	// synchronized void release() { monitorexit this } : 方法返回時已不持有 ACC_SYNCHRONIZED 的鎖
+	release := builder.AddMethod(common.ACC_SYNCHRONIZED, "release", "()V", 1, 1,
+		[]byte{opcodes.ALOAD_0, opcodes.MONITOREXIT, opcodes.RETURN})
+	class := builder.Define()
+
+	thread := runtime.NewThread()
+	for _, call := range []struct {
+		method *method_area.Method
+		arg    interface{}
+	}{
+		{exit, loader.LoadClass("java/lang/Object", false).NewObject()},
+		{release, class.NewObject()},
+	} {
+		_, ex := InvokeMethod(thread, call.method, call.arg)
+		if ex == nil {
+			t.Errorf("%s: expected IllegalMonitorStateException, got none", call.method.Name())
+			continue
+		}
+		if name := ex.Class().(*method_area.Class).Name(); name != "java/lang/IllegalMonitorStateException" {
+			t.Errorf("%s: expected IllegalMonitorStateException, got %s", call.method.Name(), name)
+		}
+	}
+}
+
+func TestLambda(t *testing.T) {
+	output := runMainOutput(t, "TestLambda")
+	// invokedynamic → LambdaMetafactory.metafactory: static / 捕獲變數 / method ref / 捕獲 this / constructor ref / checkcast
+	expected := []string{"42", "15", "7", "30", "9", "3", "100", "200"}
+	if strings.Join(output, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(output, "\n"))
+	}
+}
+
+func TestFloatCompare(t *testing.T) {
+	output := runMainOutput(t, "TestFloatCompare")
+	// 每組: < <= > >= == != (fcmpg/dcmpg 用於 < <=, fcmpl/dcmpl 用於其他), NaN 一律 false 除了 !=
+	checks := []string{
+		"false false false false false true", // NaN, 1
+		"false false false false false true", // 1, NaN
+		"false false false false false true", // NaN, NaN
+		"false true false true true false",   // 0.0, -0.0
+		"false true false true true false",   // -0.0, 0.0
+		"false false false false false true", // -0.0 / 0.0, NaN
+		"true true false false false true",   // 1, 2
+		"false false true true false true",   // 2, 1
+	}
+	var expected []string
+	for _, check := range append(checks, checks...) { // float, double
+		expected = append(expected, strings.Fields(check)...)
+		expected = append(expected, "")
+	}
+	if strings.Join(output, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(output, "\n"))
+	}
+}
+
+// TestInvokeMethodStackFull VM upcall 時 stack 已滿, 放不下 call stub: 回傳 StackOverflowError 而不是 panic
+func TestInvokeMethodStackFull(t *testing.T) {
+	loader := method_area.NewClassLoader("../test/class")
+	builder := method_area.NewSyntheticClassBuilder(loader, "StackFullCall", "java/lang/Object", nil)
+	method := builder.AddMethod(common.ACC_STATIC, "run", "()V", 0, 0, []byte{opcodes.RETURN})
+	builder.Define()
+
+	thread := runtime.NewThreadWithStackDepth(1)
+	thread.PushFrame(thread.NewFrameWithMethodAndExHandler(method, nil))
+	_, ex := InvokeMethod(thread, method)
+	if ex == nil {
+		t.Fatal("expected StackOverflowError, got none")
+	}
+	if name := ex.Class().(*method_area.Class).Name(); name != "java/lang/StackOverflowError" {
+		t.Errorf("expected StackOverflowError, got %s", name)
+	}
+}
+
+func TestMainArgs(t *testing.T) {
+	// 沒有參數: 空陣列, 不是 null
+	if output := runMainOutput(t, "TestArgs"); strings.Join(output, "\n") != "0" {
+		t.Errorf("Expected output 0, got:\n%s", strings.Join(output, "\n"))
+	}
+
+	output := runMainOutput(t, "TestArgs", "hello", "-debug", "two words")
+	expected := []string{"3", "hello", "-debug", "two words"}
+	if strings.Join(output, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(output, "\n"))
+	}
+}
+
+func TestStackTrace(t *testing.T) {
+	var output []string
+	stderr := captureLines(t, &os.Stderr, func() { output = runMainOutput(t, "TestStackTrace") })
+
+	// getStackTrace(): 從 create() 開始 (跳過建構子 frame), 行號來自 LineNumberTable
+	expected := []string{"2", "create", "19", "23"}
+	if strings.Join(output, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(output, "\n"))
+	}
+	// printStackTrace(): cause 與外層相同的 frame 折疊成 "... N more"
+	expected = []string{
+		"java.lang.Throwable: outer",
+		"\tat TestStackTrace.wrap(TestStackTrace.java:33)",
+		"\tat TestStackTrace.main(TestStackTrace.java:29)",
+		"Caused by: java.lang.RuntimeException",
+		"\tat TestStackTrace.create(TestStackTrace.java:19)",
+		"\t... 1 more",
+	}
+	if strings.Join(stderr, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected System.err:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(stderr, "\n"))
+	}
+}
+
+func TestUserClassLoader(t *testing.T) {
+	output := runMainOutput(t, "TestClassLoader")
+	// ClassLoader 子類別 → defineClass1 / findLoadedClass0, parent first 委派, 重複定義 / 名稱不符
+	expected := []string{"true", "true", "true", "true", "false", "true", "true", "true", "42", "duplicate", "wrong name"}
+	if strings.Join(output, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(output, "\n"))
+	}
+}
+
+func TestLoaderConstraint(t *testing.T) {
+	output := runMainOutput(t, "TestLoaderConstraint")
+	// field / method / override / load 都是 catch (LinkageError e) 印出: 違反 loader constraint 的 FieldRef / MethodRef 解析,
+	// 覆寫方法的 descriptor 型別不同, 以及約束記錄後才定義的 Secret
+	expected := []string{"42", "42", "field", "method", "override", "true", "load"}
+	if strings.Join(output, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(output, "\n"))
+	}
+}
+
+func TestClassUnloading(t *testing.T) {
+	// 先回收前面測試留下的 loader, 避免它們的 unloading log 混進來
+	for i := 0; i < 3; i++ {
+		heap.SystemGC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	method_area.SetVerboseClass(true)
+	defer method_area.SetVerboseClass(false)
+
+	loader := method_area.NewClassLoader("../test/class")
+	class := loader.LoadClass("TestClassUnloading", false)
+	lines := captureLines(t, &os.Stdout, func() {
+		Interpret(class.GetMainMethod(), nil, false)
+		// System.gc() 回收 loader 後, finalizer goroutine 才記錄 unloading
+		for deadline := time.Now().Add(5 * time.Second); loader.UnloadedClassCount() < 10 && time.Now().Before(deadline); {
+			heap.SystemGC()
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	var output []string
+	unloadedPlugins, total := 0, false
+	for _, line := range lines {
+		switch {
+		case line == "[Unloading class Plugin]":
+			unloadedPlugins++
+		case line == "[Unloaded 1 classes of loader PluginLoader, total unloaded: 10]":
+			total = true
+		case !strings.HasPrefix(line, "[") && !strings.HasPrefix(line, "@@") && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t"):
+			output = append(output, line)
+		}
+	}
+	if strings.Join(output, "\n") != "420" {
+		t.Errorf("Expected output 420, got:\n%s", strings.Join(output, "\n"))
+	}
+	// 10 個 job, 每個 PluginLoader 定義的 Plugin 跟著 loader 一起卸載 (-verbose:class log)
+	if count := loader.UnloadedClassCount(); count != 10 {
+		t.Errorf("Expected 10 unloaded classes, got %d", count)
+	}
+	if unloadedPlugins < 10 || !total {
+		t.Errorf("Expected [Unloading class Plugin] x10 and total unloaded: 10, got:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestConstantValue(t *testing.T) {
+	output := runMainOutput(t, "TestConstantValue")
+	// getstatic 讀另一個 class 的 static final 常量: preparation 階段由 ConstantValue 初始化, String 常量已 intern
+	expected := []string{"42", "1234567890123", "1.5", "2.25", "A", "gogo", "true"}
+	if strings.Join(output, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(output, "\n"))
+	}
+}
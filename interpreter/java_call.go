@@ -0,0 +1,55 @@
+package interpreter
+
+import (
+	"github.com/Johnny1110/gogo_jvm/instructions/base"
+	"github.com/Johnny1110/gogo_jvm/instructions/references"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+	"strings"
+)
+
+// ============================================================
+// Java Call - VM upcall
+// ============================================================
+// Go code of VM calls java method and waits for its result (ex: user-defined ClassLoader.loadClass while resolving class),
+// method runs in a nested interpreter loop on the same thread, on top of a call stub frame:
+//
+//	| method frames ... |  ← nested loop runs until call stub becomes current frame again
+//	| call stub         |  ← no method: holds args, receives return value or exception not caught by method
+//	| caller frames     |  ← instruction of current frame is still executing (Go code)
+
+// InvokeMethod invoke java method with reference args (this first) and run it to completion
+// return method's return value (reference, nil for void) or exception thrown by it
+func InvokeMethod(thread *runtime.Thread, method *method_area.Method, args ...interface{}) (interface{}, *heap.Object) {
+	// caller's instruction may RevertNextPC after upcall (ex: invokestatic waits for <clinit>)
+	pc := thread.PC()
+	defer thread.SetPC(pc)
+
+	// no room for call stub: StackOverflowError to the caller (stack is full, so there is a current frame)
+	if thread.IsStackFull() {
+		return nil, references.NewStackOverflowError(thread.CurrentFrame())
+	}
+
+	stub := thread.NewFrame(1, uint16(len(args)+2))
+	for _, arg := range args {
+		stub.OperandStack().PushRef(arg)
+	}
+	thread.PushFrame(stub)
+
+	references.InvokeMethod(stub, method)
+	reader := &base.BytecodeReader{}
+	for thread.CurrentFrame() != stub {
+		step(thread, reader, false)
+	}
+	thread.PopFrame()
+
+	// exception stopped at call stub, see references.handleException
+	if ex, ok := stub.LocalVars().GetRef(0).(*heap.Object); ok && ex != nil {
+		return nil, ex
+	}
+	if strings.HasSuffix(method.Descriptor(), ")V") {
+		return nil, nil
+	}
+	return stub.OperandStack().PopRef(), nil
+}
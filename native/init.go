@@ -6,6 +6,7 @@ import (
 	// using _ import, only for auto trigger init() func
 	_ "github.com/Johnny1110/gogo_jvm/native/java/io" // this will auto trigger all .go file init() func
 	_ "github.com/Johnny1110/gogo_jvm/native/java/lang"
+	_ "github.com/Johnny1110/gogo_jvm/native/java/lang/invoke"
 	_ "github.com/Johnny1110/gogo_jvm/native/java/lang/ref"
 	// _ "github.com/Johnny1110/gogo_jvm/native/java/util"
 )
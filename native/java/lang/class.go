@@ -30,6 +30,7 @@ func init() {
 
 	// dynamic loading
 	runtime.Register("java/lang/Class", "forName0", "(Ljava/lang/String;ZLjava/lang/ClassLoader;Ljava/lang/Class;)Ljava/lang/Class;", forName0)
+	runtime.Register("java/lang/Class", "getClassLoader0", "()Ljava/lang/ClassLoader;", getClassLoader0)
 
 	// new
 	runtime.Register("java/lang/Class", "newInstance", "()Ljava/lang/Object;", newInstance)
@@ -60,7 +61,7 @@ func getPrimitiveClass(frame *runtime.Frame) (ex *heap.Object) {
 	classLoader := frame.Method().Class().Loader()
 	primitiveClass := classLoader.GetPrimitiveClass(goName)
 	if primitiveClass == nil {
-		panic("java.lang.ClassNotFoundException: " + goName)
+		return exception.NewClassNotFoundException(frame, goName)
 	}
 
 	// return java.lang.Class Object
@@ -267,7 +268,9 @@ func forName0(frame *runtime.Frame) (ex *heap.Object) {
 	// java.lang.String → java/lang/String
 	jvmName := strings.ReplaceAll(javaName, ".", "/")
 
-	loader := frame.Method().Class().Loader()
+	// loader == null: bootstrap, user-defined loader loads class by its java loadClass
+	jLoader, _ := frame.LocalVars().GetRef(2).(*heap.Object)
+	loader := frame.Method().Class().Loader().LoaderOf(jLoader)
 	class := loader.LoadClass(jvmName, false)
 
 	// TODO: 根據 initialize 參數決定是否執行 <clinit>, MVP 簡化：總是初始化
@@ -278,6 +281,18 @@ func forName0(frame *runtime.Frame) (ex *heap.Object) {
 	return nil
 }
 
+// ============================================================
+// getClassLoader0 - Class.getClassLoader0()
+// ============================================================
+// Java signature (JDK 8): native ClassLoader getClassLoader0();
+// defining loader's object, null for bootstrap (primitive class, java.lang.Object ...)
+func getClassLoader0(frame *runtime.Frame) (ex *heap.Object) {
+	this := frame.LocalVars().GetThis().(*heap.Object)
+	class := this.Extra().(*method_area.Class)
+	pushLoaderObject(frame, class.Loader())
+	return nil
+}
+
 // ============================================================
 // newInstance - Class.newInstance() (Deprecated in Java 9+)
 // ============================================================
@@ -288,7 +303,7 @@ func newInstance(frame *runtime.Frame) (ex *heap.Object) {
 	class := this.Extra().(*method_area.Class)
 
 	if class.IsInterface() || class.IsAbstract() {
-		panic("java.lang.InstantiationException")
+		return exception.NewInstantiationException(frame, class.JavaName())
 	}
 
 	obj := class.NewObject()
@@ -296,7 +311,7 @@ func newInstance(frame *runtime.Frame) (ex *heap.Object) {
 	// find non-constructor init method
 	constructor := class.GetMethod("<init>", "()V")
 	if constructor == nil {
-		panic("java.lang.InstantiationException: no default constructor")
+		return exception.NewInstantiationException(frame, class.JavaName()+": no default constructor")
 	}
 
 	// TODO: 呼叫建構子 這需要建立新的 Frame 並執行 <init>
@@ -311,10 +326,14 @@ func newInstance(frame *runtime.Frame) (ex *heap.Object) {
 // desiredAssertionStatus0 - Class.desiredAssertionStatus0()
 // ============================================================
 // Java signature: private static native boolean desiredAssertionStatus0(Class<?> clazz);
-// TODO:（MVP 簡化：總是返回 false）
+// by -ea / -da options (runtime.DesiredAssertionStatus)
 func desiredAssertionStatus0(frame *runtime.Frame) (ex *heap.Object) {
-	frame.OperandStack().PushFalse() // assertions disabled
-
+	classObj, _ := frame.LocalVars().GetRef(0).(*heap.Object)
+	if classObj == nil {
+		return exception.NewNullPointerException(frame)
+	}
+	class := classObj.Extra().(*method_area.Class)
+	frame.OperandStack().PushBoolean(runtime.DesiredAssertionStatus(class.Name()))
 	return nil
 }
 
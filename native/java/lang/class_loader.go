@@ -0,0 +1,179 @@
+package lang
+
+import (
+	"github.com/Johnny1110/gogo_jvm/exception"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+	"strings"
+)
+
+// ============================================================
+// java.lang.ClassLoader Native Methods
+// ============================================================
+// loader object <-> runtime ClassLoader, see method_area/user_class_loader.go
+// delegation (parent first) of user-defined loader is done by java code ClassLoader.loadClass,
+// built-in loader objects (platform, app) are BuiltinClassLoader, their loadClass is native.
+func init() {
+	// JDK 9+
+	runtime.Register("java/lang/ClassLoader", "defineClass1", "(Ljava/lang/ClassLoader;Ljava/lang/String;[BIILjava/security/ProtectionDomain;Ljava/lang/String;)Ljava/lang/Class;", classLoaderDefineClass1)
+	runtime.Register("java/lang/ClassLoader", "findBootstrapClass", "(Ljava/lang/String;)Ljava/lang/Class;", classLoaderFindBootstrapClass)
+	// JDK 8: instance method, this takes place of loader arg
+	runtime.Register("java/lang/ClassLoader", "defineClass1", "(Ljava/lang/String;[BIILjava/security/ProtectionDomain;Ljava/lang/String;)Ljava/lang/Class;", classLoaderDefineClass1)
+	runtime.Register("java/lang/ClassLoader", "resolveClass0", "(Ljava/lang/Class;)V", classLoaderResolveClass0)
+
+	runtime.Register("java/lang/ClassLoader", "findLoadedClass0", "(Ljava/lang/String;)Ljava/lang/Class;", classLoaderFindLoadedClass0)
+
+	// minimal rt (non-native in standard Java)
+	runtime.Register("java/lang/ClassLoader", "getSystemClassLoader", "()Ljava/lang/ClassLoader;", classLoaderGetSystemClassLoader)
+	runtime.Register("java/lang/ClassLoader", "getPlatformClassLoader", "()Ljava/lang/ClassLoader;", classLoaderGetPlatformClassLoader)
+	runtime.Register(method_area.BUILTIN_LOADER_CLASS, "loadClass", "(Ljava/lang/String;Z)Ljava/lang/Class;", builtinClassLoaderLoadClass)
+}
+
+// ============================================================
+// defineClass1 - ClassLoader.defineClass1(...)
+// ============================================================
+// Java signature (JDK 9): static native Class<?> defineClass1(ClassLoader loader, String name, byte[] b, int off, int len,
+// ProtectionDomain pd, String source);
+func classLoaderDefineClass1(frame *runtime.Frame) (ex *heap.Object) {
+	vars := frame.LocalVars()
+	jLoader, _ := vars.GetRef(0).(*heap.Object)
+	nameObj, _ := vars.GetRef(1).(*heap.Object)
+	byteArr, _ := vars.GetRef(2).(*heap.Object)
+	off, length := vars.GetInt(3), vars.GetInt(4)
+
+	if byteArr == nil {
+		return exception.NewNullPointerException(frame)
+	}
+	data := byteArr.Bytes()
+	if off < 0 || length < 0 || int(off)+int(length) > len(data) {
+		return exception.NewArrayIndexOutOfBoundsException(frame, off+length)
+	}
+
+	// name == null: any name
+	name := ""
+	if nameObj != nil {
+		name = toInternalName(heap.GoString(nameObj))
+	}
+
+	bytecode := make([]byte, length)
+	for i, b := range data[off : off+length] {
+		bytecode[i] = byte(b)
+	}
+
+	loader := frame.Method().Class().Loader().LoaderOf(jLoader)
+	class := loader.DefineClass(name, bytecode)
+	frame.OperandStack().PushRef(class.JClass())
+	return nil
+}
+
+// ============================================================
+// findLoadedClass0 - ClassLoader.findLoadedClass0(String)
+// ============================================================
+// Java signature: private final native Class<?> findLoadedClass0(String name);
+// class which this loader is recorded as initiating loader
+func classLoaderFindLoadedClass0(frame *runtime.Frame) (ex *heap.Object) {
+	this := frame.LocalVars().GetThis().(*heap.Object)
+	nameObj, _ := frame.LocalVars().GetRef(1).(*heap.Object)
+	if nameObj == nil {
+		frame.OperandStack().PushRef(nil)
+		return nil
+	}
+
+	loader := frame.Method().Class().Loader().LoaderOf(this)
+	pushClassMirror(frame, loader.FindLoadedClass(toInternalName(heap.GoString(nameObj))))
+	return nil
+}
+
+// ============================================================
+// findBootstrapClass - ClassLoader.findBootstrapClass(String)
+// ============================================================
+// Java signature (JDK 9): private static native Class<?> findBootstrapClass(String name);
+// return null if not found
+func classLoaderFindBootstrapClass(frame *runtime.Frame) (ex *heap.Object) {
+	nameObj, _ := frame.LocalVars().GetRef(0).(*heap.Object)
+	if nameObj == nil {
+		frame.OperandStack().PushRef(nil)
+		return nil
+	}
+
+	bootstrap := frame.Method().Class().Loader().BootstrapLoader()
+	pushClassMirror(frame, bootstrap.LoadClassOrNil(toInternalName(heap.GoString(nameObj))))
+	return nil
+}
+
+// ============================================================
+// resolveClass0 - ClassLoader.resolveClass0(Class)
+// ============================================================
+// Java signature (JDK 8): private native void resolveClass0(Class<?> c);
+// class is linked (verified & prepared) when it is defined, nothing more to do
+func classLoaderResolveClass0(frame *runtime.Frame) (ex *heap.Object) {
+	if classObj, _ := frame.LocalVars().GetRef(1).(*heap.Object); classObj == nil {
+		return exception.NewNullPointerException(frame)
+	}
+	return nil
+}
+
+// ============================================================
+// getSystemClassLoader / getPlatformClassLoader
+// ============================================================
+// Java signature: public static ClassLoader getSystemClassLoader();
+func classLoaderGetSystemClassLoader(frame *runtime.Frame) (ex *heap.Object) {
+	pushLoaderObject(frame, frame.Method().Class().Loader().SystemLoader())
+	return nil
+}
+
+// Java signature: public static ClassLoader getPlatformClassLoader();
+func classLoaderGetPlatformClassLoader(frame *runtime.Frame) (ex *heap.Object) {
+	pushLoaderObject(frame, frame.Method().Class().Loader().PlatformLoader())
+	return nil
+}
+
+// ============================================================
+// loadClass - BuiltinClassLoader.loadClass(String, boolean)
+// ============================================================
+// Java signature: protected native Class<?> loadClass(String name, boolean resolve);
+// parent-first delegation of built-in loaders is done by VM
+func builtinClassLoaderLoadClass(frame *runtime.Frame) (ex *heap.Object) {
+	this := frame.LocalVars().GetThis().(*heap.Object)
+	nameObj, _ := frame.LocalVars().GetRef(1).(*heap.Object)
+	if nameObj == nil {
+		return exception.NewNullPointerException(frame)
+	}
+
+	javaName := heap.GoString(nameObj)
+	loader := frame.Method().Class().Loader().LoaderOf(this)
+	class := loader.LoadClassOrNil(toInternalName(javaName))
+	if class == nil {
+		return exception.NewClassNotFoundException(frame, javaName)
+	}
+	frame.OperandStack().PushRef(class.JClass())
+	return nil
+}
+
+// ============================================================
+// Tools
+// ============================================================
+
+// toInternalName binary name → internal name: java.lang.String → java/lang/String
+func toInternalName(javaName string) string {
+	return strings.ReplaceAll(javaName, ".", "/")
+}
+
+// pushClassMirror push java.lang.Class of class, null if class is nil
+func pushClassMirror(frame *runtime.Frame, class *method_area.Class) {
+	if class == nil {
+		frame.OperandStack().PushRef(nil)
+		return
+	}
+	frame.OperandStack().PushRef(class.JClass())
+}
+
+// pushLoaderObject push java.lang.ClassLoader object of loader, null for bootstrap
+func pushLoaderObject(frame *runtime.Frame, loader *method_area.ClassLoader) {
+	if jLoader := loader.JLoader(); jLoader != nil {
+		frame.OperandStack().PushRef(jLoader)
+		return
+	}
+	frame.OperandStack().PushRef(nil)
+}
@@ -0,0 +1,204 @@
+package invoke
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+)
+
+// ============================================================
+// bytecodeWriter - emit bytecode for synthetic class methods
+// ============================================================
+// only covers what bootstrap methods need: load / store / field / invoke / return / type conversion.
+// constant pool entries are added by SyntheticClassBuilder.
+
+type bytecodeWriter struct {
+	builder *method_area.SyntheticClassBuilder
+	code    []byte
+}
+
+func newBytecodeWriter(builder *method_area.SyntheticClassBuilder) *bytecodeWriter {
+	return &bytecodeWriter{builder: builder}
+}
+
+func (w *bytecodeWriter) Code() []byte { return w.code }
+
+func (w *bytecodeWriter) emit(bytes ...uint8) {
+	w.code = append(w.code, bytes...)
+}
+
+// emitU2 opcode + u2 operand (ex: new #idx, getfield #idx, invokevirtual #idx)
+func (w *bytecodeWriter) emitU2(opcode uint8, index uint16) {
+	w.emit(opcode, uint8(index>>8), uint8(index))
+}
+
+// load xload slot by type descriptor
+func (w *bytecodeWriter) load(descriptor string, slot uint) {
+	if slot > 0xFF {
+		panic(fmt.Sprintf("bytecodeWriter: local slot %d too large", slot))
+	}
+	var opcode uint8
+	switch descriptor[0] {
+	case 'Z', 'B', 'C', 'S', 'I':
+		opcode = opcodes.ILOAD
+	case 'J':
+		opcode = opcodes.LLOAD
+	case 'F':
+		opcode = opcodes.FLOAD
+	case 'D':
+		opcode = opcodes.DLOAD
+	default:
+		opcode = opcodes.ALOAD
+	}
+	w.emit(opcode, uint8(slot))
+}
+
+// xreturn by type descriptor
+func (w *bytecodeWriter) ret(descriptor string) {
+	switch descriptor[0] {
+	case 'V':
+		w.emit(opcodes.RETURN)
+	case 'Z', 'B', 'C', 'S', 'I':
+		w.emit(opcodes.IRETURN)
+	case 'J':
+		w.emit(opcodes.LRETURN)
+	case 'F':
+		w.emit(opcodes.FRETURN)
+	case 'D':
+		w.emit(opcodes.DRETURN)
+	default:
+		w.emit(opcodes.ARETURN)
+	}
+}
+
+// pop drop value on stack top
+func (w *bytecodeWriter) pop(descriptor string) {
+	switch descriptor[0] {
+	case 'V':
+	case 'J', 'D':
+		w.emit(opcodes.POP2)
+	default:
+		w.emit(opcodes.POP)
+	}
+}
+
+func (w *bytecodeWriter) checkcast(descriptor string) {
+	w.emitU2(opcodes.CHECKCAST, w.builder.AddClassRef(classNameOf(descriptor)))
+}
+
+// convert value on stack top from type `from` to type `to`
+// - primitive -> primitive: widening (i2l, f2d ...)
+// - primitive -> reference: boxing (Integer.valueOf ...)
+// - reference -> primitive: unboxing (Integer.intValue ...) then widening
+// - reference -> reference: checkcast
+func (w *bytecodeWriter) convert(from, to string) error {
+	if from == to {
+		return nil
+	}
+
+	fromPrimitive, toPrimitive := isPrimitive(from), isPrimitive(to)
+	switch {
+	case fromPrimitive && toPrimitive:
+		return w.widen(from, to)
+
+	case fromPrimitive && !toPrimitive:
+		wrapper := wrapperClasses[from]
+		w.emitU2(opcodes.INVOKESTATIC, w.builder.AddMethodRef(wrapper, "valueOf", "("+from+")L"+wrapper+";", nil))
+		return nil
+
+	case !fromPrimitive && toPrimitive:
+		// Integer -> long: unbox by source wrapper, then widen
+		primitive := to
+		if p, ok := primitiveOfWrapper[classNameOf(from)]; ok {
+			primitive = p
+		}
+		wrapper := wrapperClasses[primitive]
+		if classNameOf(from) != wrapper {
+			w.checkcast("L" + wrapper + ";")
+		}
+		w.emitU2(opcodes.INVOKEVIRTUAL, w.builder.AddMethodRef(wrapper, unboxMethodNames[primitive], "()"+primitive, nil))
+		return w.widen(primitive, to)
+
+	default:
+		if to != "Ljava/lang/Object;" {
+			w.checkcast(to)
+		}
+		return nil
+	}
+}
+
+// widen primitive widening conversion (JLS 5.1.2)
+func (w *bytecodeWriter) widen(from, to string) error {
+	from, to = stackType(from), stackType(to)
+	if from == to {
+		return nil
+	}
+	switch from + to {
+	case "IJ":
+		w.emit(opcodes.I2L)
+	case "IF":
+		w.emit(opcodes.I2F)
+	case "ID":
+		w.emit(opcodes.I2D)
+	case "JF":
+		w.emit(opcodes.L2F)
+	case "JD":
+		w.emit(opcodes.L2D)
+	case "FD":
+		w.emit(opcodes.F2D)
+	default:
+		return fmt.Errorf("type mismatch: can not convert %s to %s", from, to)
+	}
+	return nil
+}
+
+// ============================================================
+// descriptor helpers
+// ============================================================
+
+var wrapperClasses = map[string]string{
+	"Z": "java/lang/Boolean", "B": "java/lang/Byte", "C": "java/lang/Character", "S": "java/lang/Short",
+	"I": "java/lang/Integer", "J": "java/lang/Long", "F": "java/lang/Float", "D": "java/lang/Double",
+}
+
+var primitiveOfWrapper = map[string]string{
+	"java/lang/Boolean": "Z", "java/lang/Byte": "B", "java/lang/Character": "C", "java/lang/Short": "S",
+	"java/lang/Integer": "I", "java/lang/Long": "J", "java/lang/Float": "F", "java/lang/Double": "D",
+}
+
+var unboxMethodNames = map[string]string{
+	"Z": "booleanValue", "B": "byteValue", "C": "charValue", "S": "shortValue",
+	"I": "intValue", "J": "longValue", "F": "floatValue", "D": "doubleValue",
+}
+
+func isPrimitive(descriptor string) bool {
+	return descriptor[0] != 'L' && descriptor[0] != '['
+}
+
+// stackType boolean, byte, char, short are int on operand stack
+func stackType(descriptor string) string {
+	switch descriptor[0] {
+	case 'Z', 'B', 'C', 'S':
+		return "I"
+	}
+	return descriptor
+}
+
+// slotSize long & double take 2 slots
+func slotSize(descriptor string) uint {
+	switch descriptor[0] {
+	case 'V':
+		return 0
+	case 'J', 'D':
+		return 2
+	}
+	return 1
+}
+
+// classNameOf Ljava/lang/String; -> java/lang/String, array descriptor keep as it is
+func classNameOf(descriptor string) string {
+	if descriptor[0] == 'L' {
+		return descriptor[1 : len(descriptor)-1]
+	}
+	return descriptor
+}
@@ -0,0 +1,302 @@
+package invoke
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/classfile"
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+	"sync/atomic"
+)
+
+// ============================================================
+// java.lang.invoke.LambdaMetafactory (bootstrap method)
+// ============================================================
+// lambda / method reference:
+//
+//	Function<String, Integer> f = s -> s.length() + base;
+//
+// javac:
+//
+//	invokedynamic apply:(I)Ljava/util/function/Function;   // captured: base
+//	BSM LambdaMetafactory.metafactory
+//	  samMethodType:          (Ljava/lang/Object;)Ljava/lang/Object;
+//	  implMethod:             REF_invokeStatic Main.lambda$main$0:(ILjava/lang/String;)Ljava/lang/Integer;
+//	  instantiatedMethodType: (Ljava/lang/String;)Ljava/lang/Integer;
+//
+// we spin a class (like HotSpot InnerClassLambdaMetafactory):
+//
+//	final class Main$$Lambda$1 implements Function {
+//	    private final int arg$1;
+//	    static Function get$Lambda(int a) { new; dup; iload_0; putfield arg$1; areturn }
+//	    public Object apply(Object s) {
+//	        aload_0; getfield arg$1; aload_1; checkcast String;
+//	        invokestatic Main.lambda$main$0; areturn
+//	    }
+//	}
+//
+// CallSite target = get$Lambda, each invokedynamic execution create a new lambda object.
+
+const (
+	// altMetafactory flags
+	FLAG_SERIALIZABLE = 1 << 0
+	FLAG_MARKERS      = 1 << 1
+	FLAG_BRIDGES      = 1 << 2
+)
+
+// lambdaCounter for lambda class name: Main$$Lambda$1, Main$$Lambda$2 ...
+var lambdaCounter int32
+
+func init() {
+	method_area.RegisterBootstrap("java/lang/invoke/LambdaMetafactory", "metafactory", metafactory)
+	method_area.RegisterBootstrap("java/lang/invoke/LambdaMetafactory", "altMetafactory", altMetafactory)
+}
+
+// metafactory(Lookup, String, MethodType, MethodType samMethodType, MethodHandle implMethod, MethodType instantiatedMethodType)
+func metafactory(ctx *method_area.BootstrapContext) (*method_area.CallSite, *heap.Object) {
+	if len(ctx.StaticArgs) < 3 {
+		return nil, newBootstrapMethodError(ctx, "LambdaMetafactory requires 3 static arguments")
+	}
+	return spinLambdaClass(ctx, nil, nil)
+}
+
+// altMetafactory(Lookup, String, MethodType, Object... args)
+// args: samMethodType, implMethod, instantiatedMethodType, flags,
+// [markerCount, markers...], [bridgeCount, bridges...]
+func altMetafactory(ctx *method_area.BootstrapContext) (*method_area.CallSite, *heap.Object) {
+	args := ctx.StaticArgs
+	if len(args) < 4 {
+		return nil, newBootstrapMethodError(ctx, "LambdaMetafactory.altMetafactory requires 4 static arguments")
+	}
+	flags, _ := args[3].(int32)
+	i := 4
+
+	// serializable lambda is not supported (no writeReplace / SerializedLambda), treat as normal lambda
+	var markers []string
+	if flags&FLAG_MARKERS != 0 && i < len(args) {
+		count, _ := args[i].(int32)
+		i++
+		for ; count > 0 && i < len(args); count-- {
+			if classRef, ok := args[i].(*method_area.ClassRef); ok {
+				markers = append(markers, classRef.ClassName())
+			}
+			i++
+		}
+	}
+
+	var bridges []string
+	if flags&FLAG_BRIDGES != 0 && i < len(args) {
+		count, _ := args[i].(int32)
+		i++
+		for ; count > 0 && i < len(args); count-- {
+			if methodType, ok := args[i].(*method_area.MethodTypeRef); ok {
+				bridges = append(bridges, methodType.Descriptor())
+			}
+			i++
+		}
+	}
+
+	return spinLambdaClass(ctx, markers, bridges)
+}
+
+// spinLambdaClass define lambda proxy class and return CallSite pointing to its factory method
+func spinLambdaClass(ctx *method_area.BootstrapContext, markers, bridges []string) (*method_area.CallSite, *heap.Object) {
+	samType, ok1 := ctx.StaticArgs[0].(*method_area.MethodTypeRef)
+	implHandle, ok2 := ctx.StaticArgs[1].(*method_area.MethodHandleRef)
+	instantiatedType, ok3 := ctx.StaticArgs[2].(*method_area.MethodTypeRef)
+	if !ok1 || !ok2 || !ok3 {
+		return nil, newBootstrapMethodError(ctx, "bad LambdaMetafactory static arguments")
+	}
+
+	implMethod, err := implHandle.ResolvedMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	indyType := method_area.ParseMethodDescriptor(ctx.Descriptor)
+	ifaceName := classNameOf(indyType.GetReturnType())
+	capturedTypes := indyType.GetParameterTypes()
+
+	className := fmt.Sprintf("%s$$Lambda$%d", ctx.Caller.Name(), atomic.AddInt32(&lambdaCounter, 1))
+	builder := method_area.NewSyntheticClassBuilder(ctx.Caller.Loader(), className, "java/lang/Object",
+		append([]string{ifaceName}, markers...))
+
+	// 1. captured args -> final fields
+	capturedFieldRefs := make([]uint16, len(capturedTypes))
+	for i, t := range capturedTypes {
+		field := builder.AddField(common.ACC_PRIVATE|common.ACC_FINAL, fmt.Sprintf("arg$%d", i+1), t)
+		capturedFieldRefs[i] = builder.AddFieldRef(field)
+	}
+
+	// 2. static factory: create lambda object with captured args
+	factory := genFactoryMethod(builder, ctx.Descriptor, capturedTypes, capturedFieldRefs)
+
+	// 3. SAM method (+ bridges), all of them call implMethod
+	lambda := &lambdaInfo{
+		builder:           builder,
+		implHandle:        implHandle,
+		implMethod:        implMethod,
+		capturedTypes:     capturedTypes,
+		capturedFieldRefs: capturedFieldRefs,
+		instantiatedType:  instantiatedType,
+	}
+	descriptors := append([]string{samType.Descriptor()}, bridges...)
+	for _, descriptor := range descriptors {
+		if hasMethod(builder.Class(), ctx.Name, descriptor) {
+			continue
+		}
+		if convErr := lambda.genSamMethod(ctx.Name, descriptor); convErr != nil {
+			return nil, newBootstrapMethodError(ctx, fmt.Sprintf("%s: %v", implHandle, convErr))
+		}
+	}
+
+	builder.Define()
+	return method_area.NewCallSite(factory), nil
+}
+
+// genFactoryMethod
+//
+//	static Iface get$Lambda(captured...) {
+//	    new; (dup; xload i; putfield arg$i)*; areturn
+//	}
+func genFactoryMethod(builder *method_area.SyntheticClassBuilder, descriptor string, capturedTypes []string, capturedFieldRefs []uint16) *method_area.Method {
+	w := newBytecodeWriter(builder)
+	w.emitU2(opcodes.NEW, builder.AddClassRef(builder.Class().Name()))
+	slot := uint(0)
+	for i, t := range capturedTypes {
+		w.emit(opcodes.DUP)
+		w.load(t, slot)
+		w.emitU2(opcodes.PUTFIELD, capturedFieldRefs[i])
+		slot += slotSize(t)
+	}
+	w.emit(opcodes.ARETURN)
+
+	return builder.AddMethod(common.ACC_PUBLIC|common.ACC_STATIC|common.ACC_SYNTHETIC, "get$Lambda", descriptor,
+		4, uint16(slot), w.Code())
+}
+
+// lambdaInfo everything genSamMethod need
+type lambdaInfo struct {
+	builder           *method_area.SyntheticClassBuilder
+	implHandle        *method_area.MethodHandleRef
+	implMethod        *method_area.Method
+	capturedTypes     []string
+	capturedFieldRefs []uint16
+	instantiatedType  *method_area.MethodTypeRef
+}
+
+// genSamMethod
+//
+//	public R sam(params...) {
+//	    [new impl; dup]                      // REF_newInvokeSpecial
+//	    (aload_0; getfield arg$i)*           // captured args (may include receiver)
+//	    (xload param; convert)*              // SAM params
+//	    invokeXXX implMethod
+//	    convert return; xreturn
+//	}
+func (l *lambdaInfo) genSamMethod(name, descriptor string) error {
+	builder := l.builder
+	w := newBytecodeWriter(builder)
+	kind := l.implHandle.ReferenceKind()
+	implType := method_area.ParseMethodDescriptor(l.implMethod.Descriptor())
+
+	// impl "params": receiver (non-static) + declared params
+	implParamTypes := implType.GetParameterTypes()
+	implReturnType := implType.GetReturnType()
+	switch kind {
+	case classfile.REF_newInvokeSpecial:
+		implReturnType = "L" + l.implMethod.Class().Name() + ";"
+		w.emitU2(opcodes.NEW, builder.AddClassRef(l.implMethod.Class().Name()))
+		w.emit(opcodes.DUP)
+	case classfile.REF_invokeVirtual, classfile.REF_invokeSpecial, classfile.REF_invokeInterface:
+		implParamTypes = append([]string{"L" + l.implHandle.ClassName() + ";"}, implParamTypes...)
+	}
+
+	samType := method_area.ParseMethodDescriptor(descriptor)
+	samParamTypes := samType.GetParameterTypes()
+	if len(l.capturedTypes)+len(samParamTypes) != len(implParamTypes) {
+		return fmt.Errorf("parameter count mismatch: captured %d + sam %d != impl %d",
+			len(l.capturedTypes), len(samParamTypes), len(implParamTypes))
+	}
+
+	// instantiated types narrow erased SAM types (Object -> String), checkcast to them first
+	instantiatedParamTypes := l.instantiatedType.ParameterTypes()
+
+	maxStack := uint16(2)
+	// 1. captured args
+	for i, t := range l.capturedTypes {
+		w.emit(opcodes.ALOAD_0)
+		w.emitU2(opcodes.GETFIELD, l.capturedFieldRefs[i])
+		if err := w.convert(t, implParamTypes[i]); err != nil {
+			return err
+		}
+		maxStack += 2
+	}
+
+	// 2. SAM params
+	slot := uint(1)
+	for j, t := range samParamTypes {
+		w.load(t, slot)
+		slot += slotSize(t)
+		source := t
+		if j < len(instantiatedParamTypes) && !isPrimitive(t) && !isPrimitive(instantiatedParamTypes[j]) &&
+			instantiatedParamTypes[j] != t {
+			w.checkcast(instantiatedParamTypes[j])
+			source = instantiatedParamTypes[j]
+		}
+		if err := w.convert(source, implParamTypes[len(l.capturedTypes)+j]); err != nil {
+			return err
+		}
+		maxStack += 2
+	}
+
+	// 3. invoke impl
+	implClassName := l.implHandle.ClassName()
+	implName, implDescriptor := l.implMethod.Name(), l.implMethod.Descriptor()
+	switch kind {
+	case classfile.REF_invokeVirtual:
+		w.emitU2(opcodes.INVOKEVIRTUAL, builder.AddMethodRef(implClassName, implName, implDescriptor, l.implMethod))
+	case classfile.REF_invokeStatic:
+		w.emitU2(opcodes.INVOKESTATIC, builder.AddMethodRef(implClassName, implName, implDescriptor, l.implMethod))
+	case classfile.REF_invokeSpecial, classfile.REF_newInvokeSpecial:
+		w.emitU2(opcodes.INVOKESPECIAL, builder.AddMethodRef(implClassName, implName, implDescriptor, l.implMethod))
+	case classfile.REF_invokeInterface:
+		w.emitU2(opcodes.INVOKEINTERFACE, builder.AddInterfaceMethodRef(implClassName, implName, implDescriptor, l.implMethod))
+		w.emit(uint8(l.implMethod.ArgSlotCount()), 0)
+	default:
+		return fmt.Errorf("unsupported method handle kind %d", kind)
+	}
+
+	// 4. return
+	samReturnType := samType.GetReturnType()
+	if samReturnType == "V" {
+		w.pop(implReturnType)
+	} else {
+		if implReturnType == "V" {
+			return fmt.Errorf("impl method returns void, but %s%s needs %s", name, descriptor, samReturnType)
+		}
+		if err := w.convert(implReturnType, samReturnType); err != nil {
+			return err
+		}
+	}
+	w.ret(samReturnType)
+
+	builder.AddMethod(common.ACC_PUBLIC, name, descriptor, maxStack, uint16(slot), w.Code())
+	return nil
+}
+
+func hasMethod(class *method_area.Class, name, descriptor string) bool {
+	for _, m := range class.Methods() {
+		if m.Name() == name && m.Descriptor() == descriptor {
+			return true
+		}
+	}
+	return false
+}
+
+// newBootstrapMethodError create java.lang.BootstrapMethodError
+func newBootstrapMethodError(ctx *method_area.BootstrapContext, message string) *heap.Object {
+	errClass := ctx.Caller.Loader().LoadClass("java/lang/BootstrapMethodError", false)
+	return heap.NewExceptionObject(errClass, message)
+}
@@ -0,0 +1,321 @@
+package invoke
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+	"github.com/Johnny1110/gogo_jvm/runtime/rtcore"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// ============================================================
+// java.lang.invoke.StringConcatFactory (bootstrap method, JDK 9+ javac)
+// ============================================================
+// Java: "a=" + a + ", b=" + b
+// javac:
+//
+//	invokedynamic makeConcatWithConstants:(ILjava/lang/String;)Ljava/lang/String;
+//	BSM StringConcatFactory.makeConcatWithConstants
+//	  recipe: "a=\u0001, b=\u0001"
+//
+// recipe tags:
+//   - \u0001: next dynamic argument (from operand stack)
+//   - \u0002: next bootstrap constant (static arg after recipe)
+//   - others: literal text
+//
+// no StringBuilder / MethodHandle in Java side, so call site target is a native method
+// `Caller$$StringConcat$N.concat` (descriptor same as invokedynamic), implemented by the recipe below.
+//
+// object argument is converted by String.valueOf(obj):
+//   - boxed primitive (Integer, Character ...): formatted from its value field, same as Integer.toString(int) ...
+//   - overridden toString(): called by VM upcall (method_area.CallJava), exception thrown by it is thrown by concat
+//   - Object.toString(): default format className@hash, no upcall needed
+
+const (
+	TAG_ARG   = '\u0001'
+	TAG_CONST = '\u0002'
+)
+
+// stringConcatCounter for concat class name: Main$$StringConcat$1, Main$$StringConcat$2 ...
+var stringConcatCounter int32
+
+func init() {
+	method_area.RegisterBootstrap("java/lang/invoke/StringConcatFactory", "makeConcatWithConstants", makeConcatWithConstants)
+	method_area.RegisterBootstrap("java/lang/invoke/StringConcatFactory", "makeConcat", makeConcat)
+}
+
+// concatElement one piece of recipe
+// argIndex >= 0: dynamic argument, otherwise literal text
+type concatElement struct {
+	text     string
+	argIndex int
+}
+
+// makeConcatWithConstants(Lookup, String name, MethodType concatType, String recipe, Object... constants)
+func makeConcatWithConstants(ctx *method_area.BootstrapContext) (*method_area.CallSite, *heap.Object) {
+	if len(ctx.StaticArgs) < 1 {
+		return nil, newBootstrapMethodError(ctx, "StringConcatFactory requires a recipe")
+	}
+	recipe, ok := ctx.StaticArgs[0].(string)
+	if !ok {
+		return nil, newBootstrapMethodError(ctx, "StringConcatFactory recipe is not a String")
+	}
+
+	concatType := method_area.ParseMethodDescriptor(ctx.Descriptor)
+	argTypes := concatType.GetParameterTypes()
+	elements, err := parseRecipe(recipe, ctx.StaticArgs[1:], len(argTypes))
+	if err != nil {
+		return nil, newBootstrapMethodError(ctx, "StringConcatException: "+err.Error())
+	}
+	return spinStringConcat(ctx, elements, argTypes)
+}
+
+// makeConcat(Lookup, String name, MethodType concatType)
+// no recipe: concat all dynamic arguments
+func makeConcat(ctx *method_area.BootstrapContext) (*method_area.CallSite, *heap.Object) {
+	concatType := method_area.ParseMethodDescriptor(ctx.Descriptor)
+	argTypes := concatType.GetParameterTypes()
+	elements := make([]concatElement, len(argTypes))
+	for i := range argTypes {
+		elements[i] = concatElement{argIndex: i}
+	}
+	return spinStringConcat(ctx, elements, argTypes)
+}
+
+// parseRecipe split recipe into elements, \u0002 constants are merged into literal text
+func parseRecipe(recipe string, constants []method_area.Constant, argCount int) ([]concatElement, error) {
+	var elements []concatElement
+	var literal strings.Builder
+	argIndex, constIndex := 0, 0
+
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			elements = append(elements, concatElement{text: literal.String(), argIndex: -1})
+			literal.Reset()
+		}
+	}
+
+	for _, ch := range recipe {
+		switch ch {
+		case TAG_ARG:
+			if argIndex >= argCount {
+				return nil, fmt.Errorf("mismatched number of concat arguments: recipe wants more than %d", argCount)
+			}
+			flushLiteral()
+			elements = append(elements, concatElement{argIndex: argIndex})
+			argIndex++
+		case TAG_CONST:
+			if constIndex >= len(constants) {
+				return nil, fmt.Errorf("mismatched number of concat constants: recipe wants more than %d", len(constants))
+			}
+			s, err := constantToString(constants[constIndex])
+			if err != nil {
+				return nil, err
+			}
+			literal.WriteString(s)
+			constIndex++
+		default:
+			literal.WriteRune(ch)
+		}
+	}
+	flushLiteral()
+
+	if argIndex != argCount {
+		return nil, fmt.Errorf("mismatched number of concat arguments: recipe wants %d, but signature provides %d", argIndex, argCount)
+	}
+	if constIndex != len(constants) {
+		return nil, fmt.Errorf("mismatched number of concat constants: recipe wants %d, but only %d are passed", constIndex, len(constants))
+	}
+	return elements, nil
+}
+
+// constantToString bootstrap constant (runtime constant pool value) -> string
+func constantToString(c method_area.Constant) (string, error) {
+	switch v := c.(type) {
+	case string:
+		return v, nil
+	case int32:
+		return strconv.Itoa(int(v)), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float32:
+		return javaFloatString(float64(v), 32), nil
+	case float64:
+		return javaFloatString(v, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported concat constant: %v", c)
+	}
+}
+
+// spinStringConcat define class with native `concat` method, return CallSite pointing to it
+func spinStringConcat(ctx *method_area.BootstrapContext, elements []concatElement, argTypes []string) (*method_area.CallSite, *heap.Object) {
+	// slot of each dynamic argument in LocalVars (long & double take 2 slots)
+	argSlots := make([]uint, len(argTypes))
+	slot := uint(0)
+	for i, t := range argTypes {
+		argSlots[i] = slot
+		slot += slotSize(t)
+	}
+
+	className := fmt.Sprintf("%s$$StringConcat$%d", ctx.Caller.Name(), atomic.AddInt32(&stringConcatCounter, 1))
+	builder := method_area.NewSyntheticClassBuilder(ctx.Caller.Loader(), className, "java/lang/Object", nil)
+	target := builder.AddMethod(common.ACC_PUBLIC|common.ACC_STATIC|common.ACC_NATIVE|common.ACC_SYNTHETIC,
+		"concat", ctx.Descriptor, 0, uint16(slot), nil)
+
+	loader := ctx.Caller.Loader()
+	runtime.Register(className, "concat", ctx.Descriptor, func(frame *runtime.Frame) (ex *heap.Object) {
+		localVars := frame.LocalVars()
+		var sb strings.Builder
+		for _, e := range elements {
+			if e.argIndex < 0 {
+				sb.WriteString(e.text)
+				continue
+			}
+			s, ex := concatArgString(localVars, argSlots[e.argIndex], argTypes[e.argIndex])
+			if ex != nil {
+				return ex
+			}
+			sb.WriteString(s)
+		}
+		// result is a new String (not interned), same as javac `new StringBuilder()...toString()`
+		strClass := loader.LoadClass("java/lang/String", false)
+		frame.OperandStack().PushRef(heap.NewJString(sb.String(), strClass))
+		return nil
+	})
+
+	builder.Define()
+	return method_area.NewCallSite(target), nil
+}
+
+// concatArgString String.valueOf(arg), exception thrown by toString() is returned
+func concatArgString(localVars rtcore.Slots, slot uint, descriptor string) (string, *heap.Object) {
+	switch descriptor[0] {
+	case 'Z', 'C', 'B', 'S', 'I':
+		return primitiveString(descriptor[0], int64(localVars.GetInt(slot))), nil
+	case 'J':
+		return strconv.FormatInt(localVars.GetLong(slot), 10), nil
+	case 'F':
+		return javaFloatString(float64(localVars.GetFloat(slot)), 32), nil
+	case 'D':
+		return javaFloatString(localVars.GetDouble(slot), 64), nil
+	default:
+		ref := localVars.GetRef(slot)
+		if ref == nil {
+			return "null", nil
+		}
+		return objectString(ref.(*heap.Object))
+	}
+}
+
+// primitiveString Boolean / Character / Byte / Short / Integer toString of int value
+func primitiveString(descriptor byte, value int64) string {
+	switch descriptor {
+	case 'Z':
+		if value != 0 {
+			return "true"
+		}
+		return "false"
+	case 'C':
+		return string(rune(uint16(value)))
+	default:
+		return strconv.FormatInt(value, 10)
+	}
+}
+
+// boxedValueTypes box class → descriptor of its `value` field
+var boxedValueTypes = map[string]string{
+	"java/lang/Boolean":   "Z",
+	"java/lang/Character": "C",
+	"java/lang/Byte":      "B",
+	"java/lang/Short":     "S",
+	"java/lang/Integer":   "I",
+	"java/lang/Long":      "J",
+	"java/lang/Float":     "F",
+	"java/lang/Double":    "D",
+}
+
+// objectString String.valueOf(obj) of non-null object
+func objectString(obj *heap.Object) (string, *heap.Object) {
+	if heap.IsJString(obj) {
+		return heap.GoString(obj), nil
+	}
+	class, ok := obj.Class().(*method_area.Class)
+	if !ok || class == nil {
+		return "java.lang.Object@" + strconv.FormatUint(uint64(uint32(obj.HashCode())), 16), nil
+	}
+
+	// boxed primitive
+	if descriptor, ok := boxedValueTypes[class.Name()]; ok {
+		if field := class.GetField("value", descriptor, false); field != nil {
+			slotId := field.SlotId()
+			switch descriptor {
+			case "J":
+				return strconv.FormatInt(obj.GetLongField(slotId), 10), nil
+			case "F":
+				return javaFloatString(float64(obj.GetFloatField(slotId)), 32), nil
+			case "D":
+				return javaFloatString(obj.GetDoubleField(slotId), 64), nil
+			default:
+				return primitiveString(descriptor[0], int64(obj.GetIntField(slotId))), nil
+			}
+		}
+	}
+
+	// overridden toString()
+	toString := class.GetMethod("toString", "()Ljava/lang/String;")
+	if toString != nil && toString.Class().Name() != "java/lang/Object" && !toString.IsAbstract() {
+		ret, ex := method_area.CallJava(toString, obj)
+		if ex != nil {
+			return "", ex
+		}
+		if str, ok := ret.(*heap.Object); ok && str != nil {
+			return heap.GoString(str), nil
+		}
+		return "null", nil
+	}
+
+	// Object.toString(): getClass().getName() + "@" + Integer.toHexString(hashCode())
+	return class.JavaName() + "@" + strconv.FormatUint(uint64(uint32(obj.HashCode())), 16), nil
+}
+
+// javaFloatString Float.toString / Double.toString format
+//   - 1e-3 <= |v| < 1e7: plain decimal, at least one digit after point (1.0, 0.001, 1234567.5)
+//   - otherwise: computerized scientific notation (1.0E7, 1.5E-4)
+func javaFloatString(v float64, bitSize int) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "Infinity"
+	case math.IsInf(v, -1):
+		return "-Infinity"
+	case v == 0:
+		if math.Signbit(v) {
+			return "-0.0"
+		}
+		return "0.0"
+	}
+
+	abs := math.Abs(v)
+	if abs >= 1e-3 && abs < 1e7 {
+		s := strconv.FormatFloat(v, 'f', -1, bitSize)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s
+	}
+
+	// Go: 1.5e-04 -> Java: 1.5E-4
+	s := strconv.FormatFloat(v, 'e', -1, bitSize)
+	mantissa, exp, _ := strings.Cut(s, "e")
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	expValue, _ := strconv.Atoi(exp)
+	return mantissa + "E" + strconv.Itoa(expValue)
+}
@@ -2,6 +2,7 @@ package ref
 
 import (
 	"fmt"
+	"github.com/Johnny1110/gogo_jvm/exception"
 	"github.com/Johnny1110/gogo_jvm/runtime"
 	"github.com/Johnny1110/gogo_jvm/runtime/heap"
 )
@@ -233,9 +234,7 @@ func referenceRefersTo(frame *runtime.Frame) (ex *heap.Object) {
 //	[this] → throws CloneNotSupportedException
 func referenceClone(frame *runtime.Frame) (ex *heap.Object) {
 	// Reference objects cannot be cloned
-	// In real JVM, this throws CloneNotSupportedException
-	// For MVP, we panic with a descriptive message
-	panic("java.lang.CloneNotSupportedException: Reference objects cannot be cloned")
+	return exception.NewJavaExceptionObject(frame, "java/lang/CloneNotSupportedException", "Reference objects cannot be cloned")
 }
 
 // ------------------------------------------------------------
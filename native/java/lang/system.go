@@ -1,7 +1,7 @@
 package lang
 
 import (
-	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/Johnny1110/gogo_jvm/exception"
 	"github.com/Johnny1110/gogo_jvm/runtime"
 	"github.com/Johnny1110/gogo_jvm/runtime/heap"
 )
@@ -11,14 +11,53 @@ import (
 func init() {
 	runtime.Register("java/lang/System", "arraycopy", "(Ljava/lang/Object;ILjava/lang/Object;II)V", systemArraycopy)
 	runtime.Register("java/lang/System", "currentTimeMillis", "()J", systemCurrentTimeMillis)
+	runtime.Register("java/lang/System", "getProperty", "(Ljava/lang/String;)Ljava/lang/String;", systemGetProperty)
+	runtime.Register("java/lang/System", "getProperty", "(Ljava/lang/String;Ljava/lang/String;)Ljava/lang/String;", systemGetPropertyWithDefault)
+	runtime.Register("java/lang/System", "gc", "()V", systemGC)
 }
 
 func systemArraycopy(frame *runtime.Frame) (ex *heap.Object) {
 	// TODO
-	panic(common.NewJavaException("System", "systemArraycopy not implemented"))
+	return exception.NewInternalError(frame, "System.arraycopy not implemented")
 }
 
 func systemCurrentTimeMillis(frame *runtime.Frame) (ex *heap.Object) {
 	// TODO
-	panic(common.NewJavaException("System", "systemCurrentTimeMillis not implemented"))
+	return exception.NewInternalError(frame, "System.currentTimeMillis not implemented")
+}
+
+// Java signature: public static void gc();
+// class unloading (method_area/class_unloading.go) is reported after GC by finalizer goroutine
+func systemGC(frame *runtime.Frame) (ex *heap.Object) {
+	heap.SystemGC()
+	return nil
+}
+
+// ============================================================
+// System.getProperty - -D<key>=<value> & default properties
+// ============================================================
+
+// Java signature: public static String getProperty(String key);
+func systemGetProperty(frame *runtime.Frame) (ex *heap.Object) {
+	return pushProperty(frame, nil)
+}
+
+// Java signature: public static String getProperty(String key, String def);
+func systemGetPropertyWithDefault(frame *runtime.Frame) (ex *heap.Object) {
+	def, _ := frame.LocalVars().GetRef(1).(*heap.Object)
+	return pushProperty(frame, def)
+}
+
+func pushProperty(frame *runtime.Frame, def *heap.Object) (ex *heap.Object) {
+	key, _ := frame.LocalVars().GetRef(0).(*heap.Object)
+	if key == nil {
+		return exception.NewNullPointerException(frame)
+	}
+	value, ok := runtime.GetSystemProperty(heap.GoString(key))
+	if !ok {
+		frame.OperandStack().PushRef(def)
+		return nil
+	}
+	frame.OperandStack().PushRef(heap.InternString(value, frame.Method().Class().Loader()))
+	return nil
 }
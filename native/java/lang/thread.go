@@ -2,7 +2,7 @@ package lang
 
 import (
 	"fmt"
-	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/Johnny1110/gogo_jvm/exception"
 	"github.com/Johnny1110/gogo_jvm/runtime"
 	"github.com/Johnny1110/gogo_jvm/runtime/heap"
 )
@@ -17,10 +17,10 @@ func init() {
 
 func threadCurrentThread(frame *runtime.Frame) (ex *heap.Object) {
 	// TODO
-	panic(common.NewJavaException("Thread", "threadCurrentThread not implemented"))
+	return exception.NewInternalError(frame, "Thread.currentThread not implemented")
 }
 
 func threadSleep(frame *runtime.Frame) (ex *heap.Object) {
 	// TODO
-	panic(common.NewJavaException("Thread", "threadSleep not implemented"))
+	return exception.NewInternalError(frame, "Thread.sleep not implemented")
 }
@@ -0,0 +1,130 @@
+package lang
+
+import (
+	"github.com/Johnny1110/gogo_jvm/exception"
+	"github.com/Johnny1110/gogo_jvm/runtime"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
+	"os"
+)
+
+// ============================================================
+// java.lang.Throwable / java.lang.StackTraceElement Native Methods
+// ============================================================
+// backtrace is kept in exception object's ExceptionData.StackTrace (see exception/stack_trace.go),
+// StackTraceElement objects are created on demand.
+func init() {
+	runtime.Register("java/lang/Throwable", "fillInStackTrace", "(I)Ljava/lang/Throwable;", throwableFillInStackTrace)
+	// JDK 8
+	runtime.Register("java/lang/Throwable", "getStackTraceDepth", "()I", throwableGetStackTraceDepth)
+	runtime.Register("java/lang/Throwable", "getStackTraceElement", "(I)Ljava/lang/StackTraceElement;", throwableGetStackTraceElement)
+	// JDK 9 ~ 18
+	runtime.Register("java/lang/StackTraceElement", "initStackTraceElements", "([Ljava/lang/StackTraceElement;Ljava/lang/Throwable;)V", initStackTraceElements)
+	// JDK 19+: x is Throwable.backtrace (we store the throwable itself)
+	runtime.Register("java/lang/StackTraceElement", "initStackTraceElements", "([Ljava/lang/StackTraceElement;Ljava/lang/Object;I)V", initStackTraceElements)
+
+	// minimal rt (non-native in standard Java): print to System.err
+	runtime.Register("java/lang/Throwable", "printStackTrace", "()V", throwablePrintStackTrace)
+}
+
+// ============================================================
+// fillInStackTrace - Throwable.fillInStackTrace(int dummy)
+// ============================================================
+// Java signature: private native Throwable fillInStackTrace(int dummy);
+// called by Throwable constructors, trace starts at the frame doing `new`
+func throwableFillInStackTrace(frame *runtime.Frame) (ex *heap.Object) {
+	this := frame.LocalVars().GetThis().(*heap.Object)
+	exception.FillInStackTrace(frame.Thread(), this)
+
+	// JDK 9+ Throwable.getOurStackTrace() reads backtrace & depth
+	setRefField(this, "backtrace", "Ljava/lang/Object;", this)
+	if field := getInstanceField(this, "depth", "I"); field != nil {
+		this.SetIntField(field.SlotId(), int32(len(exception.GetStackTrace(this))))
+	}
+
+	frame.OperandStack().PushRef(this)
+	return nil
+}
+
+// Java signature: native int getStackTraceDepth();
+func throwableGetStackTraceDepth(frame *runtime.Frame) (ex *heap.Object) {
+	this := frame.LocalVars().GetThis().(*heap.Object)
+	frame.OperandStack().PushInt(int32(len(exception.GetStackTrace(this))))
+	return nil
+}
+
+// Java signature: native StackTraceElement getStackTraceElement(int index);
+func throwableGetStackTraceElement(frame *runtime.Frame) (ex *heap.Object) {
+	this := frame.LocalVars().GetThis().(*heap.Object)
+	index := frame.LocalVars().GetInt(1)
+
+	trace := exception.GetStackTrace(this)
+	if index < 0 || int(index) >= len(trace) {
+		return exception.NewArrayIndexOutOfBoundsException(frame, index)
+	}
+
+	loader := frame.Method().Class().Loader()
+	elementObj := loader.LoadClass("java/lang/StackTraceElement", false).NewObject()
+	setStackTraceElement(elementObj, trace[index], loader)
+	frame.OperandStack().PushRef(elementObj)
+	return nil
+}
+
+// ============================================================
+// initStackTraceElements - StackTraceElement.initStackTraceElements
+// ============================================================
+// Java signature (JDK 9): static native void initStackTraceElements(StackTraceElement[] elements, Throwable x);
+// elements are allocated by java code, length = Throwable.depth
+func initStackTraceElements(frame *runtime.Frame) (ex *heap.Object) {
+	elements, _ := frame.LocalVars().GetRef(0).(*heap.Object)
+	throwable, _ := frame.LocalVars().GetRef(1).(*heap.Object)
+	if elements == nil || throwable == nil {
+		return exception.NewNullPointerException(frame)
+	}
+
+	loader := frame.Method().Class().Loader()
+	trace := exception.GetStackTrace(throwable)
+	for i, elementObj := range elements.Refs() {
+		if elementObj != nil && i < len(trace) {
+			setStackTraceElement(elementObj, trace[i], loader)
+		}
+	}
+	return nil
+}
+
+// ============================================================
+// printStackTrace - Throwable.printStackTrace()
+// ============================================================
+// Java signature: public void printStackTrace();
+func throwablePrintStackTrace(frame *runtime.Frame) (ex *heap.Object) {
+	this := frame.LocalVars().GetThis().(*heap.Object)
+	exception.PrintStackTrace(os.Stderr, this)
+	return nil
+}
+
+// ============================================================
+// Tools
+// ============================================================
+
+// setStackTraceElement copy element into StackTraceElement object's fields
+func setStackTraceElement(elementObj *heap.Object, element *heap.StackTraceElement, loader *method_area.ClassLoader) {
+	setRefField(elementObj, "declaringClass", "Ljava/lang/String;", heap.InternString(element.ClassName, loader))
+	setRefField(elementObj, "methodName", "Ljava/lang/String;", heap.InternString(element.MethodName, loader))
+	if element.FileName != "" {
+		setRefField(elementObj, "fileName", "Ljava/lang/String;", heap.InternString(element.FileName, loader))
+	}
+	if field := getInstanceField(elementObj, "lineNumber", "I"); field != nil {
+		elementObj.SetIntField(field.SlotId(), int32(element.LineNumber))
+	}
+}
+
+// setRefField set ref field if declared (field set differs between JDK versions)
+func setRefField(obj *heap.Object, name, descriptor string, value *heap.Object) {
+	if field := getInstanceField(obj, name, descriptor); field != nil {
+		obj.SetRefField(field.SlotId(), value)
+	}
+}
+
+func getInstanceField(obj *heap.Object, name, descriptor string) *method_area.Field {
+	return obj.Class().(*method_area.Class).GetField(name, descriptor, false)
+}
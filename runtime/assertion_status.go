@@ -0,0 +1,78 @@
+package runtime
+
+import "strings"
+
+// ============================================================
+// Assertion Status - -ea / -da (Class.desiredAssertionStatus)
+// ============================================================
+//
+//	-ea                  enable assertions in all classes (except system classes)
+//	-ea:com.foo...       enable in package com.foo and its sub packages
+//	-ea:...              enable in unnamed package
+//	-ea:com.foo.Main     enable in class com.foo.Main
+//	-da[:...]            disable, same format
+//	-esa / -dsa          enable / disable in system classes
+//
+// priority: class setting > most specific package setting > default,
+// same target given multiple times: last one wins.
+
+var (
+	defaultAssertionStatus bool
+	systemAssertionStatus  bool
+	classAssertionStatus   = make(map[string]bool) // key: binary name (com.foo.Main)
+	packageAssertionStatus = make(map[string]bool) // key: package name (com.foo), "" is unnamed package
+)
+
+// SetAssertionStatus target: "" all classes, "pkg..." package tree, otherwise class name
+func SetAssertionStatus(target string, enabled bool) {
+	switch {
+	case target == "":
+		defaultAssertionStatus = enabled
+	case strings.HasSuffix(target, "..."):
+		packageAssertionStatus[strings.TrimSuffix(target, "...")] = enabled
+	default:
+		classAssertionStatus[target] = enabled
+	}
+}
+
+// SetSystemAssertionStatus -esa / -dsa
+func SetSystemAssertionStatus(enabled bool) {
+	systemAssertionStatus = enabled
+}
+
+// DesiredAssertionStatus className: internal name (com/foo/Main) or binary name (com.foo.Main)
+func DesiredAssertionStatus(className string) bool {
+	name := strings.ReplaceAll(className, "/", ".")
+	if enabled, ok := classAssertionStatus[name]; ok {
+		return enabled
+	}
+
+	pkg := ""
+	if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+		pkg = name[:dot]
+	}
+	// com.foo.bar → com.foo → com (unnamed package "" only match classes without package)
+	for {
+		if enabled, ok := packageAssertionStatus[pkg]; ok {
+			return enabled
+		}
+		dot := strings.LastIndexByte(pkg, '.')
+		if dot < 0 {
+			break
+		}
+		pkg = pkg[:dot]
+	}
+	if isSystemClassName(name) {
+		return systemAssertionStatus
+	}
+	return defaultAssertionStatus
+}
+
+func isSystemClassName(name string) bool {
+	for _, prefix := range []string{"java.", "javax.", "jdk.", "sun."} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
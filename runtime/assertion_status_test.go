@@ -0,0 +1,27 @@
+package runtime
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDesiredAssertionStatus(t *testing.T) {
+	defer func() {
+		defaultAssertionStatus, systemAssertionStatus = false, false
+		classAssertionStatus, packageAssertionStatus = make(map[string]bool), make(map[string]bool)
+	}()
+
+	// -ea -da:com.foo... -ea:com.foo.bar... -da:com.foo.bar.Baz -ea:...
+	SetAssertionStatus("", true)
+	SetAssertionStatus("com.foo...", false)
+	SetAssertionStatus("com.foo.bar...", true)
+	SetAssertionStatus("com.foo.bar.Baz", false)
+	SetAssertionStatus("...", false)
+
+	assert.True(t, DesiredAssertionStatus("org/example/Main"))
+	assert.False(t, DesiredAssertionStatus("com/foo/Main"))
+	assert.True(t, DesiredAssertionStatus("com/foo/bar/qux/Main"))
+	assert.False(t, DesiredAssertionStatus("com/foo/bar/Baz"))
+	assert.False(t, DesiredAssertionStatus("Main"))
+	assert.False(t, DesiredAssertionStatus("java/lang/String")) // system class: -esa not given
+}
@@ -39,6 +39,10 @@ type Frame struct {
 	method       *method_area.Method
 
 	exHandler func(frame *Frame, ex *heap.Object)
+
+	// syncObj monitor held by ACC_SYNCHRONIZED method (this or java.lang.Class object)
+	// released when method return or unwound by exception
+	syncObj *heap.Object
 }
 
 // NewFrame create new Frame
@@ -173,6 +177,23 @@ func (f *Frame) RevertNextPC() {
 
 func (f *Frame) Method() *method_area.Method { return f.method }
 
+// EnterSyncMonitor lock obj for ACC_SYNCHRONIZED method, owner is current thread
+func (f *Frame) EnterSyncMonitor(obj *heap.Object) {
+	obj.MonitorEnter(f.thread)
+	f.syncObj = obj
+}
+
+// ExitSyncMonitor unlock ACC_SYNCHRONIZED method's monitor (if any)
+// return false if current thread doesn't hold it anymore (unbalanced monitorexit in method body)
+func (f *Frame) ExitSyncMonitor() bool {
+	if f.syncObj == nil {
+		return true
+	}
+	obj := f.syncObj
+	f.syncObj = nil
+	return obj.MonitorExit(f.thread)
+}
+
 func (f *Frame) JavaThrow(ex *heap.Object) {
 	if ex == nil {
 		panic("JavaThrow called with nil exception.")
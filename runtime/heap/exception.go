@@ -1,12 +1,40 @@
 package heap
 
+import "fmt"
+
 // ============================================================
 // Exception Object Factory - v0.2.10
 // ============================================================
 
 // ExceptionData store in object.extra
 type ExceptionData struct {
-	Message string // ex: "/ by zero"
+	Message    string // ex: "/ by zero"
+	Cause      *Object
+	StackTrace []*StackTraceElement // captured when thrown (nil: not captured yet), top frame first
+}
+
+// StackTraceElement one frame of backtrace (java.lang.StackTraceElement)
+type StackTraceElement struct {
+	ClassName  string // java name, ex: com.foo.Main
+	MethodName string
+	FileName   string // empty if unknown
+	LineNumber int    // -1: unknown, -2: native method
+}
+
+// String java style: com.foo.Main.main(Main.java:42)
+func (e *StackTraceElement) String() string {
+	var source string
+	switch {
+	case e.LineNumber == -2:
+		source = "Native Method"
+	case e.FileName == "":
+		source = "Unknown Source"
+	case e.LineNumber >= 0:
+		source = fmt.Sprintf("%s:%d", e.FileName, e.LineNumber)
+	default:
+		source = e.FileName
+	}
+	return fmt.Sprintf("%s.%s(%s)", e.ClassName, e.MethodName, source)
 }
 
 func NewExceptionObject(exClass interface{}, message string) *Object {
@@ -36,3 +64,12 @@ func (o *Object) GetExceptionData() *ExceptionData {
 
 	return nil
 }
+
+// InitExceptionData get ex data, attach an empty one if object has none (exception created by java code `new`)
+// return nil if object.extra is used by something else
+func (o *Object) InitExceptionData() *ExceptionData {
+	if o.extra == nil {
+		o.extra = &ExceptionData{}
+	}
+	return o.GetExceptionData()
+}
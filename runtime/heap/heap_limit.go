@@ -0,0 +1,117 @@
+package heap
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"sync/atomic"
+)
+
+// ============================================================
+// Heap Size Limit (-Xms / -Xmx)
+// ============================================================
+// gogo-jvm objects live in Go heap and are collected by Go GC,
+// so java heap usage = Go heap objects (including class metadata of gogo-jvm itself).
+//
+// -Xmx: max heap size, 0 means unlimited (default)
+//   - single allocation bigger than max heap → OutOfMemoryError directly
+//   - every (max heap / 16) bytes allocated, check live heap:
+//     over limit → force GC (-verbose:gc log), still over limit → OutOfMemoryError
+//   - also passed to Go runtime as soft memory limit, Go GC works harder when heap is near it
+//
+// -Xms: initial heap size, Go heap grows on demand, only reported as committed size in -verbose:gc
+
+const heapCheckRatio = 16
+
+var (
+	maxHeapSize         uint64 // 0: unlimited
+	initialHeapSize     uint64
+	verboseGC           bool
+	allocatedSinceCheck uint64 // atomic, objects may be allocated by multiple goroutines
+)
+
+// SetHeapSize set -Xms / -Xmx (bytes), 0 means not specified
+func SetHeapSize(initial, maxSize uint64) error {
+	if maxSize > 0 && initial > maxSize {
+		return fmt.Errorf("initial heap size set to a larger value than the maximum heap size")
+	}
+	initialHeapSize, maxHeapSize = initial, maxSize
+	if maxSize > 0 {
+		debug.SetMemoryLimit(int64(maxSize))
+	}
+	return nil
+}
+
+func MaxHeapSize() uint64     { return maxHeapSize }
+func InitialHeapSize() uint64 { return initialHeapSize }
+
+// SetVerboseGC -verbose:gc
+func SetVerboseGC(verbose bool) {
+	verboseGC = verbose
+}
+
+// checkAllocation called before allocating object / array of `size` bytes
+func checkAllocation(size uint64) {
+	if maxHeapSize == 0 {
+		return
+	}
+	if size > maxHeapSize {
+		panic(common.NewJavaException("java/lang/OutOfMemoryError", "Java heap space"))
+	}
+
+	if atomic.AddUint64(&allocatedSinceCheck, size) < maxHeapSize/heapCheckRatio {
+		return
+	}
+	atomic.StoreUint64(&allocatedSinceCheck, 0)
+
+	before := heapObjectBytes()
+	if before+size <= maxHeapSize {
+		return
+	}
+	runtime.GC()
+	after := heapObjectBytes()
+	if verboseGC {
+		committed := max(initialHeapSize, after)
+		fmt.Printf("[GC (Allocation Failure) %dK->%dK(%dK)]\n", before/1024, after/1024, committed/1024)
+	}
+	if after+size > maxHeapSize {
+		panic(common.NewJavaException("java/lang/OutOfMemoryError", "Java heap space"))
+	}
+}
+
+// SystemGC System.gc(): force full GC, unreachable objects (and classes of unreachable user-defined loaders) are reclaimed
+func SystemGC() {
+	before := heapObjectBytes()
+	runtime.GC()
+	if verboseGC {
+		after := heapObjectBytes()
+		committed := max(initialHeapSize, after)
+		fmt.Printf("[Full GC (System.gc()) %dK->%dK(%dK)]\n", before/1024, after/1024, committed/1024)
+	}
+}
+
+// heapObjectBytes bytes occupied by Go heap objects (live + not yet swept)
+func heapObjectBytes() uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// object size estimation: header (mark word + class pointer) + payload
+const objectHeaderSize = 16
+
+func objectSize(slotCount uint) uint64 {
+	return objectHeaderSize + uint64(slotCount)*8
+}
+
+func arraySize(length int32, elementSize uint64) uint64 {
+	if length < 0 {
+		return objectHeaderSize
+	}
+	return objectHeaderSize + uint64(length)*elementSize
+}
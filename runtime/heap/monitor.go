@@ -0,0 +1,196 @@
+package heap
+
+import (
+	"sync"
+)
+
+// ============================================================
+// Monitor - synchronized / monitorenter / monitorexit
+// ============================================================
+// lock state is kept in Mark Word lock bits (see mark_word.go):
+//
+//	01 unlocked ──enter──► 00 thin lock ──contention──► 10 heavy lock (Monitor)
+//	                        │  ▲
+//	                        └──┘ same owner enter again: lockCount++ (reentrant)
+//
+// thin lock: no per-object mutex, owner and recursion count are saved in object's lock record
+// (HotSpot put a Lock Record on owner's stack and CAS its address into Mark Word,
+// we keep it in Object instead, hashCode bits stay in Mark Word untouched).
+// lock record and thin lock bits are changed together under inflateLock, so nobody sees one without the other.
+//
+// heavy lock: once another owner try to enter a thin-locked object, the lock is inflated
+// into a Monitor (mutex + cond), it never deflate back.
+//
+// owner is interface{} (actual type is *runtime.Thread) to avoid circular import.
+
+// inflateLock guard thin lock record, serialize thin lock -> heavy lock inflation
+var inflateLock sync.Mutex
+
+// Monitor heavyweight lock (ObjectMonitor)
+type Monitor struct {
+	mu         sync.Mutex
+	cond       *sync.Cond
+	owner      interface{}
+	entryCount uint32 // reentrant count
+}
+
+func newMonitor(owner interface{}, entryCount uint32) *Monitor {
+	m := &Monitor{owner: owner, entryCount: entryCount}
+	m.cond = sync.NewCond(&m.mu)
+	return m
+}
+
+// Enter block until monitor is free or already owned by owner
+func (m *Monitor) Enter(owner interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for m.owner != nil && m.owner != owner {
+		m.cond.Wait()
+	}
+	m.owner = owner
+	m.entryCount++
+}
+
+// Exit return false if owner doesn't hold this monitor (IllegalMonitorStateException)
+func (m *Monitor) Exit(owner interface{}) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.owner != owner || m.entryCount == 0 {
+		return false
+	}
+	m.entryCount--
+	if m.entryCount == 0 {
+		m.owner = nil
+		m.cond.Signal()
+	}
+	return true
+}
+
+// Owner current owner, nil if free
+func (m *Monitor) Owner() interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.owner
+}
+
+// ============================================================
+// Object lock operations
+// ============================================================
+
+// MonitorEnter acquire object lock (monitorenter / synchronized method entry)
+func (o *Object) MonitorEnter(owner interface{}) {
+	for {
+		switch o.LockState() {
+		case LockStateUnlocked, LockStateLightLock:
+			if o.enterThin(owner) {
+				return
+			}
+			// inflated, retry as heavy lock
+		case LockStateHeavyLock:
+			o.monitor.Enter(owner)
+			return
+		default:
+			// GC marked, should not happen while mutator is running
+			panic("MonitorEnter on a GC marked object")
+		}
+	}
+}
+
+// enterThin take or re-enter thin lock, return false if lock is (or has just been) inflated.
+// lock record (lockOwner, lockCount) is only touched under inflateLock:
+// owner is written before lock bits become 00, so a contender never sees a thin lock without owner,
+// and reentrant lockCount++ can't interleave with inflate copying the count.
+func (o *Object) enterThin(owner interface{}) bool {
+	inflateLock.Lock()
+	defer inflateLock.Unlock()
+
+	switch o.LockState() {
+	case LockStateUnlocked:
+		// 01 -> 00: first owner, take thin lock
+		o.lockOwner = owner
+		o.lockCount = 1
+		o.SetLockState(LockStateLightLock)
+		return true
+	case LockStateLightLock:
+		if o.lockOwner == owner {
+			// reentrant
+			o.lockCount++
+			return true
+		}
+		// contention: inflate then retry as heavy lock
+		o.inflateLocked()
+		return false
+	default:
+		return false
+	}
+}
+
+// MonitorExit release object lock (monitorexit / synchronized method exit)
+// return false if owner doesn't hold the lock -> caller throw IllegalMonitorStateException
+func (o *Object) MonitorExit(owner interface{}) bool {
+	if o.LockState() == LockStateLightLock {
+		// hold inflateLock, so the lock record won't be moved into Monitor halfway
+		inflateLock.Lock()
+		if o.LockState() == LockStateLightLock {
+			defer inflateLock.Unlock()
+			if o.lockOwner != owner {
+				return false
+			}
+			o.lockCount--
+			if o.lockCount == 0 {
+				o.lockOwner = nil
+				o.SetLockState(LockStateUnlocked)
+			}
+			return true
+		}
+		inflateLock.Unlock()
+	}
+
+	switch o.LockState() {
+	case LockStateHeavyLock:
+		return o.monitor.Exit(owner)
+	default:
+		// unlocked
+		return false
+	}
+}
+
+// IsLockedBy check owner holds object lock (wait/notify need it)
+func (o *Object) IsLockedBy(owner interface{}) bool {
+	owned, _ := o.lockRecord(owner)
+	return owned
+}
+
+// LockCount reentrant count of current owner (0 if unlocked)
+func (o *Object) LockCount() uint32 {
+	_, count := o.lockRecord(nil)
+	return count
+}
+
+// lockRecord owner holds the lock or not, and reentrant count of current owner
+func (o *Object) lockRecord(owner interface{}) (bool, uint32) {
+	if o.LockState() == LockStateLightLock {
+		inflateLock.Lock()
+		if o.LockState() == LockStateLightLock {
+			defer inflateLock.Unlock()
+			return o.lockOwner == owner, o.lockCount
+		}
+		inflateLock.Unlock()
+	}
+	if o.LockState() != LockStateHeavyLock {
+		return false, 0
+	}
+	o.monitor.mu.Lock()
+	defer o.monitor.mu.Unlock()
+	return o.monitor.owner == owner, o.monitor.entryCount
+}
+
+// inflateLocked thin lock -> heavy lock (inflateLock held)
+// the thin lock owner and its recursion count move into the new Monitor,
+// so owner's later MonitorExit goes through Monitor.Exit.
+func (o *Object) inflateLocked() {
+	o.monitor = newMonitor(o.lockOwner, o.lockCount)
+	o.lockOwner = nil
+	o.lockCount = 0
+	o.SetLockState(LockStateHeavyLock)
+}
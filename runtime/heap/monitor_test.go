@@ -0,0 +1,121 @@
+package heap
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// owner 只需要是可比較的指標, 這裡用假 thread 代替 *runtime.Thread
+type fakeThread struct{ name string }
+
+func Test_ThinLockReentrant(t *testing.T) {
+	obj := NewObject(nil, 0)
+	t1 := &fakeThread{"t1"}
+	hash := obj.HashCode()
+
+	obj.MonitorEnter(t1)
+	assert.Equal(t, uint8(LockStateLightLock), obj.LockState())
+	obj.MonitorEnter(t1) // reentrant
+	assert.Equal(t, uint32(2), obj.LockCount())
+	assert.True(t, obj.IsLockedBy(t1))
+
+	assert.True(t, obj.MonitorExit(t1))
+	assert.Equal(t, uint8(LockStateLightLock), obj.LockState())
+	assert.True(t, obj.MonitorExit(t1))
+	assert.Equal(t, uint8(LockStateUnlocked), obj.LockState())
+
+	// hashCode 不受 lock bits 影響
+	assert.Equal(t, hash, obj.HashCode())
+}
+
+func Test_UnbalancedExit(t *testing.T) {
+	obj := NewObject(nil, 0)
+	t1 := &fakeThread{"t1"}
+	t2 := &fakeThread{"t2"}
+
+	// 沒有 lock 就 exit
+	assert.False(t, obj.MonitorExit(t1))
+
+	// 非 owner exit
+	obj.MonitorEnter(t1)
+	assert.False(t, obj.MonitorExit(t2))
+	assert.True(t, obj.MonitorExit(t1))
+	assert.False(t, obj.MonitorExit(t1))
+}
+
+func Test_InflateOnContention(t *testing.T) {
+	obj := NewObject(nil, 0)
+	t1 := &fakeThread{"t1"}
+	t2 := &fakeThread{"t2"}
+
+	obj.MonitorEnter(t1)
+	obj.MonitorEnter(t1)
+
+	acquired := make(chan struct{})
+	go func() {
+		obj.MonitorEnter(t2) // 競爭 -> 膨脹為 heavy lock, 並等待 t1 釋放
+		close(acquired)
+	}()
+
+	// 等待膨脹完成
+	for obj.LockState() != LockStateHeavyLock {
+		time.Sleep(time.Millisecond)
+	}
+	// 膨脹後 owner 與重入次數保留
+	assert.True(t, obj.IsLockedBy(t1))
+	assert.Equal(t, uint32(2), obj.LockCount())
+
+	assert.True(t, obj.MonitorExit(t1))
+	select {
+	case <-acquired:
+		t.Fatal("t2 acquired lock before t1 fully released")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	assert.True(t, obj.MonitorExit(t1))
+	<-acquired
+	assert.True(t, obj.IsLockedBy(t2))
+	assert.True(t, obj.MonitorExit(t2))
+	assert.Nil(t, obj.monitor.Owner())
+}
+
+func Test_ConcurrentEnterExit(t *testing.T) {
+	// 每輪用新物件, 讓 thin lock -> heavy lock 膨脹的競爭反覆發生
+	const rounds, threads, loops = 200, 8, 50
+	for r := 0; r < rounds; r++ {
+		obj := NewObject(nil, 0)
+		counter := 0
+
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < threads; i++ {
+			wg.Add(1)
+			go func(th *fakeThread) {
+				defer wg.Done()
+				<-start
+				for j := 0; j < loops; j++ {
+					obj.MonitorEnter(th)
+					obj.MonitorEnter(th) // reentrant, 與膨脹同時發生
+					counter++
+					if !obj.IsLockedBy(th) || obj.LockCount() != 2 {
+						t.Errorf("%s lost lock record", th.name)
+					}
+					obj.MonitorExit(th)
+					if !obj.MonitorExit(th) {
+						t.Errorf("%s exit failed", th.name)
+					}
+				}
+			}(&fakeThread{fmt.Sprintf("t%d", i)})
+		}
+		close(start)
+		wg.Wait()
+
+		// 互斥成立則沒有遺失的遞增, 全部 exit 後無人持有
+		assert.Equal(t, threads*loops, counter)
+		assert.Equal(t, uint32(0), obj.LockCount())
+	}
+}
@@ -2,6 +2,7 @@ package heap
 
 import (
 	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
 	"github.com/Johnny1110/gogo_jvm/runtime/rtcore"
 )
 
@@ -39,6 +40,13 @@ type Object struct {
 	// - String Object: possibly store Go string
 	// - Class Object: store *Class (usage: reflection)
 	extra interface{}
+
+	// lock record (synchronized), see monitor.go
+	// - thin lock: owner + recursion count, mark word lock bits = 00
+	// - heavy lock: inflated monitor, mark word lock bits = 10
+	lockOwner interface{}
+	lockCount uint32
+	monitor   *Monitor
 }
 
 // NewObject create new object with specified class
@@ -47,6 +55,7 @@ type Object struct {
 // the object's constructor is calling by `invokespecial` <init>
 func NewObject(class interface{}, slotCount uint) *Object {
 	fmt.Println("@@ Debug - [NewObject] class:", class, ", slotCount:", slotCount)
+	checkAllocation(objectSize(slotCount))
 	return &Object{
 		markWord: InitialMarkWord, // init state: non-lock, age=0, hashCode=0
 		class:    class,
@@ -146,7 +155,7 @@ func IsNull(obj *Object) bool {
 // CheckNotNull check non-null
 func CheckNotNull(obj *Object) {
 	if obj == nil {
-		panic("java.lang.NullPointerException")
+		panic(common.NewJavaException("java/lang/NullPointerException", ""))
 	}
 }
 
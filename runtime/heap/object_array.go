@@ -1,6 +1,9 @@
 package heap
 
-import "fmt"
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+)
 
 // extend content for object.go
 
@@ -9,6 +12,7 @@ import "fmt"
 
 // NewByteArray create []byte or []bool
 func NewByteArray(class interface{}, length int32) *Object {
+	checkAllocation(arraySize(length, 1))
 	return &Object{
 		markWord: InitialMarkWord, // init state: non-lock, age=0, hashCode=0
 		class:    class,
@@ -18,6 +22,7 @@ func NewByteArray(class interface{}, length int32) *Object {
 
 // NewShortArray create short[] array
 func NewShortArray(class interface{}, length int32) *Object {
+	checkAllocation(arraySize(length, 2))
 	return &Object{
 		markWord: InitialMarkWord, // init state: non-lock, age=0, hashCode=0
 		class:    class,
@@ -27,6 +32,7 @@ func NewShortArray(class interface{}, length int32) *Object {
 
 // NewIntArray create int[] array
 func NewIntArray(class interface{}, length int32) *Object {
+	checkAllocation(arraySize(length, 4))
 	fmt.Printf("@@ DEBUG - NewIntArray(), class: %v, length: %v\n", class, length)
 	return &Object{
 		markWord: InitialMarkWord, // init state: non-lock, age=0, hashCode=0
@@ -37,6 +43,7 @@ func NewIntArray(class interface{}, length int32) *Object {
 
 // NewLongArray create long[] array
 func NewLongArray(class interface{}, length int32) *Object {
+	checkAllocation(arraySize(length, 8))
 	return &Object{
 		markWord: InitialMarkWord, // init state: non-lock, age=0, hashCode=0
 		class:    class,
@@ -47,6 +54,7 @@ func NewLongArray(class interface{}, length int32) *Object {
 // NewCharArray create char[] array
 // Java char is 16-bit unsigned
 func NewCharArray(class interface{}, length int32) *Object {
+	checkAllocation(arraySize(length, 2))
 	return &Object{
 		markWord: InitialMarkWord, // init state: non-lock, age=0, hashCode=0
 		class:    class,
@@ -56,6 +64,7 @@ func NewCharArray(class interface{}, length int32) *Object {
 
 // NewFloatArray create float[] array
 func NewFloatArray(class interface{}, length int32) *Object {
+	checkAllocation(arraySize(length, 4))
 	return &Object{
 		markWord: InitialMarkWord, // init state: non-lock, age=0, hashCode=0
 		class:    class,
@@ -65,6 +74,7 @@ func NewFloatArray(class interface{}, length int32) *Object {
 
 // NewDoubleArray create double[] array
 func NewDoubleArray(class interface{}, length int32) *Object {
+	checkAllocation(arraySize(length, 8))
 	return &Object{
 		markWord: InitialMarkWord, // init state: non-lock, age=0, hashCode=0
 		class:    class,
@@ -74,6 +84,7 @@ func NewDoubleArray(class interface{}, length int32) *Object {
 
 // NewRefArray create ref array (Object[], String[], other class[])
 func NewRefArray(class interface{}, length int32) *Object {
+	checkAllocation(arraySize(length, 8))
 	return &Object{
 		markWord: InitialMarkWord, // init state: non-lock, age=0, hashCode=0
 		class:    class,
@@ -96,7 +107,7 @@ func (o *Object) IsArray() bool {
 
 func CheckIndex(arrLen, index int32) {
 	if index < 0 || index >= arrLen {
-		panic("java.lang.ArrayIndexOutOfBoundsException")
+		panic(common.NewJavaException("java/lang/ArrayIndexOutOfBoundsException", fmt.Sprintf("Index %d out of bounds for length %d", index, arrLen)))
 	}
 }
 
@@ -105,7 +116,7 @@ func (o *Object) checkArrayIndex(index int32) {
 		panic("Object is not an array")
 	}
 	if index < 0 || index >= o.ArrayLength() {
-		panic("java.lang.ArrayIndexOutOfBoundsException")
+		panic(common.NewJavaException("java/lang/ArrayIndexOutOfBoundsException", fmt.Sprintf("Index %d out of bounds for length %d", index, o.ArrayLength())))
 	}
 }
 
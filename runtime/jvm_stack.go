@@ -1,5 +1,7 @@
 package runtime
 
+import "github.com/Johnny1110/gogo_jvm/common"
+
 type JVMStack struct {
 	maxSize uint   // max size of jvm stack
 	size    uint   // current stack size
@@ -14,10 +16,13 @@ func NewJVMStack(maxSize uint) *JVMStack {
 	}
 }
 
+// Push push frame into stack
+// invoker should check IsFull() first and throw java/lang/StackOverflowError as java exception,
+// panic here only if it didn't
 func (s *JVMStack) Push(frame *Frame) {
 	// check StackOverFlow
-	if s.size >= s.maxSize {
-		panic("java.lang.StackOverflowError")
+	if s.IsFull() {
+		panic(common.NewJavaException("java/lang/StackOverflowError", ""))
 	}
 
 	currentFrame := s.top
@@ -32,7 +37,7 @@ func (s *JVMStack) Push(frame *Frame) {
 
 func (s *JVMStack) Pop() *Frame {
 	if s.IsEmpty() {
-		panic("java.lang.StackUnderflowError")
+		panic(common.NewJavaException("java/lang/InternalError", "JVM stack underflow"))
 	}
 
 	poppedFrame := s.top
@@ -47,7 +52,7 @@ func (s *JVMStack) Pop() *Frame {
 
 func (s *JVMStack) Top() *Frame {
 	if s.IsEmpty() {
-		panic("java.lang.StackUnderflowError")
+		panic(common.NewJavaException("java/lang/InternalError", "JVM stack underflow"))
 	}
 
 	return s.top
@@ -61,6 +66,14 @@ func (s *JVMStack) Size() uint {
 	return s.size
 }
 
+func (s *JVMStack) MaxSize() uint {
+	return s.maxSize
+}
+
+func (s *JVMStack) IsFull() bool {
+	return s.size >= s.maxSize
+}
+
 // Clear clear stack
 func (s *JVMStack) Clear() {
 	for !s.IsEmpty() {
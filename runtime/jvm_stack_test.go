@@ -0,0 +1,22 @@
+package runtime
+
+import (
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_JVMStack_underflow(t *testing.T) {
+	stack := NewJVMStack(1)
+	for _, op := range []func(){func() { stack.Pop() }, func() { stack.Top() }} {
+		func() {
+			defer func() {
+				ex, ok := recover().(*common.JavaException)
+				if assert.True(t, ok, "expected java exception") {
+					assert.Equal(t, "java/lang/InternalError", ex.ClassName)
+				}
+			}()
+			op()
+		}()
+	}
+}
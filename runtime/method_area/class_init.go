@@ -0,0 +1,160 @@
+package method_area
+
+import "sync"
+
+// ============================================================
+// Class Initialization State (JVMS 5.5)
+// ============================================================
+// each class has an initialization lock LC, and a state:
+//
+//	NOT_INITIALIZED ──StartInit──► BEING_INITIALIZED(thread T) ──FinishInit──► INITIALIZED
+//	                                        │
+//	                                        └──FailInit──► ERRONEOUS (later use: NoClassDefFoundError)
+//
+// <clinit> is executed by interpreter (see instructions/references/class_init.go),
+// this file only keeps the state machine, thread is interface{} (*runtime.Thread) to avoid circular import.
+
+type ClassInitState uint8
+
+const (
+	CLASS_NOT_INITIALIZED   ClassInitState = iota // linked, <clinit> not run yet
+	CLASS_BEING_INITIALIZED                       // initThread is running its supers' init or <clinit>
+	CLASS_INITIALIZED                             // ready for use
+	CLASS_ERRONEOUS                               // init failed, can not be used anymore
+)
+
+func (s ClassInitState) String() string {
+	switch s {
+	case CLASS_NOT_INITIALIZED:
+		return "not initialized"
+	case CLASS_BEING_INITIALIZED:
+		return "being initialized"
+	case CLASS_INITIALIZED:
+		return "initialized"
+	default:
+		return "erroneous"
+	}
+}
+
+// InitAction result of StartInit, what caller (thread T) should do next
+type InitAction uint8
+
+const (
+	INIT_PROCEED   InitAction = iota // class is marked being initialized by T, T must initialize it
+	INIT_DONE                        // class is initialized, or recursive request by T (use it as is)
+	INIT_ERRONEOUS                   // class is erroneous, throw NoClassDefFoundError
+)
+
+// IsInitialized class is ready for use
+func (c *Class) IsInitialized() bool {
+	c.initLock.Lock()
+	defer c.initLock.Unlock()
+	return c.initState == CLASS_INITIALIZED
+}
+
+func (c *Class) InitState() ClassInitState {
+	c.initLock.Lock()
+	defer c.initLock.Unlock()
+	return c.initState
+}
+
+// StartInit JVMS 5.5 step 1~6, class being initialized by other thread: block until it is done or failed
+func (c *Class) StartInit(thread interface{}) InitAction {
+	c.initLock.Lock()
+	defer c.initLock.Unlock()
+
+	for c.initState == CLASS_BEING_INITIALIZED && c.initThread != thread {
+		c.getInitCond().Wait()
+	}
+
+	switch c.initState {
+	case CLASS_BEING_INITIALIZED, CLASS_INITIALIZED:
+		return INIT_DONE
+	case CLASS_ERRONEOUS:
+		return INIT_ERRONEOUS
+	default:
+		c.initState = CLASS_BEING_INITIALIZED
+		c.initThread = thread
+		return INIT_PROCEED
+	}
+}
+
+// FinishInit JVMS 5.5 step 10, <clinit> completed normally
+// return class waiting for this one (continue its init), nil if none
+func (c *Class) FinishInit() *Class {
+	return c.endInit(CLASS_INITIALIZED)
+}
+
+// FailInit JVMS 5.5 step 11/12, <clinit> (or super's init) completed abruptly
+// return class waiting for this one (it fails as well), nil if none
+func (c *Class) FailInit() *Class {
+	return c.endInit(CLASS_ERRONEOUS)
+}
+
+func (c *Class) endInit(state ClassInitState) *Class {
+	c.initLock.Lock()
+	defer c.initLock.Unlock()
+
+	c.initState = state
+	c.initThread = nil
+	waiter := c.initWaiter
+	c.initWaiter = nil
+	c.getInitCond().Broadcast()
+	return waiter
+}
+
+// SetInitWaiter class continues its init after this one is initialized (called by initializing thread)
+func (c *Class) SetInitWaiter(waiter *Class) {
+	c.initLock.Lock()
+	defer c.initLock.Unlock()
+	c.initWaiter = waiter
+}
+
+func (c *Class) getInitCond() *sync.Cond {
+	if c.initCond == nil {
+		c.initCond = sync.NewCond(&c.initLock)
+	}
+	return c.initCond
+}
+
+// InitDependencies classes must be initialized before this class (JVMS 5.5 step 7):
+// superclass, then superinterfaces declaring non-abstract non-static (default) methods,
+// in recursive left-to-right order of interfaces array (super interfaces before the interface).
+// interface doesn't init its superinterfaces
+func (c *Class) InitDependencies() []*Class {
+	if c.IsInterface() {
+		return nil
+	}
+
+	var deps []*Class
+	if c.superClass != nil {
+		deps = append(deps, c.superClass)
+	}
+	visited := map[*Class]bool{}
+	var collect func(iface *Class)
+	collect = func(iface *Class) {
+		if visited[iface] {
+			return
+		}
+		visited[iface] = true
+		for _, super := range iface.interfaces {
+			collect(super)
+		}
+		if iface.declaresDefaultMethod() {
+			deps = append(deps, iface)
+		}
+	}
+	for _, iface := range c.interfaces {
+		collect(iface)
+	}
+	return deps
+}
+
+func (c *Class) declaresDefaultMethod() bool {
+	for _, method := range c.methods {
+		if !method.IsAbstract() && !method.IsStatic() {
+			return true
+		}
+	}
+	return false
+}
@@ -1,5 +1,7 @@
 package method_area
 
+import "github.com/Johnny1110/gogo_jvm/common"
+
 // ============================================================
 // v0.3.3: Object Lifecycle Support Methods
 // ============================================================
@@ -29,7 +31,7 @@ func (c *Class) IsCloneable() bool {
 	// recursive check all []interfaces to find java/lang/Cloneable
 	cloneableClass := c.Loader().LoadClass("java/lang/Cloneable", false)
 	if cloneableClass == nil {
-		panic("java class not found: java/lang/Cloneable")
+		panic(common.NewJavaException("java/lang/NoClassDefFoundError", "java/lang/Cloneable"))
 	}
 	return c.IsSubInterfaceOf(cloneableClass)
 }
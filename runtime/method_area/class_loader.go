@@ -3,15 +3,35 @@ package method_area
 import (
 	"fmt"
 	"github.com/Johnny1110/gogo_jvm/classfile"
+	"github.com/Johnny1110/gogo_jvm/classpath"
 	"github.com/Johnny1110/gogo_jvm/common"
 	"github.com/Johnny1110/gogo_jvm/runtime/heap"
 	"github.com/Johnny1110/gogo_jvm/runtime/rtcore"
-	"io/ioutil"
+	"strings"
 )
 
+// ClassLoader runtime class loader
+// built-in loaders (parent-first delegation, see LoadClass):
+//
+//	bootstrap → platform → app (system class loader)
+//
+// user-defined loader is a java.lang.ClassLoader subclass object, its loading is done by java code (loadClass)
 type ClassLoader struct {
-	classPath string            // .class files dir
-	classMap  map[string]*Class // loaded classes（Method Area）- key: className
+	name      string               // bootstrap / platform / app, or java class name of user-defined loader
+	parent    *ClassLoader         // delegation parent of built-in loader, nil for bootstrap and user-defined loader
+	classpath *classpath.Classpath // dirs & jars to find .class files, nil for user-defined loader
+	packages  []string             // packages defined by this built-in loader, nil: all (see findClass)
+	builtin   bool
+
+	id uint64
+	// classes initiated (and defined) by this loader, key: className, see SystemDictionary
+	classes map[string]*Class
+	// shared by all loaders (Method Area)
+	dictionary *SystemDictionary
+	// user-defined loader only: reports unloading when loader is reclaimed (see class_unloading.go)
+	unloading *loaderUnloading
+	// java.lang.ClassLoader object, nil for bootstrap (null in java)
+	jLoader *heap.Object
 
 	// v0.3.1: Reflection Support - 解決雞生蛋問題
 	// lClassClass is "java/lang/Class" 的 Class (metadata)
@@ -21,17 +41,50 @@ type ClassLoader struct {
 	primitiveClasses map[string]*Class
 }
 
-// NewClassLoader create class loader
+// there is no runtime image (jimage / rt.jar) in this VM, rt classes are found in classpath as well,
+// so built-in loaders split classpath by package name
+var (
+	bootPackages     = []string{"java/", "jdk/", "sun/"}
+	platformPackages = []string{"javax/"}
+)
+
+// NewClassLoader create built-in class loaders: bootstrap → platform → app, return app loader (system class loader)
+// classPath: path list separated by ':' (dir, .jar / .zip, dir/*), see classpath.Parse
 func NewClassLoader(classPath string) *ClassLoader {
-	loader := &ClassLoader{
-		classPath:        classPath,
-		classMap:         make(map[string]*Class),
+	dictionary := newSystemDictionary()
+	bootstrap := &ClassLoader{
+		name:             "bootstrap",
+		id:               dictionary.newLoaderId(),
+		classes:          make(map[string]*Class),
+		classpath:        classpath.Parse(classPath),
+		packages:         bootPackages,
+		builtin:          true,
+		dictionary:       dictionary,
 		primitiveClasses: make(map[string]*Class),
 	}
+	dictionary.bootstrap = bootstrap
 	// v0.3.1: init reflection system
-	loader.initReflection()
+	bootstrap.initReflection()
+
+	dictionary.platform = bootstrap.newBuiltinChild("platform", platformPackages)
+	dictionary.app = dictionary.platform.newBuiltinChild("app", nil)
+	return dictionary.app
+}
 
-	return loader
+// newBuiltinChild create built-in loader delegating to this loader, searching same classpath
+func (loader *ClassLoader) newBuiltinChild(name string, packages []string) *ClassLoader {
+	return &ClassLoader{
+		name:             name,
+		id:               loader.dictionary.newLoaderId(),
+		classes:          make(map[string]*Class),
+		parent:           loader,
+		classpath:        loader.classpath,
+		packages:         packages,
+		builtin:          true,
+		dictionary:       loader.dictionary,
+		jlClassClass:     loader.jlClassClass,
+		primitiveClasses: loader.primitiveClasses,
+	}
 }
 
 // ============================================================
@@ -56,7 +109,7 @@ func (loader *ClassLoader) initReflection() {
 	jlClassClass.jClass = loader.createJClassObject(jlClassClass)
 	// ---------------------------------------------------------------------------
 	// 3: create jClass for other loaded classes
-	for _, class := range loader.classMap {
+	for _, class := range loader.dictionary.definedClasses(loader) {
 		if class.jClass == nil {
 			class.jClass = loader.createJClassObject(class)
 		}
@@ -71,30 +124,30 @@ func (loader *ClassLoader) initReflection() {
 // loadBasicClass load basic class not create  jClass
 func (loader *ClassLoader) loadBasicClass(name string) *Class {
 	// if loaded just return
-	if class, ok := loader.classMap[name]; ok {
-		return class
+	if class := loader.dictionary.find(loader, name); class != nil {
+		return checkLinked(class)
 	}
 
 	// read class bytecode
-	classBytecode, err := loader.readClass(name)
+	classBytecode, entry, err := loader.readClass(name)
 	if err != nil {
 		fmt.Printf("read class %s error: %v \n", name, err)
-		panic("java.lang.ClassNotFoundException: " + name)
+		panic(common.NewJavaException("java/lang/ClassNotFoundException", name))
 	}
 
 	// parse classfile
 	cf, err := classfile.Parse(classBytecode)
 	if err != nil {
 		fmt.Printf("parse class %s error: %v \n", name, err)
-		panic("java.lang.ClassFormatError: " + err.Error())
+		panicParseError(err)
 	}
 
 	// create class without jClass
 	class := newClass(cf)
 	class.loader = loader
 
-	// store into classMap
-	loader.classMap[name] = class
+	// store into Method Area
+	loader.dictionary.define(class)
 
 	// load super
 	loader.resolveSuperClass(class)
@@ -103,6 +156,7 @@ func (loader *ClassLoader) loadBasicClass(name string) *Class {
 	// link（Verification and Preparation）
 	link(class)
 
+	logLoadedClass(name, entry.String())
 	fmt.Printf("@@ Debug - [ClassLoader] Loaded (basic): %s\n", name)
 	return class
 }
@@ -166,6 +220,7 @@ func (loader *ClassLoader) GetPrimitiveClass(name string) *Class {
 
 // LoadClass load class
 // name: class's full name, like "java/lang/Object" or "Calculator"
+// this loader becomes an initiating loader of returned class (see SystemDictionary)
 func (loader *ClassLoader) LoadClass(name string, debug bool) *Class {
 	// 1. check primitiveClass first (v0.3.1)
 	if primitiveClass, ok := loader.primitiveClasses[name]; ok {
@@ -173,18 +228,28 @@ func (loader *ClassLoader) LoadClass(name string, debug bool) *Class {
 	}
 
 	// 2. check is loaded or not, return cache.
-	if class, ok := loader.classMap[name]; ok {
-		return class
+	if class := loader.dictionary.find(loader, name); class != nil {
+		return checkLinked(class)
 	}
 
-	// check array type (v0.3.1)
+	var class *Class
 	if len(name) > 0 && name[0] == '[' {
-		// 3. load array class
-		return loader.loadArrayClass(name)
+		// 3. load array class (v0.3.1)
+		class = loader.loadArrayClass(name)
+	} else if loader.builtin {
+		// 4. load normal class, parent-first delegation
+		class = loader.loadNonArrayClass(name, debug)
 	} else {
-		// 4. load normal class
-		return loader.loadNonArrayClass(name, debug)
+		// 5. user-defined loader: java code loadClass(String)
+		class = loader.loadClassByJava(name)
 	}
+
+	if class == nil {
+		fmt.Printf("load class %s error: not found by loader %s\n", name, loader)
+		panic(common.NewJavaException("java/lang/ClassNotFoundException", name))
+	}
+	loader.dictionary.initiate(loader, class)
+	return checkLinked(class)
 }
 
 func (loader *ClassLoader) LoadClassIface(name string) interface{} {
@@ -193,9 +258,28 @@ func (loader *ClassLoader) LoadClassIface(name string) interface{} {
 
 // loadArrayClass load array class
 // array class is dynamic generate, no need .class file
+// JVMS 5.3.3: array class is defined by component's defining loader (bootstrap for primitive component)
 func (loader *ClassLoader) loadArrayClass(name string) *Class {
 	fmt.Printf("@@ Debug - [ClassLoader] Loading array class: %s\n", name)
 
+	// parse elements
+	var componentClass *Class
+	definingLoader := loader.dictionary.bootstrap
+	componentClassName := GetComponentClassName(name)
+	if componentClassName != "" {
+		if isPrimitiveTypeName(componentClassName) {
+			// primitive type
+			componentClass = loader.GetPrimitiveClass(componentClassName)
+		} else {
+			// normal class type
+			componentClass = loader.LoadClass(componentClassName, false)
+			definingLoader = componentClass.loader
+		}
+	}
+	if definingLoader != loader {
+		return definingLoader.LoadClass(name, false)
+	}
+
 	arrayClass := &Class{
 		name:        name,
 		accessFlags: common.ACC_PUBLIC, // array class is public
@@ -205,25 +289,14 @@ func (loader *ClassLoader) loadArrayClass(name string) *Class {
 			loader.LoadClass("java/lang/Cloneable", false),
 			loader.LoadClass("java/io/Serializable", false),
 		},
-		initStarted: true, // array no need init
-	}
-
-	// parse elements
-	componentClassName := GetComponentClassName(name)
-	if componentClassName != "" {
-		if isPrimitiveTypeName(componentClassName) {
-			// primitive type
-			arrayClass.componentClass = loader.GetPrimitiveClass(componentClassName)
-		} else {
-			// normal class type
-			arrayClass.componentClass = loader.LoadClass(componentClassName, false)
-		}
+		componentClass: componentClass,
+		initState:      CLASS_INITIALIZED, // array no need init
 	}
 
 	// create jClass for arrayClass
 	arrayClass.jClass = loader.createJClassObject(arrayClass)
 	// cache
-	loader.classMap[name] = arrayClass
+	loader.dictionary.define(arrayClass)
 
 	fmt.Printf("@@ Debug - [ClassLoader] Loaded array class: %s (component: %s)\n",
 		name, componentClassName)
@@ -241,60 +314,128 @@ func isPrimitiveTypeName(name string) bool {
 	return false
 }
 
-// loadNonArrayClass load non array class
+// loadNonArrayClass built-in loader: parent-first delegation (bootstrap → platform → app), nil if not found
+// every loader on the delegation path which creates the class becomes its initiating loader
 func (loader *ClassLoader) loadNonArrayClass(name string, debug bool) *Class {
+	if class := loader.dictionary.find(loader, name); class != nil {
+		return checkLinked(class)
+	}
+
+	// 1. parent first
+	var class *Class
+	if loader.parent != nil {
+		class = loader.parent.loadNonArrayClass(name, debug)
+	}
+	// 2. find by itself
+	if class == nil {
+		class = loader.findClass(name, debug)
+	}
+
+	if class != nil {
+		loader.dictionary.initiate(loader, class)
+	}
+	return class
+}
+
+// findClass built-in loader: define class found in classpath, nil if not found or its package belongs to other loader
+func (loader *ClassLoader) findClass(name string, debug bool) *Class {
+	if !loader.ownsPackage(name) {
+		return nil
+	}
+
 	// 1. read .class
-	classBytecode, err := loader.readClass(name)
+	classBytecode, entry, err := loader.readClass(name)
+	if err != nil && loader == loader.dictionary.bootstrap && isVMThrowableClass(name) {
+		return loader.defineVMThrowableClass(name)
+	}
 	if err != nil {
-		fmt.Printf("read class %s error: %v \n", name, err)
-		panic("java.lang.ClassNotFoundException: " + name)
+		return nil
 	}
 
-	// 2. parse ClassFile bytecode to class object
-	class := loader.defineClass(classBytecode, debug)
+	// 2. parse ClassFile bytecode to class object, link and create java.lang.Class
+	return loader.defineAndLinkClass(classBytecode, name, entry.String(), debug)
+}
 
-	// 3. do link（Verification and Preparation）
+// ownsPackage built-in loader's packages, see bootPackages
+func (loader *ClassLoader) ownsPackage(name string) bool {
+	if loader.packages == nil {
+		return true
+	}
+	for _, pkg := range loader.packages {
+		if strings.HasPrefix(name, pkg) {
+			return true
+		}
+	}
+	return false
+}
+
+// defineAndLinkClass define class from bytecode, then link and create its java.lang.Class
+// source: where bytecode comes from, for -verbose:class
+func (loader *ClassLoader) defineAndLinkClass(data []byte, name, source string, debug bool) *Class {
+	// 1. parse ClassFile bytecode to class object
+	class := loader.defineClass(data, name, debug)
+
+	// 2. do link（Verification and Preparation）
 	link(class)
 
-	// 4. create java.lang.Class (v0.3.1)
+	// 3. create java.lang.Class (v0.3.1)
 	if class.jClass == nil && loader.jlClassClass != nil {
 		class.jClass = loader.createJClassObject(class)
 	}
 
-	fmt.Printf("@@ Debug - [ClassLoader] Loaded: %s\n", name)
+	logLoadedClass(class.name, source)
+	fmt.Printf("@@ Debug - [ClassLoader] Loaded: %s (loader: %s)\n", class.name, loader)
 	return class
 }
 
-// readClass read .class file
-func (loader *ClassLoader) readClass(name string) ([]byte, error) {
-	// try read from classpath
-	// classname format: java/lang/Object → java/lang/Object.class
-	filePath := loader.classPath + "/" + name + ".class"
-	data, err := ioutil.ReadFile(filePath)
-	if err != nil {
-		// try find at current path
-		filePath = loader.classPath + "/" + name + ".class"
-		data, err = ioutil.ReadFile(filePath)
-		if err != nil {
-			// try with class name
-			data, err = ioutil.ReadFile(name + ".class")
-		}
+// readClass read .class file from classpath
+// classname format: java/lang/Object → <entry>/java/lang/Object.class
+// return the classpath entry where class is found as well
+func (loader *ClassLoader) readClass(name string) ([]byte, classpath.Entry, error) {
+	return loader.classpath.ReadClass(name)
+}
+
+// -verbose:class
+var verboseClass bool
+
+func SetVerboseClass(verbose bool) {
+	verboseClass = verbose
+}
+
+// logLoadedClass -verbose:class log, ex: [Loaded java.lang.Object from /app/rt.jar]
+func logLoadedClass(name string, source string) {
+	if verboseClass {
+		fmt.Printf("[Loaded %s from %s]\n", strings.ReplaceAll(name, "/", "."), source)
 	}
-	return data, err
+}
+
+// Classpath classpath of this loader
+func (loader *ClassLoader) Classpath() *classpath.Classpath {
+	return loader.classpath
 }
 
 // defineClass define class (create Class from bytecode)
-func (loader *ClassLoader) defineClass(data []byte, debug bool) *Class {
+// name: expected class name, "" means any (ClassLoader.defineClass(null, ...))
+func (loader *ClassLoader) defineClass(data []byte, name string, debug bool) *Class {
 	// 1. parse ClassFile
 	cf, err := classfile.Parse(data)
 	if err != nil {
-		panic("java.lang.ClassFormatError: " + err.Error())
+		panicParseError(err)
 	}
 
 	if debug {
 		classfile.Debug(cf, true)
 	}
 
+	// module-info.class (Java 9+) only describe a module, can not be loaded as class
+	if cf.IsModule() {
+		panic(common.NewJavaException("java/lang/NoClassDefFoundError", cf.ClassName()+" is not a class because access_flag ACC_MODULE is set"))
+	}
+	if cf.IsPreview() {
+		fmt.Printf("@@ Debug - [ClassLoader] %s uses preview features of class file version %d\n", cf.ClassName(), cf.MajorVersion())
+	}
+	loader.checkDefinable(cf.ClassName(), name)
+
 	// 2. convert classfile to Class object
 	class := newClass(cf)
 	// make class linked with this ClassLoader
@@ -305,19 +446,46 @@ func (loader *ClassLoader) defineClass(data []byte, debug bool) *Class {
 	// 4. load interface
 	loader.resolveInterfaces(class)
 
-	// after step 3 and 4, all interfaces and parent, grandparent will be loaded into this ClassLoader
+	// after step 3 and 4, all interfaces and parent, grandparent will be loaded (initiated) by this ClassLoader
 
-	// 5. store into Method Area (this class will never be load again)
-	loader.classMap[class.name] = class
+	// 5. store into Method Area (this class will never be load again by this loader)
+	loader.dictionary.define(class)
 
 	return class
 }
 
+// checkDefinable check class `actual` (read from bytecode) can be defined by this loader as `name`
+func (loader *ClassLoader) checkDefinable(actual, name string) {
+	if name != "" && name != actual {
+		panic(common.NewJavaException("java/lang/NoClassDefFoundError", fmt.Sprintf("%s (wrong name: %s)", name, actual)))
+	}
+	// only bootstrap (and platform) loader can define java.* classes
+	if !loader.builtin && strings.HasPrefix(actual, "java/") {
+		pkg := actual[:strings.LastIndex(actual, "/")]
+		panic(common.NewJavaException("java/lang/SecurityException", "Prohibited package name: "+strings.ReplaceAll(pkg, "/", ".")))
+	}
+	// fail fast before loading super class
+	if loader.dictionary.find(loader, actual) != nil {
+		panic(duplicateClassDefinition(loader, actual))
+	}
+}
+
+// panicParseError java error from parser (ex: UnsupportedClassVersionError) is thrown as it is,
+// others are ClassFormatError
+func panicParseError(err error) {
+	if javaErr, ok := err.(*common.JavaException); ok {
+		panic(javaErr)
+	}
+	panic(common.NewJavaException("java/lang/ClassFormatError", err.Error()))
+}
+
 // resolveSuperClass load super class
 func (loader *ClassLoader) resolveSuperClass(class *Class) {
 	if class.name != "java/lang/Object" && class.superClassName != "" {
 		// recursive load parent class
 		class.superClass = class.loader.LoadClass(class.superClassName, false)
+		// Java 17+ sealed class
+		checkPermittedSubclass(class.superClass, class)
 	}
 }
 
@@ -328,27 +496,51 @@ func (loader *ClassLoader) resolveInterfaces(class *Class) {
 		class.interfaces = make([]*Class, interfaceCount)
 		for i, ifaceName := range class.interfaceNames {
 			class.interfaces[i] = class.loader.LoadClass(ifaceName, false)
+			checkPermittedSubclass(class.interfaces[i], class)
 		}
 	}
 }
 
 // link
+// class is already in dictionary (verifier may load it by name), so a class failed linking stays there as erroneous:
+// later loading of it throws the same error again (JVMS 5.4.3), its unverified code / half-prepared statics are never used.
 func link(class *Class) {
-	// 1. Verification - TODO: skip
+	defer func() {
+		if r := recover(); r != nil {
+			if ex, ok := r.(*common.JavaException); ok {
+				class.linkError = ex
+			}
+			panic(r)
+		}
+	}()
+
+	// 1. Verification
 	verify(class)
 
-	// 2.Preparation - allocate space for static const
+	// 2.Preparation - allocate space for static const, loader constraints of overriding methods
 	prepare(class)
 }
 
+// checkLinked throw linking error of erroneous class
+func checkLinked(class *Class) *Class {
+	if class.linkError != nil {
+		panic(class.linkError)
+	}
+	return class
+}
+
 // verify Verification check bytecode, prevent jvm from crash
 func verify(class *Class) {
-	// TODO: 字節碼驗證 MVP 階段跳過
+	// 字節碼驗證 (verifier.go), -Xverify 控制範圍
+	if shouldVerify(class) {
+		verifyMethods(class)
+	}
 }
 
 // prepare Preparation
 // allocate space for static const
 func prepare(class *Class) {
+	checkOverridingConstraints(class)
 	calcInstanceFieldSlotIds(class) // for object
 	calcStaticFieldSlotIds(class)   // for class
 	allocAndInitStaticVars(class)   // only init static, instant will be alloc when create object
@@ -391,7 +583,53 @@ func calcStaticFieldSlotIds(class *Class) {
 }
 
 // allocAndInitStaticVars allocate and init static-vars
+// static field with ConstantValue attribute (static final 常量) gets its value here, before <clinit>
 func allocAndInitStaticVars(class *Class) {
 	class.staticVars = rtcore.NewSlots(class.staticSlotCount)
-	// TODO: 初始化 static final 常量
+	for _, field := range class.fields {
+		// JVMS 4.7.2: ConstantValue of non-static field is silently ignored
+		if field.IsStatic() && field.constValueIndex > 0 {
+			initStaticFinalVar(class, field)
+		}
+	}
+}
+
+// initStaticFinalVar copy ConstantValue from rtcp into static var slot
+// ex: static final int MAX = 100;  static final String NAME = "gogo";
+func initStaticFinalVar(class *Class, field *Field) {
+	vars := class.staticVars
+	slotId := field.slotId
+	value := class.constantPool.GetConstant(field.constValueIndex)
+
+	ok := true
+	switch field.descriptor {
+	case "Z", "B", "C", "S", "I":
+		var val int32
+		val, ok = value.(int32)
+		vars.SetInt(slotId, val)
+	case "J":
+		var val int64
+		val, ok = value.(int64)
+		vars.SetLong(slotId, val)
+	case "F":
+		var val float32
+		val, ok = value.(float32)
+		vars.SetFloat(slotId, val)
+	case "D":
+		var val float64
+		val, ok = value.(float64)
+		vars.SetDouble(slotId, val)
+	case "Ljava/lang/String;":
+		var val string
+		if val, ok = value.(string); ok {
+			vars.SetRef(slotId, heap.InternString(val, class.loader))
+		}
+	default:
+		ok = false
+	}
+
+	if !ok {
+		panic(common.NewJavaException("java/lang/ClassFormatError",
+			fmt.Sprintf("Illegal ConstantValue for field %s.%s (%s)", class.name, field.name, field.descriptor)))
+	}
 }
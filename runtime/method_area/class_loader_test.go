@@ -0,0 +1,93 @@
+package method_area
+
+import (
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// static final 常量在 preparation 就有值, 不需要執行 <clinit>
+func Test_prepare_constantValue(t *testing.T) {
+	loader := NewClassLoader(testClassPath)
+	class := loader.LoadClass("ConstHolder", false)
+	assert.Equal(t, CLASS_NOT_INITIALIZED, class.InitState())
+
+	vars := class.StaticVars()
+	slotOf := func(name, descriptor string) uint { return class.GetField(name, descriptor, true).SlotId() }
+	assert.Equal(t, int32(42), vars.GetInt(slotOf("INT", "I")))
+	assert.Equal(t, int64(1234567890123), vars.GetLong(slotOf("LONG", "J")))
+	assert.Equal(t, float32(1.5), vars.GetFloat(slotOf("FLOAT", "F")))
+	assert.Equal(t, 2.25, vars.GetDouble(slotOf("DOUBLE", "D")))
+	assert.Equal(t, int32('A'), vars.GetInt(slotOf("CHAR", "C")))
+
+	str := vars.GetRef(slotOf("STRING", "Ljava/lang/String;")).(*heap.Object)
+	assert.Equal(t, "gogo", heap.GoString(str))
+	assert.Same(t, heap.InternString("gogo", loader), str)
+}
+
+// 同一份 bytes 由不同 loader 定義是不同 class, 同一 loader 只能定義一次
+func Test_loaderHierarchy_dictionary(t *testing.T) {
+	app := NewClassLoader(testClassPath)
+	platform := app.PlatformLoader()
+	assert.Same(t, platform, app.Parent())
+	assert.True(t, platform.Parent().IsBootstrap())
+
+	object := app.LoadClass("java/lang/Object", false)
+	assert.True(t, object.Loader().IsBootstrap())
+	assert.Same(t, object, app.FindLoadedClass("java/lang/Object")) // app, platform are initiating loaders by delegation
+	assert.Same(t, object, platform.FindLoadedClass("java/lang/Object"))
+
+	greeter := app.LoadClass("Greeter", false)
+	assert.Same(t, app, greeter.Loader())
+	assert.Nil(t, platform.LoadClassOrNil("Greeter")) // not in platform packages
+	assert.Nil(t, app.BootstrapLoader().FindLoadedClass("Greeter"))
+
+	data, err := os.ReadFile(filepath.Join(testClassPath, "Greeter.class"))
+	assert.NoError(t, err)
+	other := platform.DefineClass("Greeter", data)
+	assert.NotSame(t, greeter, other)
+	assert.Same(t, platform, other.Loader())
+
+	defer func() {
+		err := recover().(*common.JavaException)
+		assert.Equal(t, "java/lang/LinkageError", err.ClassName)
+		assert.Equal(t, "loader 'app' attempted duplicate class definition for Greeter.", err.Message)
+	}()
+	app.DefineClass("Greeter", data)
+}
+
+// link 失敗的 class 留在 dictionary 但為 erroneous, 再次載入拋出同樣的錯誤 (不會執行未驗證的 bytecode)
+func Test_link_erroneousClass(t *testing.T) {
+	loader := NewClassLoader(testClassPath)
+
+	cases := []struct {
+		name      string
+		exception string
+	}{
+		{"erroneous/BadVerify", "java/lang/VerifyError"},        // static int f() { fconst_0; ireturn }
+		{"erroneous/BadConstant", "java/lang/ClassFormatError"}, // static final int X = "x"
+	}
+	for _, c := range cases {
+		var first *common.JavaException
+		for i := 0; i < 2; i++ {
+			func() {
+				defer func() {
+					ex, ok := recover().(*common.JavaException)
+					if assert.True(t, ok, c.name) {
+						assert.Equal(t, c.exception, ex.ClassName, c.name)
+						if first == nil {
+							first = ex
+						} else {
+							assert.Same(t, first, ex, c.name)
+						}
+					}
+				}()
+				loader.LoadClass(c.name, false)
+			}()
+		}
+		assert.NotNil(t, loader.FindLoadedClass(c.name), c.name)
+	}
+}
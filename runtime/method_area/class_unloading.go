@@ -0,0 +1,108 @@
+package method_area
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// ============================================================
+// Class Unloading (JLS 12.7)
+// ============================================================
+// class of user-defined loader is unloaded together with its defining loader, when none of them is reachable:
+// no instance, no java.lang.Class mirror, no loader object, no class defined by the loader.
+// they all live in Go heap and are linked with each other:
+//
+//	object → Class ⇄ jClass (mirror)
+//	Class → staticVars, RuntimeConstantPool
+//	Class → loader ⇄ jLoader (java.lang.ClassLoader object)
+//	loader → classes (classes initiated by loader, see SystemDictionary)
+//
+// nothing global of VM refers to this group (constraints refer to loader by id), so Go GC reclaims it at once.
+// loaderUnloading is the only part with a finalizer: it is referenced by the loader only and refers to nothing of the group
+// (Go never collects a cycle which has a finalizer).
+//
+// classes of built-in loaders (bootstrap, platform, app) are never unloaded.
+type loaderUnloading struct {
+	dictionary *SystemDictionary
+	loaderId   uint64
+	loaderName string
+	classNames []string // classes defined by loader
+}
+
+// trackUnloading user-defined loader: report its classes as unloaded when loader is reclaimed
+func (loader *ClassLoader) trackUnloading() {
+	loader.unloading = &loaderUnloading{
+		dictionary: loader.dictionary,
+		loaderId:   loader.id,
+		loaderName: loader.String(),
+	}
+	runtime.SetFinalizer(loader.unloading, (*loaderUnloading).unload)
+}
+
+// onDefine class is defined by this loader
+func (loader *ClassLoader) onDefine(class *Class) {
+	if loader.unloading != nil {
+		loader.unloading.classNames = append(loader.unloading.classNames, class.name)
+	}
+}
+
+// unload runs in finalizer goroutine after loader is reclaimed
+func (u *loaderUnloading) unload() {
+	d := u.dictionary
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.removeLoaderConstraints(u.loaderId)
+	d.unloadedCount += uint64(len(u.classNames))
+	logUnloadedClasses(u.classNames, u.loaderName, d.unloadedCount)
+}
+
+// removeLoaderConstraints unloaded loader no longer takes part in constraints (lock held)
+func (d *SystemDictionary) removeLoaderConstraints(loaderId uint64) {
+	for name, constraints := range d.constraints {
+		kept := constraints[:0]
+		for _, constraint := range constraints {
+			loaders := constraint.loaders[:0]
+			for _, id := range constraint.loaders {
+				if id != loaderId {
+					loaders = append(loaders, id)
+				}
+			}
+			constraint.loaders = loaders
+			// class defined by unloaded loader is not loaded by any other loader any more
+			if constraint.definer == loaderId {
+				constraint.definer, constraint.definerName = 0, ""
+			}
+			if len(loaders) > 1 {
+				kept = append(kept, constraint)
+			}
+		}
+		if len(kept) == 0 {
+			delete(d.constraints, name)
+		} else {
+			d.constraints[name] = kept
+		}
+	}
+}
+
+// UnloadedClassCount number of classes unloaded since VM started
+func (loader *ClassLoader) UnloadedClassCount() uint64 {
+	d := loader.dictionary
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.unloadedCount
+}
+
+// logUnloadedClasses -verbose:class log, ex:
+//
+//	[Unloading class Plugin]
+//	[Unloaded 1 classes of loader PluginLoader, total unloaded: 3]
+func logUnloadedClasses(names []string, loaderName string, total uint64) {
+	if !verboseClass {
+		return
+	}
+	for _, name := range names {
+		fmt.Printf("[Unloading class %s]\n", javaName(name))
+	}
+	fmt.Printf("[Unloaded %d classes of loader %s, total unloaded: %d]\n", len(names), loaderName, total)
+}
@@ -0,0 +1,45 @@
+package method_area
+
+import (
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// loader 不可達後, 它定義的 class 跟著被回收, 約束也不再包含它
+func Test_classUnloading(t *testing.T) {
+	defer SetJavaCaller(javaCaller)
+	app := NewClassLoader(testClassPath)
+	// loadClass(String) of user-defined loader: delegate to app
+	SetJavaCaller(func(method *Method, args ...interface{}) (interface{}, *heap.Object) {
+		name := strings.ReplaceAll(heap.GoString(args[1].(*heap.Object)), ".", "/")
+		return app.LoadClass(name, false).JClass(), nil
+	})
+	data, err := os.ReadFile(filepath.Join(testClassPath, "Greeter.class"))
+	assert.NoError(t, err)
+
+	runJob := func() {
+		loader := app.LoaderOf(app.LoadClass("PluginLoader", false).NewObject())
+		greeter := loader.DefineClass("Greeter", data)
+		assert.Same(t, loader, greeter.Loader())
+		assert.True(t, app.dictionary.addConstraint("Secret", loader, app))
+	}
+	for i := 0; i < 3; i++ {
+		runJob()
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); app.UnloadedClassCount() < 3 && time.Now().Before(deadline); {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, uint64(3), app.UnloadedClassCount())
+
+	app.dictionary.lock.Lock()
+	defer app.dictionary.lock.Unlock()
+	assert.Empty(t, app.dictionary.constraints["Secret"])
+}
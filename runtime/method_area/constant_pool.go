@@ -72,6 +72,18 @@ func newRuntimeConstantPool(class *Class, cfCp classfile.ClassFileConstantPool)
 		case *classfile.ConstantInterfaceMethodRefInfo:
 			methodRefInfo := cpInfo.(*classfile.ConstantInterfaceMethodRefInfo)
 			consts[i] = NewInterfaceMethodRef(rtCp, methodRefInfo)
+		case *classfile.ConstantMethodHandleInfo:
+			methodHandleInfo := cpInfo.(*classfile.ConstantMethodHandleInfo)
+			consts[i] = NewMethodHandleRef(rtCp, methodHandleInfo)
+		case *classfile.ConstantMethodTypeInfo:
+			methodTypeInfo := cpInfo.(*classfile.ConstantMethodTypeInfo)
+			consts[i] = NewMethodTypeRef(methodTypeInfo.Descriptor())
+		case *classfile.ConstantInvokeDynamicInfo:
+			indyInfo := cpInfo.(*classfile.ConstantInvokeDynamicInfo)
+			consts[i] = NewInvokeDynamicRef(rtCp, indyInfo)
+		case *classfile.ConstantDynamicInfo:
+			dynamicInfo := cpInfo.(*classfile.ConstantDynamicInfo)
+			consts[i] = NewDynamicConstantRef(rtCp, dynamicInfo)
 			// Utf8 and NameAndType are not required to put in runtime constant pool, they are used by others.
 		}
 	}
@@ -2,6 +2,7 @@ package method_area
 
 import (
 	"github.com/Johnny1110/gogo_jvm/classfile"
+	"github.com/Johnny1110/gogo_jvm/common"
 )
 
 // FieldRef
@@ -28,8 +29,16 @@ func (r *FieldRef) resolveFieldRef() {
 	class := r.ResolvedClass()
 	field := lookupField(class, r.name, r.descriptor)
 	if field == nil {
-		panic("java.lang.NoSuchFieldError: " + r.name)
+		panic(common.NewJavaException("java/lang/NoSuchFieldError", r.name))
 	}
+	// private field is accessible to nestmates only
+	if field.IsPrivate() {
+		if msg := privateAccessViolation(r.cp.Class(), field.class, "field", field.name); msg != "" {
+			panic(common.NewJavaException("java/lang/IllegalAccessError", msg))
+		}
+	}
+	// field type must be the same class in both loaders
+	checkFieldConstraints(r.cp.Class(), field)
 	r.field = field
 }
 
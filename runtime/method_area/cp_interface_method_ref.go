@@ -2,6 +2,7 @@ package method_area
 
 import (
 	"github.com/Johnny1110/gogo_jvm/classfile"
+	"github.com/Johnny1110/gogo_jvm/common"
 )
 
 // ============================================================
@@ -42,16 +43,27 @@ func (r *InterfaceMethodRef) resolveInterfaceMethodRef() {
 
 	// 2. check is interface
 	if !c.IsInterface() {
-		panic("java.lang.IncompatibleClassChangeError")
+		panic(common.NewJavaException("java/lang/IncompatibleClassChangeError", "class "+c.name+" is not a interface"))
 	}
 
 	// 3. loop up method
 	method := lookupInterfaceMethod(c, r.name, r.descriptor)
 	if method == nil {
-		panic("java.lang.NoSuchMethodError")
+		panic(common.NewJavaException("java/lang/NoSuchMethodError", r.className+"."+r.name+r.descriptor))
+	}
+
+	// 4. private interface method (Java 9+) is accessible to nestmates only
+	if method.IsPrivate() {
+		if msg := privateAccessViolation(r.cp.Class(), method.class, "method", method.name+method.descriptor); msg != "" {
+			panic(common.NewJavaException("java/lang/IllegalAccessError", msg))
+		}
+	}
+
+	// 5. descriptor types must be the same classes in both loaders
+	if msg := methodConstraintViolation(r.cp.Class(), method); msg != "" {
+		panic(common.NewJavaException("java/lang/LinkageError", msg))
 	}
 
-	// TODO: 訪問權限檢查
 	r.method = method
 }
 
@@ -0,0 +1,308 @@
+package method_area
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/classfile"
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+)
+
+// ============================================================
+// invokedynamic support (Java 7+)
+// ============================================================
+// javac compile lambda `() -> System.out.println("hi")` into:
+//
+//	invokedynamic #0:run:()Ljava/lang/Runnable;
+//	  │
+//	  └─> BootstrapMethods[0]
+//	        bsm:  MethodHandle REF_invokeStatic LambdaMetafactory.metafactory
+//	        args: MethodType ()V                          (SAM erased type)
+//	              MethodHandle REF_invokeStatic Main.lambda$main$0:()V  (impl)
+//	              MethodType ()V                          (SAM instantiated type)
+//
+// first execution link the call site: call bootstrap method -> CallSite (cached in InvokeDynamicRef)
+// next execution just invoke CallSite.target
+//
+// we don't have java.lang.invoke (MethodHandles.Lookup / MethodType / CallSite Object) in Java side,
+// so bootstrap methods are implemented in Go (see native/java/lang/invoke) and registered by RegisterBootstrap.
+
+// ============================================================
+// MethodHandleRef
+// ============================================================
+
+// MethodHandleRef CONSTANT_MethodHandle in runtime
+// MemberRef keep the referenced member symbol (owner class, name, descriptor)
+type MethodHandleRef struct {
+	MemberRef
+	referenceKind uint8
+	isInterface   bool    // referenced by InterfaceMethodref
+	method        *Method // resolved (kind 5 ~ 9)
+}
+
+func NewMethodHandleRef(cp *RuntimeConstantPool, refInfo *classfile.ConstantMethodHandleInfo) *MethodHandleRef {
+	ref := &MethodHandleRef{}
+	ref.cp = cp
+	ref.className, ref.name, ref.descriptor = refInfo.MemberRef()
+	ref.referenceKind = refInfo.ReferenceKind()
+	ref.isInterface = refInfo.IsInterfaceRef()
+	return ref
+}
+
+func (r *MethodHandleRef) ReferenceKind() uint8 { return r.referenceKind }
+func (r *MethodHandleRef) IsInterface() bool    { return r.isInterface }
+
+// IsFieldHandle kind 1 ~ 4 (getter / setter handle)
+func (r *MethodHandleRef) IsFieldHandle() bool {
+	return r.referenceKind >= classfile.REF_getField && r.referenceKind <= classfile.REF_putStatic
+}
+
+// ResolvedMethod resolve referenced method (lazy, cached)
+// return (method, java-error)
+func (r *MethodHandleRef) ResolvedMethod() (*Method, *heap.Object) {
+	if r.method != nil {
+		return r.method, nil
+	}
+
+	class := r.ResolvedClass()
+	if r.IsFieldHandle() {
+		return nil, newJavaError(class.Loader(), "java/lang/IncompatibleClassChangeError",
+			fmt.Sprintf("method handle kind %d is not a method: %s.%s", r.referenceKind, r.className, r.name))
+	}
+
+	var method *Method
+	switch {
+	case r.referenceKind == classfile.REF_newInvokeSpecial:
+		// constructor must be declared by class itself
+		for _, m := range class.methods {
+			if m.name == "<init>" && m.descriptor == r.descriptor {
+				method = m
+			}
+		}
+	case class.IsInterface():
+		method = lookupInterfaceMethod(class, r.name, r.descriptor)
+	default:
+		method = lookupMethod(class, r.name, r.descriptor)
+	}
+
+	if method == nil {
+		return nil, newJavaError(class.Loader(), "java/lang/NoSuchMethodError", r.className+"."+r.name+r.descriptor)
+	}
+
+	r.method = method
+	return method, nil
+}
+
+func (r *MethodHandleRef) String() string {
+	return fmt.Sprintf("MethodHandle[kind=%d %s.%s%s]", r.referenceKind, r.className, r.name, r.descriptor)
+}
+
+// ============================================================
+// MethodTypeRef
+// ============================================================
+
+// MethodTypeRef CONSTANT_MethodType in runtime, only a method descriptor
+type MethodTypeRef struct {
+	descriptor string
+}
+
+func NewMethodTypeRef(descriptor string) *MethodTypeRef {
+	return &MethodTypeRef{descriptor: descriptor}
+}
+
+func (r *MethodTypeRef) Descriptor() string { return r.descriptor }
+
+// ParameterTypes ex: (ILjava/lang/String;)V -> ["I", "Ljava/lang/String;"]
+func (r *MethodTypeRef) ParameterTypes() []string {
+	return parseMethodDescriptor(r.descriptor).parameterTypes
+}
+
+// ReturnType ex: (ILjava/lang/String;)V -> "V"
+func (r *MethodTypeRef) ReturnType() string {
+	return parseMethodDescriptor(r.descriptor).returnType
+}
+
+// ============================================================
+// CallSite
+// ============================================================
+
+// CallSite linked invokedynamic call site
+// target is a static method with the same descriptor as invokedynamic,
+// invokedynamic pass its args to target, target's return value is invokedynamic's result.
+type CallSite struct {
+	target *Method
+}
+
+func NewCallSite(target *Method) *CallSite {
+	return &CallSite{target: target}
+}
+
+func (c *CallSite) Target() *Method { return c.target }
+
+// ============================================================
+// Bootstrap Method Registry
+// ============================================================
+
+// BootstrapContext everything a bootstrap method need
+// (Go version of bsm(Lookup caller, String name, MethodType type, Object... staticArgs))
+type BootstrapContext struct {
+	Caller     *Class     // class which contains the invokedynamic
+	Name       string     // invokedynamic name, ex: "run", "makeConcatWithConstants"
+	Descriptor string     // invokedynamic descriptor, ex: "(Ljava/lang/String;)Ljava/util/function/Supplier;"
+	StaticArgs []Constant // resolved runtime constants: *MethodTypeRef, *MethodHandleRef, int32, string ...
+}
+
+// BootstrapMethod Go implementation of a bootstrap method
+type BootstrapMethod func(ctx *BootstrapContext) (*CallSite, *heap.Object)
+
+// bootstrapRegistry key: className~methodName
+// descriptor is not part of key, JDK bootstrap methods are not overloaded
+var bootstrapRegistry = map[string]BootstrapMethod{}
+
+// RegisterBootstrap register bootstrap method implementation
+func RegisterBootstrap(className, methodName string, bsm BootstrapMethod) {
+	bootstrapRegistry[className+"~"+methodName] = bsm
+}
+
+func findBootstrap(className, methodName string) BootstrapMethod {
+	return bootstrapRegistry[className+"~"+methodName]
+}
+
+// bootstrapMethod runtime copy of classfile.BootstrapMethod (constant pool indexes)
+type bootstrapMethod struct {
+	methodRef uint
+	arguments []uint
+}
+
+func newBootstrapMethods(attr *classfile.BootstrapMethodsAttribute) []*bootstrapMethod {
+	if attr == nil {
+		return nil
+	}
+	cfBootstrapMethods := attr.BootstrapMethods()
+	bootstrapMethods := make([]*bootstrapMethod, len(cfBootstrapMethods))
+	for i, cfBm := range cfBootstrapMethods {
+		bm := &bootstrapMethod{methodRef: uint(cfBm.MethodRef())}
+		for _, argIndex := range cfBm.Arguments() {
+			bm.arguments = append(bm.arguments, uint(argIndex))
+		}
+		bootstrapMethods[i] = bm
+	}
+	return bootstrapMethods
+}
+
+// ============================================================
+// InvokeDynamicRef
+// ============================================================
+
+// InvokeDynamicRef CONSTANT_InvokeDynamic in runtime
+type InvokeDynamicRef struct {
+	cp                       *RuntimeConstantPool
+	bootstrapMethodAttrIndex uint
+	name                     string
+	descriptor               string
+	callSite                 *CallSite // linked call site (cached)
+}
+
+func NewInvokeDynamicRef(cp *RuntimeConstantPool, refInfo *classfile.ConstantInvokeDynamicInfo) *InvokeDynamicRef {
+	ref := &InvokeDynamicRef{}
+	ref.cp = cp
+	ref.bootstrapMethodAttrIndex = uint(refInfo.BootstrapMethodAttrIndex())
+	ref.name, ref.descriptor = refInfo.NameAndDescriptor()
+	return ref
+}
+
+func (r *InvokeDynamicRef) Name() string       { return r.name }
+func (r *InvokeDynamicRef) Descriptor() string { return r.descriptor }
+
+// ResolvedCallSite link call site (lazy, only once)
+// return (callSite, java-error), error is BootstrapMethodError or the error thrown by bootstrap method
+func (r *InvokeDynamicRef) ResolvedCallSite() (*CallSite, *heap.Object) {
+	if r.callSite != nil {
+		return r.callSite, nil
+	}
+
+	caller := r.cp.Class()
+	loader := caller.Loader()
+
+	if int(r.bootstrapMethodAttrIndex) >= len(caller.bootstrapMethods) {
+		return nil, newJavaError(loader, "java/lang/BootstrapMethodError",
+			fmt.Sprintf("bootstrap method index %d out of range in %s", r.bootstrapMethodAttrIndex, caller.name))
+	}
+	bm := caller.bootstrapMethods[r.bootstrapMethodAttrIndex]
+
+	// 1. bootstrap method handle
+	bsmRef, ok := r.cp.GetConstant(bm.methodRef).(*MethodHandleRef)
+	if !ok {
+		return nil, newJavaError(loader, "java/lang/BootstrapMethodError", "bootstrap method is not a MethodHandle")
+	}
+
+	// 2. static args
+	staticArgs := make([]Constant, len(bm.arguments))
+	for i, argIndex := range bm.arguments {
+		staticArgs[i] = r.cp.GetConstant(argIndex)
+	}
+
+	// 3. find Go implementation
+	bsm := findBootstrap(bsmRef.ClassName(), bsmRef.Name())
+	if bsm == nil {
+		return nil, newJavaError(loader, "java/lang/BootstrapMethodError",
+			"unsupported bootstrap method: "+bsmRef.ClassName()+"."+bsmRef.Name())
+	}
+
+	// 4. link
+	callSite, err := bsm(&BootstrapContext{
+		Caller:     caller,
+		Name:       r.name,
+		Descriptor: r.descriptor,
+		StaticArgs: staticArgs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. call site type must match invokedynamic descriptor
+	target := callSite.Target()
+	if target == nil || !target.IsStatic() || target.descriptor != r.descriptor {
+		return nil, newJavaError(loader, "java/lang/BootstrapMethodError",
+			"call site type mismatch, expected "+r.descriptor)
+	}
+
+	r.callSite = callSite
+	return callSite, nil
+}
+
+// newJavaError create error object by class name (ex: java/lang/BootstrapMethodError)
+func newJavaError(loader *ClassLoader, className, message string) *heap.Object {
+	return heap.NewExceptionObject(loader.LoadClass(className, false), message)
+}
+
+// ============================================================
+// DynamicConstantRef (Java 11+, CONSTANT_Dynamic / condy)
+// ============================================================
+// value is computed by bootstrap method (ConstantBootstraps, ...) when first `ldc`.
+// those bootstrap methods return arbitrary MethodHandle / Object which we can not execute,
+// so resolving it is reported as UnsupportedClassVersionError instead of a broken value.
+type DynamicConstantRef struct {
+	cp                       *RuntimeConstantPool
+	bootstrapMethodAttrIndex uint
+	name                     string
+	descriptor               string
+}
+
+func NewDynamicConstantRef(cp *RuntimeConstantPool, refInfo *classfile.ConstantDynamicInfo) *DynamicConstantRef {
+	ref := &DynamicConstantRef{}
+	ref.cp = cp
+	ref.bootstrapMethodAttrIndex = uint(refInfo.BootstrapMethodAttrIndex())
+	ref.name, ref.descriptor = refInfo.NameAndDescriptor()
+	return ref
+}
+
+func (r *DynamicConstantRef) Name() string       { return r.name }
+func (r *DynamicConstantRef) Descriptor() string { return r.descriptor }
+
+// UnsupportedError java.lang.UnsupportedClassVersionError for this constant
+func (r *DynamicConstantRef) UnsupportedError() *common.JavaException {
+	class := r.cp.Class()
+	return common.NewJavaException("java/lang/UnsupportedClassVersionError", fmt.Sprintf("%s (class file version %s): "+
+		"dynamically-computed constant (CONSTANT_Dynamic) %s:%s is not supported",
+		class.JavaName(), class.versionString(), r.name, r.descriptor))
+}
@@ -38,26 +38,27 @@ func (r *MethodRef) resolveMethodRef() *heap.Object {
 
 	// 2. check is interface or not.
 	if class.IsInterface() {
-		errClass := class.Loader().LoadClass("java/lang/IncompatibleClassChangeError", false)
-		if errClass == nil {
-			panic("java.lang.IncompatibleClassChangeError")
-		} else {
-			return heap.NewExceptionObject(errClass, fmt.Sprintf("class %s is a interface", class.name))
-		}
-
+		return newJavaError(class.Loader(), "java/lang/IncompatibleClassChangeError", fmt.Sprintf("class %s is a interface", class.name))
 	}
 
 	// 3. find method
 	method := lookupMethod(class, r.name, r.descriptor)
 	if method == nil {
 		fmt.Printf("@@ DEBUG - resolveMethodRef failed, jClass = %s \n", r.class.jClass)
-		errClass := class.Loader().LoadClass("java/lang/NoSuchMethodError", false)
-		if errClass == nil {
-			panic("java.lang.NoSuchMethodError: " + r.className + "." + r.name + r.descriptor)
-		} else {
-			return heap.NewExceptionObject(errClass, fmt.Sprintf("java.lang.NoSuchMethodError: "+r.className+"."+r.name+r.descriptor))
+		return newJavaError(class.Loader(), "java/lang/NoSuchMethodError", r.className+"."+r.name+r.descriptor)
+
+	}
+
+	// 4. private method is accessible to nestmates only
+	if method.IsPrivate() {
+		if msg := privateAccessViolation(r.cp.Class(), method.class, "method", method.name+method.descriptor); msg != "" {
+			return newJavaError(class.Loader(), "java/lang/IllegalAccessError", msg)
 		}
+	}
 
+	// 5. descriptor types must be the same classes in both loaders
+	if msg := methodConstraintViolation(r.cp.Class(), method); msg != "" {
+		return newJavaError(class.Loader(), "java/lang/LinkageError", msg)
 	}
 
 	r.method = method
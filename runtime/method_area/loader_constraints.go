@@ -0,0 +1,206 @@
+package method_area
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+	"strings"
+)
+
+// ============================================================
+// Loader Constraints (JVMS 5.3.4)
+// ============================================================
+// class C (loader L1) uses field / method declared by class D (loader L2), type T in descriptor must be the same class
+// in both loaders: T^L1 = T^L2, otherwise C and D see different layouts of "same" type:
+//
+//	Holder (app):   static Secret secret;      Secret^app: { int value }
+//	Client (L1):    Holder.secret.value        Secret^L1:  { long stamp; int value } → wrong field slot!
+//
+// constraints are imposed when:
+//   - resolving field / method ref whose declaring class is defined by other loader (FieldRef, MethodRef, InterfaceMethodRef)
+//   - preparing class which overrides method of super class / interface defined by other loader (checkOverridingConstraints)
+//
+// imposing constraint does not load T (resolution is still lazy), it only records loaders which must agree on name T.
+// loader loading T later is checked against the constraint (SystemDictionary.define / initiate).
+// constraint refers to loaders by id, it must not keep user-defined loader alive (see class_unloading.go).
+type loaderConstraint struct {
+	loaders     []uint64 // ids of loaders which must agree on name
+	definer     uint64   // defining loader id of class of name, 0: none of loaders has loaded it yet
+	definerName string   // for message
+}
+
+// constraintOf constraint of (name, loader), nil if loader is not constrained on name (lock held)
+func (d *SystemDictionary) constraintOf(name string, loaderId uint64) *loaderConstraint {
+	for _, constraint := range d.constraints[name] {
+		for _, id := range constraint.loaders {
+			if id == loaderId {
+				return constraint
+			}
+		}
+	}
+	return nil
+}
+
+// checkConstraint loader is going to record class: class must be the one other constrained loaders see
+func (d *SystemDictionary) checkConstraint(loader *ClassLoader, class *Class) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	constraint := d.constraintOf(class.name, loader.id)
+	if constraint == nil {
+		return
+	}
+	if constraint.definer != 0 && constraint.definer != class.loader.id {
+		panic(common.NewJavaException("java/lang/LinkageError", fmt.Sprintf(
+			"loader constraint violation: loader %s wants to load class %s. A different class with the same name was previously loaded by %s.",
+			loader, class.JavaName(), constraint.definerName)))
+	}
+	constraint.definer, constraint.definerName = class.loader.id, class.loader.String()
+}
+
+// addConstraint impose T^l1 = T^l2, return false if l1 and l2 already have different classes of name
+func (d *SystemDictionary) addConstraint(name string, l1, l2 *ClassLoader) bool {
+	if l1 == l2 {
+		return true
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	c1, c2 := d.constraintOf(name, l1.id), d.constraintOf(name, l2.id)
+	definer1, name1 := d.constrainedDefiner(name, l1, c1)
+	definer2, name2 := d.constrainedDefiner(name, l2, c2)
+	if definer1 != 0 && definer2 != 0 && definer1 != definer2 {
+		return false
+	}
+	definer, definerName := definer1, name1
+	if definer == 0 {
+		definer, definerName = definer2, name2
+	}
+
+	constraint := c1
+	switch {
+	case c1 == nil && c2 == nil:
+		constraint = &loaderConstraint{loaders: []uint64{l1.id, l2.id}}
+		d.constraints[name] = append(d.constraints[name], constraint)
+	case c1 == nil:
+		constraint = c2
+		constraint.loaders = append(constraint.loaders, l1.id)
+	case c2 == nil:
+		constraint.loaders = append(constraint.loaders, l2.id)
+	case c1 != c2:
+		// merge c2 into c1
+		constraint.loaders = append(constraint.loaders, c2.loaders...)
+		d.removeConstraint(name, c2)
+	}
+	constraint.definer, constraint.definerName = definer, definerName
+	return true
+}
+
+// constrainedDefiner defining loader of name seen by loader: loaded by it, or required by its constraint
+func (d *SystemDictionary) constrainedDefiner(name string, loader *ClassLoader, constraint *loaderConstraint) (uint64, string) {
+	if class := d.find(loader, name); class != nil {
+		return class.loader.id, class.loader.String()
+	}
+	if constraint != nil {
+		return constraint.definer, constraint.definerName
+	}
+	return 0, ""
+}
+
+func (d *SystemDictionary) removeConstraint(name string, constraint *loaderConstraint) {
+	constraints := d.constraints[name]
+	for i, c := range constraints {
+		if c == constraint {
+			d.constraints[name] = append(constraints[:i], constraints[i+1:]...)
+			return
+		}
+	}
+}
+
+// addDescriptorConstraints impose constraints on every reference type of field / method descriptor
+// return violated type (internal name), "" if ok
+func (d *SystemDictionary) addDescriptorConstraints(descriptor string, l1, l2 *ClassLoader) string {
+	if l1 == l2 {
+		return ""
+	}
+	for _, name := range referenceTypeNames(descriptor) {
+		if !d.addConstraint(name, l1, l2) {
+			return name
+		}
+	}
+	return ""
+}
+
+// referenceTypeNames class names in descriptor, array is constrained by its element class
+// ex: (I[Ljava/lang/String;LFoo;)LBar; → [java/lang/String Foo Bar]
+func referenceTypeNames(descriptor string) []string {
+	var names []string
+	for i := 0; i < len(descriptor); i++ {
+		if descriptor[i] == 'L' {
+			end := strings.IndexByte(descriptor[i:], ';') + i
+			names = append(names, descriptor[i+1:end])
+			i = end
+		}
+	}
+	return names
+}
+
+// ============================================================
+// constraint checks of resolution & preparation
+// ============================================================
+
+// checkFieldConstraints class `current` resolving field declared by other loader's class (JVMS 5.4.3.2)
+func checkFieldConstraints(current *Class, field *Field) {
+	l1, l2 := current.loader, field.class.loader
+	if violated := l1.dictionary.addDescriptorConstraints(field.descriptor, l1, l2); violated != "" {
+		panic(common.NewJavaException("java/lang/LinkageError", fmt.Sprintf(
+			"loader constraint violation: when resolving field \"%s\" of type %s, the class loader %s of the current class, %s, and the class loader %s for the field's defining class, %s, have different Class objects for type %s",
+			field.name, javaName(violated), l1, current.JavaName(), l2, field.class.JavaName(), javaName(violated))))
+	}
+}
+
+// methodConstraintViolation class `current` resolving method declared by other loader's class (JVMS 5.4.3.3, 5.4.3.4)
+// return LinkageError message, "" if ok
+func methodConstraintViolation(current *Class, method *Method) string {
+	l1, l2 := current.loader, method.class.loader
+	violated := l1.dictionary.addDescriptorConstraints(method.descriptor, l1, l2)
+	if violated == "" {
+		return ""
+	}
+	return fmt.Sprintf(
+		"loader constraint violation: when resolving method '%s.%s%s' the class loader %s of the current class, %s, and the class loader %s for the method's defining class, %s, have different Class objects for the type %s used in the signature",
+		method.class.JavaName(), method.name, method.descriptor, l1, current.JavaName(), l2, method.class.JavaName(), javaName(violated))
+}
+
+// checkOverridingConstraints method of class overrides method declared by other loader's super class / interface (JVMS 5.4.2)
+func checkOverridingConstraints(class *Class) {
+	for _, method := range class.methods {
+		if method.IsStatic() || method.IsPrivate() || method.name == "<init>" || method.name == "<clinit>" {
+			continue
+		}
+		var overridden []*Method
+		if class.superClass != nil {
+			if m := lookupMethod(class.superClass, method.name, method.descriptor); m != nil && !m.IsStatic() && !m.IsPrivate() {
+				overridden = append(overridden, m)
+			}
+		}
+		for _, iface := range class.interfaces {
+			if m := lookupInterfaceMethod(iface, method.name, method.descriptor); m != nil && !m.IsStatic() {
+				overridden = append(overridden, m)
+			}
+		}
+
+		for _, m := range overridden {
+			l1, l2 := class.loader, m.class.loader
+			if violated := l1.dictionary.addDescriptorConstraints(method.descriptor, l1, l2); violated != "" {
+				panic(common.NewJavaException("java/lang/LinkageError", fmt.Sprintf(
+					"loader constraint violation for class %s: when selecting overriding method '%s%s' the class loader %s of the selected method's type %s, and the class loader %s for its super type %s have different Class objects for the type %s used in the signature",
+					class.JavaName(), method.name, method.descriptor, l1, class.JavaName(), l2, m.class.JavaName(), javaName(violated))))
+			}
+		}
+	}
+}
+
+// javaName internal name → binary name: java/lang/String → java.lang.String
+func javaName(name string) string {
+	return strings.ReplaceAll(name, "/", ".")
+}
@@ -0,0 +1,44 @@
+package method_area
+
+import (
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_referenceTypeNames(t *testing.T) {
+	assert.Equal(t, []string{"java/lang/String", "Foo", "Bar"}, referenceTypeNames("(I[Ljava/lang/String;LFoo;)LBar;"))
+	assert.Equal(t, []string{"LFoo"}, referenceTypeNames("[[LLFoo;"))
+	assert.Nil(t, referenceTypeNames("(IJ[D)V"))
+}
+
+// 已載入不同 class: 無法加上約束; 約束先成立: 之後載入不同 class 失敗
+func Test_loaderConstraint(t *testing.T) {
+	app := NewClassLoader(testClassPath)
+	platform := app.PlatformLoader()
+	dictionary := app.dictionary
+	data, err := os.ReadFile(filepath.Join(testClassPath, "Secret.class"))
+	assert.NoError(t, err)
+
+	// Object is the same class in every loader
+	app.LoadClass("java/lang/Object", false)
+	platform.LoadClass("java/lang/Object", false)
+	assert.Equal(t, "", dictionary.addDescriptorConstraints("(Ljava/lang/Object;)V", app, platform))
+
+	// constraint first, Secret is not loaded by constraining it
+	assert.True(t, dictionary.addConstraint("Secret", app, platform))
+	assert.Nil(t, app.FindLoadedClass("Secret"))
+	secret := app.LoadClass("Secret", false)
+	assert.Equal(t, secret.loader.id, dictionary.constraintOf("Secret", platform.id).definer)
+
+	defer func() {
+		err := recover().(*common.JavaException)
+		assert.Equal(t, "java/lang/LinkageError", err.ClassName)
+		assert.Equal(t, "loader constraint violation: loader 'platform' wants to load class Secret. "+
+			"A different class with the same name was previously loaded by 'app'.", err.Message)
+		assert.Nil(t, platform.FindLoadedClass("Secret"))
+	}()
+	platform.DefineClass("Secret", data)
+}
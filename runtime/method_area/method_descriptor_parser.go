@@ -13,6 +13,11 @@ type MethodDescriptor struct {
 	returnType     string
 }
 
+// ParseMethodDescriptor exported for native package (ex: LambdaMetafactory)
+func ParseMethodDescriptor(descriptor string) MethodDescriptor {
+	return parseMethodDescriptor(descriptor)
+}
+
 func parseMethodDescriptor(descriptor string) MethodDescriptor {
 	md := MethodDescriptor{}
 
@@ -7,6 +7,7 @@ import (
 	"github.com/Johnny1110/gogo_jvm/runtime/heap"
 	"github.com/Johnny1110/gogo_jvm/runtime/rtcore"
 	"strings"
+	"sync"
 )
 
 // Class instant in runtime method area
@@ -31,20 +32,25 @@ type Class struct {
 	constantPool      *RuntimeConstantPool // ConstantPool Runtime
 	fields            []*Field
 	methods           []*Method
-	loader            *ClassLoader // 加載此類的 ClassLoader
-	superClass        *Class       // parent class ref
-	interfaces        []*Class     // interface refs
-	instanceSlotCount uint         // 實例變量佔用的 slot 數量
-	staticSlotCount   uint         // 類變量佔用的 slot 數量
-	staticVars        rtcore.Slots // class's static vars
-
-	// initStarted mark is this class already starting init, make sure <clinit> only do once
-	// usage:
+	loader            *ClassLoader          // 加載此類的 ClassLoader
+	superClass        *Class                // parent class ref
+	interfaces        []*Class              // interface refs
+	instanceSlotCount uint                  // 實例變量佔用的 slot 數量
+	staticSlotCount   uint                  // 類變量佔用的 slot 數量
+	staticVars        rtcore.Slots          // class's static vars
+	linkError         *common.JavaException // not nil: linking failed (VerifyError, LinkageError...), class is erroneous
+
+	// class initialization (JVMS 5.5), see class_init.go
+	// triggered by:
 	// - `new` create Object
 	// - getstatic/putstatic access static fields
 	// - invokestatic call static method
 	// - 子類初始化時（父類需先初始化）
-	initStarted bool
+	initState  ClassInitState
+	initThread interface{} // thread doing init (*runtime.Thread), valid when initState = CLASS_BEING_INITIALIZED
+	initWaiter *Class      // class waiting for this class's init to continue its own init (JVMS 5.5 step 7)
+	initLock   sync.Mutex  // LC: initialization lock
+	initCond   *sync.Cond
 
 	// v0.3.1: JClass - Reflection Support (Mirror Design Pattern)
 	// jClass pointing to Java's java.lang.Class Object, allow user to using obj.getClass()
@@ -56,6 +62,25 @@ type Class struct {
 	// ex - 1: int[] componentClass is int Class
 	// ex - 2: String[][] componentClass is String[] Class
 	componentClass *Class
+
+	// bootstrapMethods copy from BootstrapMethods attribute, used by InvokeDynamicRef
+	bootstrapMethods []*bootstrapMethod
+
+	// sourceFile from SourceFile attribute (ex: Main.java), empty if compiled without -g:source
+	sourceFile string
+
+	// class file version (ex: 52.0 = Java 8, 65.0 = Java 21), features are gated by it
+	majorVersion uint16
+	minorVersion uint16
+	// Java 11+ nestmates: nestHostName from NestHost, nestMemberNames from NestMembers
+	// nestHost is resolved lazily by NestHost()
+	nestHostName    string
+	nestMemberNames []string
+	nestHost        *Class
+	// Java 16+ records: components from Record attribute (nil if not a record)
+	recordComponents []*RecordComponent
+	// Java 17+ sealed: permittedSubclassNames from PermittedSubclasses (nil if not sealed)
+	permittedSubclassNames []string
 }
 
 // newClass create Class from classfile.ClassFile
@@ -68,6 +93,24 @@ func newClass(cf *classfile.ClassFile) *Class {
 	c.constantPool = newRuntimeConstantPool(c, cf.ConstantPool())
 	c.fields = newFields(c, cf.Fields())
 	c.methods = newMethods(c, cf.Methods())
+	c.bootstrapMethods = newBootstrapMethods(cf.BootstrapMethodsAttribute())
+	c.majorVersion = cf.MajorVersion()
+	c.minorVersion = cf.MinorVersion()
+	if attr := cf.SourceFileAttribute(); attr != nil {
+		c.sourceFile = attr.FileName()
+	}
+	if attr := cf.NestHostAttribute(); attr != nil {
+		c.nestHostName = attr.HostClassName()
+	}
+	if attr := cf.NestMembersAttribute(); attr != nil {
+		c.nestMemberNames = attr.ClassNames()
+	}
+	if attr := cf.RecordAttribute(); attr != nil {
+		c.recordComponents = newRecordComponents(attr)
+	}
+	if attr := cf.PermittedSubclassesAttribute(); attr != nil {
+		c.permittedSubclassNames = attr.ClassNames()
+	}
 	return c
 }
 
@@ -91,17 +134,6 @@ func (c *Class) NewObject() *heap.Object {
 
 // =============== Class Initialization ===============
 
-// InitStarted check init is start or not
-func (c *Class) InitStarted() bool {
-	return c.initStarted
-}
-
-// StartInit mark init
-// should be called before <clinit>
-func (c *Class) StartInit() {
-	c.initStarted = true
-}
-
 // GetClinitMethod get class init method <clinit>
 // return nil if  <clinit> not found
 // <clinit> will be generated by compiler
@@ -125,6 +157,7 @@ func (c *Class) SuperClass() *Class                 { return c.superClass }
 func (c *Class) StaticVars() rtcore.Slots           { return c.staticVars }
 func (c *Class) AccessFlags() uint16                { return c.accessFlags }
 func (c *Class) InstanceSlotCount() uint            { return c.instanceSlotCount }
+func (c *Class) SourceFile() string                 { return c.sourceFile }
 func (c *Class) Interfaces() []*Class {
 	return c.interfaces
 }
@@ -488,7 +521,7 @@ func (c *Class) String() string {
 	// Runtime status
 	sb.WriteString(fmt.Sprintf("  Constant Pool: %v ", c.constantPool != nil))
 	sb.WriteString(fmt.Sprintf("  Loader: %v ", c.loader != nil))
-	sb.WriteString(fmt.Sprintf("  Init Started: %v ", c.initStarted))
+	sb.WriteString(fmt.Sprintf("  Init State: %v ", c.initState))
 
 	sb.WriteString("}")
 
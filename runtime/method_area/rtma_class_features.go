@@ -0,0 +1,120 @@
+package method_area
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/classfile"
+	"github.com/Johnny1110/gogo_jvm/common"
+)
+
+// ============================================================
+// Class File Version Features (Java 11 ~ 21)
+// ============================================================
+// classfile package only parse attributes allowed by class version,
+// here we keep the runtime part:
+//   - nestmates (Java 11): NestHost / NestMembers
+//   - records   (Java 16): Record
+//   - sealed    (Java 17): PermittedSubclasses
+
+// RecordComponent record Point(int x, int y) -> [x:I, y:I]
+type RecordComponent struct {
+	name       string
+	descriptor string
+}
+
+func newRecordComponents(attr *classfile.RecordAttribute) []*RecordComponent {
+	components := make([]*RecordComponent, 0, len(attr.Components()))
+	for _, info := range attr.Components() {
+		components = append(components, &RecordComponent{name: info.Name(), descriptor: info.Descriptor()})
+	}
+	return components
+}
+
+func (rc *RecordComponent) Name() string       { return rc.name }
+func (rc *RecordComponent) Descriptor() string { return rc.descriptor }
+
+// =============== Version ===============
+
+func (c *Class) MajorVersion() uint16 { return c.majorVersion }
+func (c *Class) MinorVersion() uint16 { return c.minorVersion }
+
+// IsPreview class compiled with --enable-preview
+func (c *Class) IsPreview() bool {
+	return c.majorVersion >= classfile.FEATURE_PREVIEW && c.minorVersion == classfile.PREVIEW_MINOR_VERSION
+}
+
+// versionString ex: "65.0"
+func (c *Class) versionString() string {
+	return fmt.Sprintf("%d.%d", c.majorVersion, c.minorVersion)
+}
+
+// =============== Records ===============
+
+// IsRecord class has Record attribute (and extends java.lang.Record)
+func (c *Class) IsRecord() bool                       { return c.recordComponents != nil }
+func (c *Class) RecordComponents() []*RecordComponent { return c.recordComponents }
+
+// =============== Sealed ===============
+
+func (c *Class) IsSealed() bool                   { return c.permittedSubclassNames != nil }
+func (c *Class) PermittedSubclassNames() []string { return c.permittedSubclassNames }
+
+// checkPermittedSubclass JVMS 5.3.5: if super is sealed, sub must be listed in its PermittedSubclasses
+func checkPermittedSubclass(super, sub *Class) {
+	if super == nil || !super.IsSealed() {
+		return
+	}
+	for _, name := range super.permittedSubclassNames {
+		if name == sub.name {
+			return
+		}
+	}
+	kind := "class"
+	if super.IsInterface() {
+		kind = "interface"
+	}
+	panic(common.NewJavaException("java/lang/IncompatibleClassChangeError", fmt.Sprintf("class %s cannot inherit from sealed %s %s",
+		sub.JavaName(), kind, super.JavaName())))
+}
+
+// =============== Nestmates ===============
+
+// NestHost JVMS 5.4.4 (Java 15+ behavior):
+// host must list this class in its NestMembers, otherwise (invalid nest) class is its own host.
+func (c *Class) NestHost() *Class {
+	if c.nestHost != nil {
+		return c.nestHost
+	}
+
+	c.nestHost = c
+	if c.nestHostName != "" && c.nestHostName != c.name && c.loader != nil {
+		host := c.loader.LoadClass(c.nestHostName, false)
+		if host.hasNestMember(c.name) {
+			c.nestHost = host
+		}
+	}
+	return c.nestHost
+}
+
+func (c *Class) hasNestMember(name string) bool {
+	for _, member := range c.nestMemberNames {
+		if member == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNestmateOf same nest host -> can access each other's private members
+func (c *Class) IsNestmateOf(other *Class) bool {
+	return c == other || c.NestHost() == other.NestHost()
+}
+
+// privateAccessViolation JVMS 5.4.4: private member is accessible only to classes of its nest,
+// class without NestHost / NestMembers (before Java 11) is a nest by itself, javac generates access$000 bridge instead.
+// return IllegalAccessError message, "" if ok
+func privateAccessViolation(current, declaring *Class, kind, member string) string {
+	if current == nil || current.IsNestmateOf(declaring) {
+		return ""
+	}
+	return fmt.Sprintf("class %s tried to access private %s %s.%s", current.JavaName(), kind, declaring.JavaName(), member)
+}
@@ -0,0 +1,73 @@
+package method_area
+
+import (
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// javac --release 21 TestModernClassFile.java: nestmates, record, sealed
+func Test_modernClassFile(t *testing.T) {
+	loader := NewClassLoader(testClassPath)
+	outer := loader.LoadClass("TestModernClassFile", false)
+	assert.Equal(t, uint16(65), outer.MajorVersion())
+
+	point := loader.LoadClass("TestModernClassFile$Point", false)
+	assert.True(t, point.IsRecord())
+	if assert.Len(t, point.RecordComponents(), 2) {
+		assert.Equal(t, "x", point.RecordComponents()[0].Name())
+		assert.Equal(t, "I", point.RecordComponents()[1].Descriptor())
+	}
+
+	shape := loader.LoadClass("TestModernClassFile$Shape", false)
+	assert.True(t, shape.IsSealed())
+	assert.Equal(t, []string{"TestModernClassFile$Circle", "TestModernClassFile$Square"}, shape.PermittedSubclassNames())
+}
+
+// private 成員只有同一個 nest 的 class 可以存取 (JVMS 5.4.4), 否則 IllegalAccessError
+func Test_nestmates_privateAccess(t *testing.T) {
+	loader := NewClassLoader(testClassPath)
+	outer := loader.LoadClass("TestModernClassFile", false)
+	inner := loader.LoadClass("TestModernClassFile$Inner", false)
+	other := loader.LoadClass("HelloWorld", false) // Java 8 class, 自己就是 nest host
+
+	assert.Same(t, outer, inner.NestHost())
+	assert.Same(t, other, other.NestHost())
+	assert.True(t, inner.IsNestmateOf(outer))
+	assert.False(t, other.IsNestmateOf(outer))
+
+	// private field: outer.secret
+	fieldRef := func(current *Class) *FieldRef {
+		ref := &FieldRef{}
+		ref.cp = current.constantPool
+		ref.className, ref.name, ref.descriptor = outer.name, "secret", "I"
+		return ref
+	}
+	assert.NotNil(t, fieldRef(inner).ResolvedField())
+	func() {
+		defer func() {
+			ex, ok := recover().(*common.JavaException)
+			if assert.True(t, ok) {
+				assert.Equal(t, "java/lang/IllegalAccessError", ex.ClassName)
+				assert.Equal(t, "class HelloWorld tried to access private field TestModernClassFile.secret", ex.Message)
+			}
+		}()
+		fieldRef(other).ResolvedField()
+	}()
+
+	// private method: outer.twice(I)I
+	methodRef := func(current *Class) *MethodRef {
+		ref := &MethodRef{}
+		ref.cp = current.constantPool
+		ref.className, ref.name, ref.descriptor = outer.name, "twice", "(I)I"
+		return ref
+	}
+	method, err := methodRef(inner).ResolvedMethod()
+	assert.Nil(t, err)
+	assert.Equal(t, "twice", method.Name())
+	method, err = methodRef(other).ResolvedMethod()
+	assert.Nil(t, method)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "java/lang/IllegalAccessError", err.Class().(*Class).Name())
+	}
+}
@@ -24,7 +24,9 @@ type Method struct {
 	maxLocals      uint16
 	code           []byte
 	argSlotCount   uint
-	exceptionTable ExceptionTable // v0.2.10
+	exceptionTable ExceptionTable                    // v0.2.10
+	stackMapTable  *classfile.StackMapTableAttribute // for verifier, nil if absent
+	lineNumbers    []*classfile.LineNumberTableEntry // for stack trace, nil if compiled without -g:lines
 }
 
 // newMethods create from classfile
@@ -55,6 +57,10 @@ func (m *Method) copyAttributes(cfMethod *classfile.MemberInfo) {
 		m.code = codeAttr.Code()
 		// v0.2.10: parse exception table:
 		m.exceptionTable = newExceptionTable(codeAttr.ExceptionTable(), m.Class().ConstantPool())
+		m.stackMapTable = codeAttr.StackMapTableAttribute()
+		if lntAttr := codeAttr.LineNumberTableAttribute(); lntAttr != nil {
+			m.lineNumbers = lntAttr.LineNumberTable()
+		}
 	}
 }
 
@@ -90,6 +96,23 @@ func (m *Method) MaxLocals() uint16   { return m.maxLocals }
 func (m *Method) Code() []byte        { return m.code }
 func (m *Method) ArgSlotCount() uint  { return m.argSlotCount }
 func (m *Method) AccessFlags() uint16 { return m.accessFlags }
+
+// GetLineNumber source line of instruction at pc (same as StackTraceElement.lineNumber)
+// -2: native method, -1: unknown (no LineNumberTable)
+func (m *Method) GetLineNumber(pc int) int {
+	if m.IsNative() {
+		return -2
+	}
+	// entries are not required to be sorted, take the closest start pc before pc
+	line, start := -1, -1
+	for _, entry := range m.lineNumbers {
+		if int(entry.StartPc()) <= pc && int(entry.StartPc()) > start {
+			line, start = int(entry.LineNumber()), int(entry.StartPc())
+		}
+	}
+	return line
+}
+
 func (m *Method) ExceptionTable() ExceptionTable {
 	return m.exceptionTable
 }
@@ -0,0 +1,138 @@
+package method_area
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+)
+
+// ============================================================
+// Synthetic Class (Java 7+: lambda proxy class, hidden class ...)
+// ============================================================
+// class spin at runtime, not from .class file.
+// HotSpot use ASM (InnerClassLambdaMetafactory) generate bytes then define it,
+// we skip the classfile format and build runtime Class directly:
+//
+//	builder := NewSyntheticClassBuilder(loader, "Main$$Lambda$1", "java/lang/Object", []string{"Runnable"})
+//	fIdx := builder.AddFieldRef(builder.AddField(ACC_PRIVATE|ACC_FINAL, "arg$1", "I"))
+//	builder.AddMethod(ACC_PUBLIC, "run", "()V", maxStack, maxLocals, code)
+//	class := builder.Define()
+//
+// constant pool index returned by AddXXX can be used as operand in code.
+
+type SyntheticClassBuilder struct {
+	class *Class
+}
+
+func NewSyntheticClassBuilder(loader *ClassLoader, name, superClassName string, interfaceNames []string) *SyntheticClassBuilder {
+	class := &Class{
+		accessFlags:    common.ACC_FINAL | common.ACC_SYNTHETIC,
+		name:           name,
+		superClassName: superClassName,
+		interfaceNames: interfaceNames,
+		loader:         loader,
+	}
+	// index 0 is not used (same as classfile constant pool)
+	class.constantPool = &RuntimeConstantPool{class: class, consts: []Constant{nil}}
+	return &SyntheticClassBuilder{class: class}
+}
+
+func (b *SyntheticClassBuilder) Class() *Class { return b.class }
+
+// AddField add field into class, return the field
+func (b *SyntheticClassBuilder) AddField(accessFlags uint16, name, descriptor string) *Field {
+	field := &Field{
+		accessFlags: accessFlags,
+		name:        name,
+		descriptor:  descriptor,
+		class:       b.class,
+	}
+	b.class.fields = append(b.class.fields, field)
+	return field
+}
+
+// AddMethod add method into class, return the method
+func (b *SyntheticClassBuilder) AddMethod(accessFlags uint16, name, descriptor string, maxStack, maxLocals uint16, code []byte) *Method {
+	method := &Method{
+		accessFlags: accessFlags,
+		name:        name,
+		descriptor:  descriptor,
+		class:       b.class,
+		maxStack:    maxStack,
+		maxLocals:   maxLocals,
+		code:        code,
+	}
+	method.calcArgSlotCount()
+	b.class.methods = append(b.class.methods, method)
+	return method
+}
+
+// addConstant append constant into cp, return index
+func (b *SyntheticClassBuilder) addConstant(c Constant) uint16 {
+	cp := b.class.constantPool
+	cp.consts = append(cp.consts, c)
+	if len(cp.consts) > 0xFFFF {
+		panic(fmt.Sprintf("synthetic class %s: too many constants", b.class.name))
+	}
+	return uint16(len(cp.consts) - 1)
+}
+
+// AddClassRef add ClassRef (for new / checkcast / anewarray), return cp index
+func (b *SyntheticClassBuilder) AddClassRef(className string) uint16 {
+	return b.addConstant(NewClassRef(b.class.constantPool, className))
+}
+
+// AddFieldRef add FieldRef of this class's own field (resolved), return cp index
+func (b *SyntheticClassBuilder) AddFieldRef(field *Field) uint16 {
+	ref := &FieldRef{field: field}
+	ref.cp = b.class.constantPool
+	ref.className, ref.name, ref.descriptor = b.class.name, field.name, field.descriptor
+	ref.class = b.class
+	return b.addConstant(ref)
+}
+
+// AddMethodRef add MethodRef (for invokevirtual / invokespecial / invokestatic), return cp index
+// method != nil: ref is already resolved (ex: lambda impl method, which may be private or declared in interface)
+// method == nil: ref is resolved lazily by className, name and descriptor (ex: Integer.valueOf)
+func (b *SyntheticClassBuilder) AddMethodRef(className, name, descriptor string, method *Method) uint16 {
+	ref := &MethodRef{method: method}
+	ref.cp = b.class.constantPool
+	ref.className, ref.name, ref.descriptor = className, name, descriptor
+	if method != nil {
+		ref.class = method.class
+	}
+	return b.addConstant(ref)
+}
+
+// AddInterfaceMethodRef add InterfaceMethodRef (for invokeinterface), return cp index
+func (b *SyntheticClassBuilder) AddInterfaceMethodRef(className, name, descriptor string, method *Method) uint16 {
+	ref := &InterfaceMethodRef{method: method}
+	ref.cp = b.class.constantPool
+	ref.className, ref.name, ref.descriptor = className, name, descriptor
+	if method != nil {
+		ref.class = method.class
+	}
+	return b.addConstant(ref)
+}
+
+// Define load super & interfaces, link and store class into ClassLoader
+// synthetic class has no <clinit>, mark init started directly
+func (b *SyntheticClassBuilder) Define() *Class {
+	class := b.class
+	loader := class.loader
+	if loader.FindLoadedClass(class.name) != nil {
+		panic(duplicateClassDefinition(loader, class.name))
+	}
+
+	loader.resolveSuperClass(class)
+	loader.resolveInterfaces(class)
+	loader.dictionary.define(class)
+
+	link(class)
+	class.initState = CLASS_INITIALIZED
+	if loader.jlClassClass != nil {
+		class.jClass = loader.createJClassObject(class)
+	}
+
+	fmt.Printf("@@ Debug - [ClassLoader] Defined (synthetic): %s\n", class.name)
+	return class
+}
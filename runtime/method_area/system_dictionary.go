@@ -0,0 +1,89 @@
+package method_area
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+	"strings"
+	"sync"
+)
+
+// ============================================================
+// System Dictionary - loaded classes of all class loaders (Method Area)
+// ============================================================
+// runtime class is identified by (defining loader, name), same .class defined by 2 loaders are 2 different classes.
+// JVMS 5.3: loader L is an initiating loader of class C if L creates C, by defining it directly or by delegation.
+// dictionary records every (initiating loader, name) → class, defining loader is always one of initiating loaders:
+//
+//	(app, "Plugin")       → Plugin (defined by app)
+//	(app, "java/lang/Object")       → Object (defined by bootstrap, initiated by app)
+//	(bootstrap, "java/lang/Object") → Object
+//
+// entries of a loader are kept in the loader itself (ClassLoader.classes), so classes of user-defined loader
+// are reclaimed with the loader (see class_unloading.go).
+// 1 dictionary is shared by all loaders of VM, built-in loaders are kept here as well (never unloaded).
+type SystemDictionary struct {
+	// class name → loader constraints on it (see loader_constraints.go)
+	constraints map[string][]*loaderConstraint
+	lock        sync.Mutex // constraints & unloading stats, loader may be unloaded by GC goroutine
+
+	bootstrap *ClassLoader
+	platform  *ClassLoader
+	app       *ClassLoader // system class loader
+
+	nextLoaderId  uint64
+	unloadedCount uint64
+}
+
+func newSystemDictionary() *SystemDictionary {
+	return &SystemDictionary{constraints: make(map[string][]*loaderConstraint)}
+}
+
+// newLoaderId id of new loader, loader constraints refer to loader by id (see loaderConstraint)
+func (d *SystemDictionary) newLoaderId() uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.nextLoaderId++
+	return d.nextLoaderId
+}
+
+// find class initiated by loader, nil if loader never loaded it
+func (d *SystemDictionary) find(loader *ClassLoader, name string) *Class {
+	return loader.classes[name]
+}
+
+// define record class by its defining loader (class.loader)
+// a loader can define a name only once: LinkageError (duplicate class definition)
+func (d *SystemDictionary) define(class *Class) {
+	if d.find(class.loader, class.name) != nil {
+		panic(duplicateClassDefinition(class.loader, class.name))
+	}
+	d.checkConstraint(class.loader, class)
+	class.loader.classes[class.name] = class
+	class.loader.onDefine(class)
+}
+
+// initiate record loader as an initiating loader of class
+func (d *SystemDictionary) initiate(loader *ClassLoader, class *Class) {
+	if d.find(loader, class.name) == class {
+		return
+	}
+	d.checkConstraint(loader, class)
+	loader.classes[class.name] = class
+}
+
+// definedClasses classes defined by loader
+func (d *SystemDictionary) definedClasses(loader *ClassLoader) []*Class {
+	var classes []*Class
+	for _, class := range loader.classes {
+		if class.loader == loader {
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
+
+// duplicateClassDefinition LinkageError: loader 'app' attempted duplicate class definition for Foo.
+func duplicateClassDefinition(loader *ClassLoader, name string) *common.JavaException {
+	return common.NewJavaException("java/lang/LinkageError",
+		fmt.Sprintf("loader %s attempted duplicate class definition for %s.", loader, strings.ReplaceAll(name, "/", ".")))
+}
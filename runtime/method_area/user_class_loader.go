@@ -0,0 +1,164 @@
+package method_area
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/Johnny1110/gogo_jvm/runtime/heap"
+	"strings"
+)
+
+// ============================================================
+// User-defined Class Loader (java.lang.ClassLoader subclass)
+// ============================================================
+// user-defined loader is a java object, VM creates its runtime ClassLoader when meeting the object at the first time,
+// they are linked by each other: ClassLoader.jLoader <-> jLoader.extra
+//
+//   - java → VM: ClassLoader natives (defineClass1, findLoadedClass0 ...), see native/java/lang/class_loader.go
+//   - VM → java: resolving symbolic reference of class defined by user-defined loader calls loader.loadClass(String)
+//
+// built-in loaders (platform, app) have java objects as well (BuiltinClassLoader), their loadClass is native.
+
+// BUILTIN_LOADER_CLASS java class of built-in loader objects (ClassLoader.getSystemClassLoader())
+const BUILTIN_LOADER_CLASS = "jdk/internal/loader/BuiltinClassLoader"
+
+// JavaCaller run java method from Go code to completion (VM upcall), set by interpreter
+// args: references (this first), return method's return value or exception thrown by it
+type JavaCaller func(method *Method, args ...interface{}) (interface{}, *heap.Object)
+
+var javaCaller JavaCaller
+
+func SetJavaCaller(caller JavaCaller) {
+	javaCaller = caller
+}
+
+// CallJava run java method from native code to completion (ex: overridden toString() of string concat argument)
+func CallJava(method *Method, args ...interface{}) (interface{}, *heap.Object) {
+	return javaCaller(method, args...)
+}
+
+// String loader name for messages: 'app', 'platform', bootstrap or java class name of user-defined loader
+func (loader *ClassLoader) String() string {
+	if loader.builtin && loader.parent != nil {
+		return "'" + loader.name + "'"
+	}
+	return loader.name
+}
+
+// Name loader name, see ClassLoader.name
+func (loader *ClassLoader) Name() string {
+	return loader.name
+}
+
+// IsBootstrap is bootstrap class loader (null in java)
+func (loader *ClassLoader) IsBootstrap() bool {
+	return loader == loader.dictionary.bootstrap
+}
+
+// Parent delegation parent of built-in loader, nil for bootstrap and user-defined loader
+func (loader *ClassLoader) Parent() *ClassLoader {
+	return loader.parent
+}
+
+// BootstrapLoader bootstrap class loader of VM
+func (loader *ClassLoader) BootstrapLoader() *ClassLoader {
+	return loader.dictionary.bootstrap
+}
+
+// PlatformLoader ClassLoader.getPlatformClassLoader()
+func (loader *ClassLoader) PlatformLoader() *ClassLoader {
+	return loader.dictionary.platform
+}
+
+// SystemLoader ClassLoader.getSystemClassLoader() (app loader)
+func (loader *ClassLoader) SystemLoader() *ClassLoader {
+	return loader.dictionary.app
+}
+
+// LoaderOf runtime ClassLoader of java.lang.ClassLoader object, nil object is bootstrap loader
+func (loader *ClassLoader) LoaderOf(jLoader *heap.Object) *ClassLoader {
+	if jLoader == nil {
+		return loader.dictionary.bootstrap
+	}
+	if runtimeLoader, ok := jLoader.Extra().(*ClassLoader); ok {
+		return runtimeLoader
+	}
+
+	userLoader := &ClassLoader{
+		name:             jLoader.Class().(*Class).JavaName(),
+		id:               loader.dictionary.newLoaderId(),
+		classes:          make(map[string]*Class),
+		dictionary:       loader.dictionary,
+		jLoader:          jLoader,
+		jlClassClass:     loader.jlClassClass,
+		primitiveClasses: loader.primitiveClasses,
+	}
+	userLoader.trackUnloading()
+	jLoader.SetExtra(userLoader)
+	return userLoader
+}
+
+// JLoader java.lang.ClassLoader object of this loader, nil for bootstrap (null in java)
+// built-in loader's object is created when first required, its parent field is parent loader's object
+func (loader *ClassLoader) JLoader() *heap.Object {
+	if loader.jLoader == nil && !loader.IsBootstrap() {
+		jLoader := loader.LoadClass(BUILTIN_LOADER_CLASS, false).NewObject()
+		jLoader.SetExtra(loader)
+		class := jLoader.Class().(*Class)
+		if parent := loader.parent.JLoader(); parent != nil {
+			if field := class.GetField("parent", "Ljava/lang/ClassLoader;", false); field != nil {
+				jLoader.SetRefField(field.slotId, parent)
+			}
+		}
+		if field := class.GetField("name", "Ljava/lang/String;", false); field != nil {
+			jLoader.SetRefField(field.slotId, heap.InternString(loader.name, loader))
+		}
+		loader.jLoader = jLoader
+	}
+	return loader.jLoader
+}
+
+// DefineClass ClassLoader.defineClass(name, b, off, len): define class from bytes by this loader
+// name: expected class name (internal form), "" means any
+func (loader *ClassLoader) DefineClass(name string, data []byte) *Class {
+	return loader.defineAndLinkClass(data, name, "__JVM_DefineClass__", false)
+}
+
+// FindLoadedClass ClassLoader.findLoadedClass(name): class initiated by this loader, nil if not loaded
+func (loader *ClassLoader) FindLoadedClass(name string) *Class {
+	return loader.dictionary.find(loader, name)
+}
+
+// LoadClassOrNil load class by built-in loader without throwing ClassNotFoundException, nil if not found
+func (loader *ClassLoader) LoadClassOrNil(name string) *Class {
+	if !loader.builtin || len(name) == 0 || name[0] == '[' {
+		return nil
+	}
+	return loader.loadNonArrayClass(name, false)
+}
+
+// loadClassByJava user-defined loader: invoke java `loader.loadClass(String)` (JVMS 5.3.2)
+// exception thrown by loadClass is rethrown as it is
+func (loader *ClassLoader) loadClassByJava(name string) *Class {
+	method := loader.jLoader.Class().(*Class).GetMethod("loadClass", "(Ljava/lang/String;)Ljava/lang/Class;")
+	if method == nil || javaCaller == nil {
+		panic(common.NewJavaException("java/lang/InternalError", "can not invoke "+loader.name+".loadClass"))
+	}
+
+	// intern by bootstrap: interning by this loader loads java/lang/String by loadClass again
+	binaryName := heap.InternString(strings.ReplaceAll(name, "/", "."), loader.dictionary.bootstrap)
+	ret, ex := javaCaller(method, loader.jLoader, binaryName)
+	if ex != nil {
+		panic(common.NewThrownJavaException(ex.Class().(*Class).name, ex))
+	}
+
+	classObj, _ := ret.(*heap.Object)
+	if classObj == nil {
+		panic(common.NewJavaException("java/lang/NoClassDefFoundError", name))
+	}
+	class := classObj.Extra().(*Class)
+	if class.name != name {
+		panic(common.NewJavaException("java/lang/NoClassDefFoundError",
+			fmt.Sprintf("%s (wrong name: %s)", name, class.name)))
+	}
+	return class
+}
@@ -0,0 +1,465 @@
+package method_area
+
+import (
+	"fmt"
+	"github.com/Johnny1110/gogo_jvm/classfile"
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"strings"
+)
+
+// ============================================================
+// Bytecode Verifier (JVMS 4.10.1 - Verification by Type Checking)
+// ============================================================
+// without verifier, malformed bytecode (stack underflow, wrong type, jump into the middle of instruction ...)
+// crash gogo-jvm itself with Go index panic. verifier check it once at link time and throw VerifyError.
+//
+// type checking (class file version >= 50):
+// StackMapTable give the type state at every branch target / exception handler,
+// so each method is checked in one linear pass:
+//
+//	pc 0: initial frame (from method descriptor)
+//	each instruction:
+//	  - if pc has stack map frame: current frame must be assignable to it, continue with it
+//	  - every exception handler covering pc: [catchType] frame must be assignable to handler's frame
+//	  - execute instruction on types (pop / push / load / store), check max_stack & max_locals
+//	  - every branch target: frame must be assignable to target's stack map frame
+//	  - after goto / return / athrow / switch: next instruction must have a stack map frame
+//
+// class file version < 50: type inference (verifier_inference.go),
+// version 50 which fails type checking falls back to type inference (JVMS 4.10).
+//
+// not checked (yet): protected member access (JVMS 4.10.1.8).
+//
+// -Xverify:none    no verification
+// -Xverify:remote  (default) verify application classes, trust bootstrap classes (java/*, javax/*, jdk/*, sun/*)
+// -Xverify:all     verify all classes
+
+type VerifyMode int
+
+const (
+	VERIFY_NONE VerifyMode = iota
+	VERIFY_REMOTE
+	VERIFY_ALL
+)
+
+// first class file version using type checking verifier (StackMapTable)
+const TYPE_CHECKING_MAJOR_VERSION = classfile.JAVA_6
+
+var verifyMode = VERIFY_REMOTE
+
+// SetVerifyMode by -Xverify:<mode> value (none, remote, all)
+func SetVerifyMode(mode string) error {
+	switch mode {
+	case "none":
+		verifyMode = VERIFY_NONE
+	case "remote":
+		verifyMode = VERIFY_REMOTE
+	case "all":
+		verifyMode = VERIFY_ALL
+	default:
+		return fmt.Errorf("invalid -Xverify mode: %s (expected none, remote or all)", mode)
+	}
+	return nil
+}
+
+// shouldVerify
+// synthetic class spin at runtime (lambda, string concat) has no class file (version 0), it is trusted.
+func shouldVerify(class *Class) bool {
+	if class.majorVersion == 0 {
+		return false
+	}
+	switch verifyMode {
+	case VERIFY_NONE:
+		return false
+	case VERIFY_REMOTE:
+		return !isBootstrapClassName(class.name)
+	default:
+		return true
+	}
+}
+
+func isBootstrapClassName(name string) bool {
+	for _, prefix := range []string{"java/", "javax/", "jdk/", "sun/"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyFailure panic value inside verifier, converted to java.lang.VerifyError by verify()
+type verifyFailure struct {
+	message string
+}
+
+// verifyMethods check all methods which have code
+func verifyMethods(class *Class) {
+	for _, method := range class.methods {
+		if method.IsAbstract() || method.IsNative() {
+			continue
+		}
+		if failure := verifyMethod(class, method); failure != nil {
+			panic(common.NewJavaException("java/lang/VerifyError", failure.message))
+		}
+	}
+}
+
+func verifyMethod(class *Class, method *Method) *verifyFailure {
+	if class.majorVersion < TYPE_CHECKING_MAJOR_VERSION {
+		return inferMethod(class, method)
+	}
+	failure := typeCheckMethod(class, method)
+	if failure != nil && class.majorVersion == TYPE_CHECKING_MAJOR_VERSION {
+		// failover: version 50 StackMapTable may be missing or wrong (old bytecode tools)
+		return inferMethod(class, method)
+	}
+	return failure
+}
+
+// typeCheckMethod return nil if method pass type checking
+func typeCheckMethod(class *Class, method *Method) (failure *verifyFailure) {
+	defer func() {
+		if r := recover(); r != nil {
+			f, ok := r.(*verifyFailure)
+			if !ok {
+				panic(r)
+			}
+			failure = f
+		}
+	}()
+
+	v := &methodVerifier{class: class, method: method, pc: -1}
+	v.typeCheck()
+	return nil
+}
+
+// ============================================================
+// methodVerifier
+// ============================================================
+
+type methodVerifier struct {
+	class          *Class
+	method         *Method
+	instructions   []*vInstruction
+	instIndexes    []int // pc -> index of instructions, -1 if pc is not start of instruction
+	initialFrame   *vFrame
+	stackMapFrames map[int]*vFrame
+	returnType     string
+
+	// type inference (class file version < 50)
+	inference bool
+	state     *vInference
+
+	// current instruction (for error message)
+	pc     int
+	opcode uint8
+}
+
+// fail abort verification with message about current instruction
+func (v *methodVerifier) fail(format string, args ...interface{}) {
+	location := fmt.Sprintf("(class: %s, method: %s signature: %s)", v.class.name, v.method.name, v.method.descriptor)
+	if v.pc >= 0 {
+		location += fmt.Sprintf(" @%d %s", v.pc, opcodeName(v.opcode))
+	}
+	panic(&verifyFailure{message: location + ": " + fmt.Sprintf(format, args...)})
+}
+
+// failMethod abort verification with message about method (not a specific instruction)
+func (v *methodVerifier) failMethod(format string, args ...interface{}) {
+	v.pc = -1
+	v.fail(format, args...)
+}
+
+func opcodeName(opcode uint8) string {
+	if name := opcodes.OpcodeNames[opcode]; name != "" {
+		return name
+	}
+	return fmt.Sprintf("0x%02X", opcode)
+}
+
+func (v *methodVerifier) isInstructionStart(pc int) bool {
+	return pc >= 0 && pc < len(v.instIndexes) && v.instIndexes[pc] >= 0
+}
+
+func (v *methodVerifier) instructionAt(pc int) *vInstruction {
+	if !v.isInstructionStart(pc) {
+		return nil
+	}
+	return v.instructions[v.instIndexes[pc]]
+}
+
+// typeCheck JVMS 4.10.1.6 methodIsTypeSafe
+func (v *methodVerifier) typeCheck() {
+	method := v.method
+	if len(method.code) == 0 {
+		v.failMethod("Method has no code")
+	}
+	if !isValidMethodDescriptor(method.descriptor) {
+		v.failMethod("Illegal method descriptor")
+	}
+	v.returnType = parseMethodDescriptor(method.descriptor).returnType
+
+	v.decode()
+	initialFrame, declared := v.newInitialFrame()
+	v.initialFrame = initialFrame
+	v.stackMapFrames = v.buildStackMapFrames(declared)
+	v.checkExceptionTable()
+
+	current := initialFrame.clone()
+	for i, inst := range v.instructions {
+		v.pc, v.opcode = inst.pc, inst.opcode
+
+		if stackMapFrame, ok := v.stackMapFrames[inst.pc]; ok {
+			if current != nil && !v.isFrameAssignable(current, stackMapFrame) {
+				v.fail("Current frame is not assignable to stack map frame")
+			}
+			current = stackMapFrame.clone()
+		} else if current == nil {
+			v.fail("Expecting a stack map frame")
+		}
+
+		for _, handler := range method.exceptionTable {
+			if inst.pc >= handler.StartPC && inst.pc < handler.EndPC {
+				v.checkHandler(current, handler)
+			}
+		}
+
+		if v.execute(inst, current) {
+			if i == len(v.instructions)-1 {
+				v.fail("Falling off the end of the code")
+			}
+		} else {
+			current = nil
+		}
+	}
+}
+
+// checkBranch frame must match stack map frame at branch target (type inference: merge into target)
+func (v *methodVerifier) checkBranch(frame *vFrame, target int) {
+	if v.inference {
+		v.mergeInto(target, frame)
+		return
+	}
+	stackMapFrame, ok := v.stackMapFrames[target]
+	if !ok {
+		v.fail("Expecting a stack map frame at branch target %d", target)
+	}
+	if !v.isFrameAssignable(frame, stackMapFrame) {
+		v.fail("Type state is not assignable to stack map frame at branch target %d", target)
+	}
+}
+
+// checkHandler exception may be thrown by any instruction in [start, end),
+// locals of current frame + [catchType] must be assignable to handler's frame
+func (v *methodVerifier) checkHandler(frame *vFrame, handler *ExceptionHandler) {
+	if v.inference {
+		v.mergeInto(handler.HandlerPC, frame.withStack(catchVType(handler)))
+		return
+	}
+	stackMapFrame, ok := v.stackMapFrames[handler.HandlerPC]
+	if !ok {
+		v.fail("Expecting a stack map frame at exception handler %d", handler.HandlerPC)
+	}
+	if !v.isFrameAssignable(frame.withStack(catchVType(handler)), stackMapFrame) {
+		v.fail("Type state is not assignable to stack map frame at exception handler %d", handler.HandlerPC)
+	}
+}
+
+func catchVType(handler *ExceptionHandler) vType {
+	if handler.CatchTypeName == "" {
+		return vTypeThrowable
+	}
+	return vTypeOfClassName(handler.CatchTypeName)
+}
+
+// checkExceptionTable handler range [start, end) and handler pc must be instruction boundaries,
+// catch type must be Throwable
+func (v *methodVerifier) checkExceptionTable() {
+	codeLength := len(v.method.code)
+	for _, handler := range v.method.exceptionTable {
+		if !v.isInstructionStart(handler.StartPC) || handler.StartPC >= handler.EndPC ||
+			(handler.EndPC != codeLength && !v.isInstructionStart(handler.EndPC)) || handler.EndPC > codeLength {
+			v.failMethod("Illegal exception table range [%d, %d)", handler.StartPC, handler.EndPC)
+		}
+		if !v.isInstructionStart(handler.HandlerPC) {
+			v.failMethod("Illegal exception table handler %d", handler.HandlerPC)
+		}
+		if !v.isAssignable(catchVType(handler), vTypeThrowable) {
+			v.failMethod("Catch type is not a subclass of Throwable: %s", handler.CatchTypeName)
+		}
+	}
+}
+
+// ============================================================
+// decode - split code into instructions, check operands are in bounds
+// ============================================================
+
+// vInstruction decoded instruction
+//   - index:   local variable index / constant pool index
+//   - value:   bipush / sipush / iinc const, newarray atype, multianewarray dimensions, invokeinterface count
+//   - targets: branch targets (switch: default first)
+type vInstruction struct {
+	pc      int
+	opcode  uint8
+	index   int
+	value   int
+	targets []int
+}
+
+func (v *methodVerifier) decode() {
+	code := v.method.code
+	v.instIndexes = make([]int, len(code))
+	for i := range v.instIndexes {
+		v.instIndexes[i] = -1
+	}
+
+	for pc := 0; pc < len(code); {
+		v.pc, v.opcode = pc, code[pc]
+		v.instIndexes[pc] = len(v.instructions)
+		inst, next := v.decodeInstruction(code, pc)
+		v.instructions = append(v.instructions, inst)
+		pc = next
+	}
+
+	// branch targets must be start of instruction
+	for _, inst := range v.instructions {
+		v.pc, v.opcode = inst.pc, inst.opcode
+		for _, target := range inst.targets {
+			if !v.isInstructionStart(target) {
+				v.fail("Illegal target of jump or branch: %d", target)
+			}
+		}
+	}
+}
+
+// decodeInstruction return instruction and pc of next instruction
+func (v *methodVerifier) decodeInstruction(code []byte, pc int) (*vInstruction, int) {
+	inst := &vInstruction{pc: pc, opcode: code[pc]}
+	r := &vCodeReader{v: v, code: code, pos: pc + 1}
+
+	switch op := inst.opcode; {
+	case op == opcodes.BIPUSH:
+		inst.value = int(int8(r.u1()))
+	case op == opcodes.SIPUSH:
+		inst.value = int(r.s2())
+	case op == opcodes.LDC:
+		inst.index = int(r.u1())
+	case op == opcodes.LDC_W || op == opcodes.LDC2_W:
+		inst.index = int(r.u2())
+	case op >= opcodes.ILOAD && op <= opcodes.ALOAD, op >= opcodes.ISTORE && op <= opcodes.ASTORE, op == opcodes.RET:
+		inst.index = int(r.u1())
+	case op == opcodes.IINC:
+		inst.index = int(r.u1())
+		inst.value = int(int8(r.u1()))
+	case op >= opcodes.IFEQ && op <= opcodes.JSR, op == opcodes.IFNULL, op == opcodes.IFNONNULL:
+		inst.targets = []int{pc + int(r.s2())}
+	case op == opcodes.GOTO_W || op == opcodes.JSR_W:
+		inst.targets = []int{pc + int(r.s4())}
+	case op == opcodes.TABLESWITCH:
+		r.align()
+		defaultOffset := r.s4()
+		low, high := r.s4(), r.s4()
+		if low > high {
+			v.fail("Illegal tableswitch: low %d > high %d", low, high)
+		}
+		inst.targets = []int{pc + int(defaultOffset)}
+		for i := int64(low); i <= int64(high); i++ {
+			inst.targets = append(inst.targets, pc+int(r.s4()))
+		}
+	case op == opcodes.LOOKUPSWITCH:
+		r.align()
+		defaultOffset := r.s4()
+		npairs := r.s4()
+		if npairs < 0 {
+			v.fail("Illegal lookupswitch: npairs %d", npairs)
+		}
+		inst.targets = []int{pc + int(defaultOffset)}
+		for i := int32(0); i < npairs; i++ {
+			key := r.s4()
+			if i > 0 && int64(key) <= int64(inst.value) {
+				v.fail("Illegal lookupswitch: keys are not sorted")
+			}
+			inst.value = int(key)
+			inst.targets = append(inst.targets, pc+int(r.s4()))
+		}
+	case op >= opcodes.GETSTATIC && op <= opcodes.INVOKESTATIC, op == opcodes.NEW, op == opcodes.ANEWARRAY,
+		op == opcodes.CHECKCAST, op == opcodes.INSTANCEOF:
+		inst.index = int(r.u2())
+	case op == opcodes.INVOKEINTERFACE:
+		inst.index = int(r.u2())
+		inst.value = int(r.u1())
+		if r.u1() != 0 {
+			v.fail("Illegal invokeinterface: 4th operand byte must be 0")
+		}
+	case op == opcodes.INVOKEDYNAMIC:
+		inst.index = int(r.u2())
+		if r.u2() != 0 {
+			v.fail("Illegal invokedynamic: 3rd and 4th operand bytes must be 0")
+		}
+	case op == opcodes.NEWARRAY:
+		inst.value = int(r.u1())
+	case op == opcodes.MULTIANEWARRAY:
+		inst.index = int(r.u2())
+		inst.value = int(r.u1())
+	case op == opcodes.WIDE:
+		inst.opcode = r.u1()
+		v.opcode = inst.opcode
+		switch {
+		case inst.opcode >= opcodes.ILOAD && inst.opcode <= opcodes.ALOAD,
+			inst.opcode >= opcodes.ISTORE && inst.opcode <= opcodes.ASTORE, inst.opcode == opcodes.RET:
+			inst.index = int(r.u2())
+		case inst.opcode == opcodes.IINC:
+			inst.index = int(r.u2())
+			inst.value = int(r.s2())
+		default:
+			v.fail("Illegal instruction after wide: %s", opcodeName(inst.opcode))
+		}
+	case op > opcodes.JSR_W:
+		v.fail("Illegal instruction opcode 0x%02X", op)
+	}
+	return inst, r.pos
+}
+
+// vCodeReader bounds checked bytecode reader for decode
+type vCodeReader struct {
+	v    *methodVerifier
+	code []byte
+	pos  int
+}
+
+func (r *vCodeReader) need(n int) {
+	if r.pos+n > len(r.code) {
+		r.v.fail("Instruction operands exceed code length")
+	}
+}
+
+func (r *vCodeReader) u1() uint8 {
+	r.need(1)
+	b := r.code[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *vCodeReader) u2() uint16 {
+	r.need(2)
+	value := uint16(r.code[r.pos])<<8 | uint16(r.code[r.pos+1])
+	r.pos += 2
+	return value
+}
+
+func (r *vCodeReader) s2() int16 { return int16(r.u2()) }
+
+func (r *vCodeReader) s4() int32 {
+	r.need(4)
+	value := int32(r.code[r.pos])<<24 | int32(r.code[r.pos+1])<<16 | int32(r.code[r.pos+2])<<8 | int32(r.code[r.pos+3])
+	r.pos += 4
+	return value
+}
+
+// align tableswitch / lookupswitch padding: operands start at multiple of 4
+func (r *vCodeReader) align() {
+	for r.pos%4 != 0 {
+		r.u1()
+	}
+}
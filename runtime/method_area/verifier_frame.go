@@ -0,0 +1,328 @@
+package method_area
+
+import (
+	"github.com/Johnny1110/gogo_jvm/classfile"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+)
+
+// ============================================================
+// Verification Frame - type state of LocalVars & OperandStack
+// ============================================================
+// flagThisUninit: <init> has not called super() / this() yet (locals contain uninitializedThis)
+
+type vFrame struct {
+	locals         []vType
+	stack          []vType
+	flagThisUninit bool
+}
+
+func (f *vFrame) clone() *vFrame {
+	return &vFrame{
+		locals:         append([]vType(nil), f.locals...),
+		stack:          append([]vType(nil), f.stack...),
+		flagThisUninit: f.flagThisUninit,
+	}
+}
+
+// withStack same locals, new stack (ex: exception handler frame [Throwable])
+func (f *vFrame) withStack(stack ...vType) *vFrame {
+	return &vFrame{locals: f.locals, stack: stack, flagThisUninit: f.flagThisUninit}
+}
+
+// ============================================================
+// operand stack
+// ============================================================
+
+// push long / double push [t, top]
+func (v *methodVerifier) push(f *vFrame, t vType) {
+	f.stack = append(f.stack, t)
+	if t.isCategory2() {
+		f.stack = append(f.stack, vTypeTop)
+	}
+	if len(f.stack) > int(v.method.maxStack) {
+		v.fail("Exceeded max stack size %d", v.method.maxStack)
+	}
+}
+
+// popEntry pop one raw entry
+func (v *methodVerifier) popEntry(f *vFrame) vType {
+	if len(f.stack) == 0 {
+		v.fail("Attempt to pop empty stack")
+	}
+	t := f.stack[len(f.stack)-1]
+	f.stack = f.stack[:len(f.stack)-1]
+	return t
+}
+
+// pop value which must be assignable to expected type
+func (v *methodVerifier) pop(f *vFrame, expected vType) vType {
+	if expected.isCategory2() {
+		if half := v.popEntry(f); half.kind != vTop {
+			v.fail("Bad type on operand stack: expected %s, found %s", expected, half)
+		}
+	}
+	t := v.popEntry(f)
+	if (t.kind == vTop && expected.kind != vTop) || !v.isAssignable(t, expected) {
+		v.fail("Bad type on operand stack: %s is not assignable to %s", t, expected)
+	}
+	return t
+}
+
+// popReference pop null / class / array / uninitialized
+func (v *methodVerifier) popReference(f *vFrame) vType {
+	t := v.popEntry(f)
+	if !t.isReference() {
+		v.fail("Bad type on operand stack: expected reference, found %s", t)
+	}
+	return t
+}
+
+// popArray pop array (or null) for xaload / xastore / arraylength
+func (v *methodVerifier) popArray(f *vFrame) vType {
+	t := v.popEntry(f)
+	if t.kind != vNull && !t.isArray() {
+		v.fail("Bad type on operand stack: expected array, found %s", t)
+	}
+	return t
+}
+
+// checkNotSplit stack boundary `depth` entries from top must not split a long / double
+// (the entry just above the boundary must not be the second half)
+func (v *methodVerifier) checkNotSplit(f *vFrame, depth int) {
+	if depth > len(f.stack) {
+		v.fail("Attempt to pop empty stack")
+	}
+	if depth > 0 && f.stack[len(f.stack)-depth].kind == vTop {
+		v.fail("Bad type on operand stack: stack manipulation splits a long or double")
+	}
+}
+
+// checkCategory1 top `n` entries are all category 1 values
+func (v *methodVerifier) checkCategory1(f *vFrame, n int) {
+	if n > len(f.stack) {
+		v.fail("Attempt to pop empty stack")
+	}
+	for i := 1; i <= n; i++ {
+		if t := f.stack[len(f.stack)-i]; t.kind == vTop || t.isCategory2() {
+			v.fail("Bad type on operand stack: expected category 1 value, found %s", t)
+		}
+	}
+}
+
+// ============================================================
+// local variables
+// ============================================================
+
+func (v *methodVerifier) checkLocalIndex(index int, size int) {
+	if index < 0 || index+size > len(v.initialFrame.locals) {
+		v.fail("Illegal local variable number %d", index)
+	}
+}
+
+// load local[index] which must be assignable to expected type
+func (v *methodVerifier) load(f *vFrame, index int, expected vType) vType {
+	size := 1
+	if expected.isCategory2() {
+		size = 2
+	}
+	v.checkLocalIndex(index, size)
+	t := f.locals[index]
+	if (t.kind == vTop && expected.kind != vTop) || !v.isAssignable(t, expected) {
+		v.fail("Bad local variable type: local %d is %s, expected %s", index, t, expected)
+	}
+	if size == 2 && f.locals[index+1].kind != vTop {
+		v.fail("Bad local variable type: local %d is not %s", index, expected)
+	}
+	return t
+}
+
+// store t into local[index], long / double take [index, index+1]
+// if local[index-1] is first half of long / double, it is broken -> top
+func (v *methodVerifier) store(f *vFrame, index int, t vType) {
+	size := 1
+	if t.isCategory2() {
+		size = 2
+	}
+	v.checkLocalIndex(index, size)
+	if index > 0 && f.locals[index-1].isCategory2() {
+		f.locals[index-1] = vTypeTop
+	}
+	f.locals[index] = t
+	if size == 2 {
+		f.locals[index+1] = vTypeTop
+	}
+}
+
+// replaceUninit after <init> called: uninitialized type -> initialized class type
+func (f *vFrame) replaceUninit(from, to vType) {
+	for i, t := range f.locals {
+		if t == from {
+			f.locals[i] = to
+		}
+	}
+	for i, t := range f.stack {
+		if t == from {
+			f.stack[i] = to
+		}
+	}
+	if from.kind == vUninitThis {
+		f.flagThisUninit = false
+	}
+}
+
+// isFrameAssignable JVMS 4.10.1.4 frameIsAssignable: every local & stack entry is assignable to target
+func (v *methodVerifier) isFrameAssignable(from, to *vFrame) bool {
+	if len(from.stack) != len(to.stack) {
+		return false
+	}
+	if from.flagThisUninit && !to.flagThisUninit {
+		return false
+	}
+	for i := range to.locals {
+		if !v.isAssignable(from.locals[i], to.locals[i]) {
+			return false
+		}
+	}
+	for i := range to.stack {
+		if !v.isAssignable(from.stack[i], to.stack[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ============================================================
+// initial frame & StackMapTable frames
+// ============================================================
+
+// newInitialFrame frame at pc 0 from method descriptor
+// also return the declared locals (long / double as one entry) which StackMapTable frames are based on
+func (v *methodVerifier) newInitialFrame() (*vFrame, []vType) {
+	method := v.method
+	var declared []vType
+	flagThisUninit := false
+
+	if !method.IsStatic() {
+		if method.name == "<init>" && v.class.name != "java/lang/Object" {
+			declared = append(declared, vTypeUninitThis)
+			flagThisUninit = true
+		} else {
+			declared = append(declared, vTypeOfClassName(v.class.name))
+		}
+	}
+	descriptor := parseMethodDescriptor(method.descriptor)
+	for _, paramType := range descriptor.parameterTypes {
+		declared = append(declared, vTypeOfDescriptor(paramType))
+	}
+
+	locals := v.expandLocals(declared)
+	if locals == nil {
+		v.failMethod("Arguments can't fit into locals (max_locals = %d)", method.maxLocals)
+	}
+	return &vFrame{locals: locals, flagThisUninit: flagThisUninit}, declared
+}
+
+// expandLocals declared locals -> slots (long / double take 2), padding with top to max_locals
+// nil if exceeds max_locals
+func (v *methodVerifier) expandLocals(declared []vType) []vType {
+	locals := make([]vType, 0, v.method.maxLocals)
+	for _, t := range declared {
+		locals = append(locals, t)
+		if t.isCategory2() {
+			locals = append(locals, vTypeTop)
+		}
+	}
+	if len(locals) > int(v.method.maxLocals) {
+		return nil
+	}
+	for len(locals) < int(v.method.maxLocals) {
+		locals = append(locals, vTypeTop)
+	}
+	return locals
+}
+
+// buildStackMapFrames expand StackMapTable (delta compressed) into pc -> frame
+func (v *methodVerifier) buildStackMapFrames(declared []vType) map[int]*vFrame {
+	frames := make(map[int]*vFrame)
+	if v.method.stackMapTable == nil {
+		return frames
+	}
+
+	offset := -1
+	locals := append([]vType(nil), declared...)
+	for _, entry := range v.method.stackMapTable.Entries() {
+		offset += int(entry.OffsetDelta()) + 1
+		if !v.isInstructionStart(offset) {
+			v.failMethod("StackMapTable error: bad offset %d", offset)
+		}
+
+		var stack []vType
+		switch entry.Kind() {
+		case classfile.SAME_FRAME:
+		case classfile.SAME_LOCALS_1_STACK_ITEM_FRAME:
+			stack = v.convertVerificationTypes(entry.Stack())
+		case classfile.CHOP_FRAME:
+			if entry.ChopCount() > len(locals) {
+				v.failMethod("StackMapTable error: chop frame at %d removes too many locals", offset)
+			}
+			locals = locals[:len(locals)-entry.ChopCount()]
+		case classfile.APPEND_FRAME:
+			locals = append(locals, v.convertVerificationTypes(entry.Locals())...)
+		case classfile.FULL_FRAME:
+			locals = v.convertVerificationTypes(entry.Locals())
+			stack = v.convertVerificationTypes(entry.Stack())
+		}
+
+		frame := &vFrame{locals: v.expandLocals(locals)}
+		if frame.locals == nil {
+			v.failMethod("StackMapTable error: frame at %d exceeds max_locals %d", offset, v.method.maxLocals)
+		}
+		for _, t := range stack {
+			frame.stack = append(frame.stack, t)
+			if t.isCategory2() {
+				frame.stack = append(frame.stack, vTypeTop)
+			}
+		}
+		if len(frame.stack) > int(v.method.maxStack) {
+			v.failMethod("StackMapTable error: frame at %d exceeds max_stack %d", offset, v.method.maxStack)
+		}
+		for _, t := range frame.locals {
+			if t.kind == vUninitThis {
+				frame.flagThisUninit = true
+			}
+		}
+		frames[offset] = frame
+	}
+	return frames
+}
+
+func (v *methodVerifier) convertVerificationTypes(infos []*classfile.VerificationTypeInfo) []vType {
+	types := make([]vType, len(infos))
+	for i, info := range infos {
+		switch info.Tag() {
+		case classfile.ITEM_Top:
+			types[i] = vTypeTop
+		case classfile.ITEM_Integer:
+			types[i] = vTypeInt
+		case classfile.ITEM_Float:
+			types[i] = vTypeFloat
+		case classfile.ITEM_Long:
+			types[i] = vTypeLong
+		case classfile.ITEM_Double:
+			types[i] = vTypeDouble
+		case classfile.ITEM_Null:
+			types[i] = vTypeNull
+		case classfile.ITEM_UninitializedThis:
+			types[i] = vTypeUninitThis
+		case classfile.ITEM_Object:
+			types[i] = vTypeOfClassName(info.ClassName())
+		case classfile.ITEM_Uninitialized:
+			offset := int(info.Offset())
+			if inst := v.instructionAt(offset); inst == nil || inst.opcode != opcodes.NEW {
+				v.failMethod("StackMapTable error: uninitialized(%d) does not point to a new instruction", offset)
+			}
+			types[i] = vUninitType(offset)
+		}
+	}
+	return types
+}
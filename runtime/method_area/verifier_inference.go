@@ -0,0 +1,314 @@
+package method_area
+
+import (
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+)
+
+// ============================================================
+// Bytecode Verifier - Type Inference (JVMS 4.10.2)
+// ============================================================
+// class file version < 50 has no StackMapTable, frame at each instruction is inferred by data-flow analysis:
+//
+//	frame[0] = initial frame, mark pc 0 changed
+//	while any instruction is changed:
+//	  - pick it, execute on a copy of its frame
+//	  - merge result into every successor (fall through, branch targets, exception handlers, jsr / ret)
+//	  - successor whose frame changed by merge is marked changed
+//
+// merge (JVMS 4.10.2.2):
+//   - operand stack must have same height, each entry: same type, or references -> first common super class
+//   - locals: same type, or references -> first common super class, otherwise unusable (top)
+//
+// subroutine (jsr / ret, finally before Java 6):
+//   - jsr pushes returnAddress(subroutine pc) and jumps to subroutine
+//   - ret returns to instruction after every jsr calling this subroutine,
+//     locals stored by subroutine come from ret frame, others keep the type they had at the jsr
+
+// inferMethod return nil if method pass type inference
+func inferMethod(class *Class, method *Method) (failure *verifyFailure) {
+	defer func() {
+		if r := recover(); r != nil {
+			f, ok := r.(*verifyFailure)
+			if !ok {
+				panic(r)
+			}
+			failure = f
+		}
+	}()
+
+	v := &methodVerifier{class: class, method: method, pc: -1, inference: true}
+	v.infer()
+	return nil
+}
+
+// inference state of methodVerifier
+type vInference struct {
+	frames    map[int]*vFrame // pc -> merged incoming frame
+	changed   []bool          // index of instructions
+	retFrames map[int]*vFrame // subroutine pc -> merged frame at its ret
+	modified  map[int][]bool  // subroutine pc -> locals stored by subroutine
+}
+
+func (v *methodVerifier) infer() {
+	method := v.method
+	if len(method.code) == 0 {
+		v.failMethod("Method has no code")
+	}
+	if !isValidMethodDescriptor(method.descriptor) {
+		v.failMethod("Illegal method descriptor")
+	}
+	v.returnType = parseMethodDescriptor(method.descriptor).returnType
+
+	v.decode()
+	v.initialFrame, _ = v.newInitialFrame()
+	v.checkExceptionTable()
+
+	v.state = &vInference{
+		frames:    map[int]*vFrame{0: v.initialFrame.clone()},
+		changed:   make([]bool, len(v.instructions)),
+		retFrames: make(map[int]*vFrame),
+		modified:  make(map[int][]bool),
+	}
+	v.state.changed[0] = true
+
+	for i := v.nextChanged(); i >= 0; i = v.nextChanged() {
+		v.state.changed[i] = false
+		inst := v.instructions[i]
+		v.pc, v.opcode = inst.pc, inst.opcode
+		current := v.state.frames[inst.pc].clone()
+
+		for _, handler := range method.exceptionTable {
+			if inst.pc >= handler.StartPC && inst.pc < handler.EndPC {
+				v.checkHandler(current, handler)
+			}
+		}
+
+		if v.execute(inst, current) {
+			if i == len(v.instructions)-1 {
+				v.fail("Falling off the end of the code")
+			}
+			v.mergeInto(v.instructions[i+1].pc, current)
+		}
+	}
+}
+
+func (v *methodVerifier) nextChanged() int {
+	for i, changed := range v.state.changed {
+		if changed {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeInto merge frame into incoming frame of instruction at pc, mark it changed if frame is changed
+func (v *methodVerifier) mergeInto(pc int, frame *vFrame) {
+	merged, changed := v.mergeFrame(v.state.frames[pc], frame)
+	if changed {
+		v.state.frames[pc] = merged
+		v.state.changed[v.instIndexes[pc]] = true
+	}
+}
+
+// mergeFrame return merged frame and whether it differs from `into`
+func (v *methodVerifier) mergeFrame(into, frame *vFrame) (*vFrame, bool) {
+	if into == nil {
+		return frame.clone(), true
+	}
+	if len(into.stack) != len(frame.stack) {
+		v.fail("Inconsistent stack height %d != %d", len(into.stack), len(frame.stack))
+	}
+
+	merged := into.clone()
+	changed := false
+	for i, t := range frame.locals {
+		if m := v.mergeType(merged.locals[i], t); m != merged.locals[i] {
+			merged.locals[i] = m
+			changed = true
+		}
+	}
+	for i, t := range frame.stack {
+		m := v.mergeType(merged.stack[i], t)
+		if m.kind == vTop && merged.stack[i].kind != vTop {
+			v.fail("Mismatched stack types: %s and %s", merged.stack[i], t)
+		}
+		if m != merged.stack[i] {
+			merged.stack[i] = m
+			changed = true
+		}
+	}
+	if frame.flagThisUninit && !merged.flagThisUninit {
+		merged.flagThisUninit = true
+		changed = true
+	}
+	return merged, changed
+}
+
+// mergeType same type, or first common super class of references, otherwise top
+func (v *methodVerifier) mergeType(a, b vType) vType {
+	if a == b {
+		return a
+	}
+	if (a.kind == vRef || a.kind == vNull) && (b.kind == vRef || b.kind == vNull) {
+		return v.mergeReferences(a, b)
+	}
+	return vTypeTop
+}
+
+func (v *methodVerifier) mergeReferences(a, b vType) vType {
+	if a.kind == vNull {
+		return b
+	}
+	if b.kind == vNull || a == b {
+		return a
+	}
+
+	if a.isArray() && b.isArray() {
+		aComponent, bComponent := a.name[1:], b.name[1:]
+		if isPrimitiveDescriptor(aComponent) || isPrimitiveDescriptor(bComponent) {
+			return vTypeObject
+		}
+		return arrayOf(v.mergeReferences(vTypeOfDescriptor(aComponent), vTypeOfDescriptor(bComponent)).name)
+	}
+	if a.isArray() || b.isArray() {
+		return vTypeObject
+	}
+
+	// class which can not be loaded is assumed to be subclass of the other one (same as isJavaAssignable)
+	aClass, bClass := v.tryLoadClass(a.name), v.tryLoadClass(b.name)
+	if aClass == nil {
+		return b
+	}
+	if bClass == nil {
+		return a
+	}
+	if aClass.IsInterface() || bClass.IsInterface() {
+		return vTypeObject
+	}
+	for c := aClass; c != nil; c = c.superClass {
+		if bClass == c || bClass.IsSubClassOf(c) {
+			return vRefType(c.name)
+		}
+	}
+	return vTypeObject
+}
+
+// ============================================================
+// subroutine: jsr / ret
+// ============================================================
+
+// jsr push returnAddress and jump to subroutine,
+// if subroutine already returned somewhere, instruction after jsr can be reached as well
+func (v *methodVerifier) jsr(inst *vInstruction, f *vFrame) {
+	target := inst.targets[0]
+	callerFrame := f.clone()
+	v.push(f, vType{kind: vReturnAddress, offset: target})
+	v.mergeInto(target, f)
+
+	if retFrame := v.state.retFrames[target]; retFrame != nil {
+		v.returnFromSubroutine(inst, callerFrame, retFrame)
+	}
+}
+
+// ret return to instruction after every jsr calling this subroutine
+func (v *methodVerifier) ret(inst *vInstruction, f *vFrame) {
+	v.checkLocalIndex(inst.index, 1)
+	address := f.locals[inst.index]
+	if address.kind != vReturnAddress {
+		v.fail("Bad local variable type: local %d is %s, expected returnAddress", inst.index, address)
+	}
+
+	subroutine := address.offset
+	merged, changed := v.mergeFrame(v.state.retFrames[subroutine], f)
+	if !changed {
+		return
+	}
+	v.state.retFrames[subroutine] = merged
+
+	for _, caller := range v.instructions {
+		if (caller.opcode == opcodes.JSR || caller.opcode == opcodes.JSR_W) && caller.targets[0] == subroutine {
+			if callerFrame := v.state.frames[caller.pc]; callerFrame != nil {
+				v.returnFromSubroutine(caller, callerFrame, merged)
+			}
+		}
+	}
+}
+
+// returnFromSubroutine merge frame after subroutine returns into instruction after jsr
+func (v *methodVerifier) returnFromSubroutine(caller *vInstruction, callerFrame, retFrame *vFrame) {
+	next := v.instIndexes[caller.pc] + 1
+	if next >= len(v.instructions) {
+		v.fail("Falling off the end of the code")
+	}
+
+	modified := v.subroutineModifiedLocals(caller.targets[0])
+	frame := &vFrame{
+		locals:         append([]vType(nil), callerFrame.locals...),
+		stack:          append([]vType(nil), retFrame.stack...),
+		flagThisUninit: callerFrame.flagThisUninit || retFrame.flagThisUninit,
+	}
+	for i := range frame.locals {
+		if modified[i] {
+			frame.locals[i] = retFrame.locals[i]
+		}
+	}
+	v.mergeInto(v.instructions[next].pc, frame)
+}
+
+// subroutineModifiedLocals locals stored by any instruction reachable from subroutine entry (before ret),
+// including nested subroutines and exception handlers inside it
+func (v *methodVerifier) subroutineModifiedLocals(subroutine int) []bool {
+	if modified, ok := v.state.modified[subroutine]; ok {
+		return modified
+	}
+
+	modified := make([]bool, len(v.initialFrame.locals))
+	visited := make([]bool, len(v.instructions))
+	work := []int{v.instIndexes[subroutine]}
+	for len(work) > 0 {
+		i := work[len(work)-1]
+		work = work[:len(work)-1]
+		if i >= len(v.instructions) || visited[i] {
+			continue
+		}
+		visited[i] = true
+		inst := v.instructions[i]
+
+		if t, index, ok := storedLocal(inst); ok && index < len(modified) {
+			modified[index] = true
+			if t.isCategory2() && index+1 < len(modified) {
+				modified[index+1] = true
+			}
+		}
+
+		for _, handler := range v.method.exceptionTable {
+			if inst.pc >= handler.StartPC && inst.pc < handler.EndPC {
+				work = append(work, v.instIndexes[handler.HandlerPC])
+			}
+		}
+		switch inst.opcode {
+		case opcodes.RET, opcodes.GOTO, opcodes.GOTO_W, opcodes.TABLESWITCH, opcodes.LOOKUPSWITCH, opcodes.ATHROW,
+			opcodes.IRETURN, opcodes.LRETURN, opcodes.FRETURN, opcodes.DRETURN, opcodes.ARETURN, opcodes.RETURN:
+		default:
+			work = append(work, i+1)
+		}
+		if inst.opcode != opcodes.RET {
+			for _, target := range inst.targets {
+				work = append(work, v.instIndexes[target])
+			}
+		}
+	}
+
+	v.state.modified[subroutine] = modified
+	return modified
+}
+
+// storedLocal local variable written by xstore
+func storedLocal(inst *vInstruction) (vType, int, bool) {
+	op := inst.opcode
+	if op >= opcodes.ISTORE && op <= opcodes.ASTORE || op >= opcodes.ISTORE_0 && op <= opcodes.ASTORE_3 {
+		t, index := loadStoreType(inst)
+		return t, index, true
+	}
+	return vTypeTop, 0, false
+}
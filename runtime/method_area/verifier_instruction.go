@@ -0,0 +1,637 @@
+package method_area
+
+import (
+	"github.com/Johnny1110/gogo_jvm/classfile"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"strings"
+)
+
+// ============================================================
+// Verifier - instruction type rules (JVMS 4.10.1.9)
+// ============================================================
+// execute instruction on verification types, frame is modified in place.
+// return false if instruction never falls through (goto, return, athrow, switch ...)
+
+// arithmetic & conversion: pop operands, push result
+type vTypeRule struct {
+	pops []vType
+	push *vType
+}
+
+func rule(push *vType, pops ...vType) vTypeRule { return vTypeRule{pops: pops, push: push} }
+
+var (
+	pInt, pFloat, pLong, pDouble = &vTypeInt, &vTypeFloat, &vTypeLong, &vTypeDouble
+	I, F, J, D                   = vTypeInt, vTypeFloat, vTypeLong, vTypeDouble
+)
+
+// simpleRules instructions only pop / push primitive values (operands are popped from right to left)
+var simpleRules = map[uint8]vTypeRule{
+	opcodes.IADD: rule(pInt, I, I), opcodes.LADD: rule(pLong, J, J), opcodes.FADD: rule(pFloat, F, F), opcodes.DADD: rule(pDouble, D, D),
+	opcodes.ISUB: rule(pInt, I, I), opcodes.LSUB: rule(pLong, J, J), opcodes.FSUB: rule(pFloat, F, F), opcodes.DSUB: rule(pDouble, D, D),
+	opcodes.IMUL: rule(pInt, I, I), opcodes.LMUL: rule(pLong, J, J), opcodes.FMUL: rule(pFloat, F, F), opcodes.DMUL: rule(pDouble, D, D),
+	opcodes.IDIV: rule(pInt, I, I), opcodes.LDIV: rule(pLong, J, J), opcodes.FDIV: rule(pFloat, F, F), opcodes.DDIV: rule(pDouble, D, D),
+	opcodes.IREM: rule(pInt, I, I), opcodes.LREM: rule(pLong, J, J), opcodes.FREM: rule(pFloat, F, F), opcodes.DREM: rule(pDouble, D, D),
+	opcodes.INEG: rule(pInt, I), opcodes.LNEG: rule(pLong, J), opcodes.FNEG: rule(pFloat, F), opcodes.DNEG: rule(pDouble, D),
+
+	opcodes.ISHL: rule(pInt, I, I), opcodes.LSHL: rule(pLong, I, J), opcodes.ISHR: rule(pInt, I, I), opcodes.LSHR: rule(pLong, I, J),
+	opcodes.IUSHR: rule(pInt, I, I), opcodes.LUSHR: rule(pLong, I, J),
+	opcodes.IAND: rule(pInt, I, I), opcodes.LAND: rule(pLong, J, J), opcodes.IOR: rule(pInt, I, I), opcodes.LOR: rule(pLong, J, J),
+	opcodes.IXOR: rule(pInt, I, I), opcodes.LXOR: rule(pLong, J, J),
+
+	opcodes.I2L: rule(pLong, I), opcodes.I2F: rule(pFloat, I), opcodes.I2D: rule(pDouble, I),
+	opcodes.L2I: rule(pInt, J), opcodes.L2F: rule(pFloat, J), opcodes.L2D: rule(pDouble, J),
+	opcodes.F2I: rule(pInt, F), opcodes.F2L: rule(pLong, F), opcodes.F2D: rule(pDouble, F),
+	opcodes.D2I: rule(pInt, D), opcodes.D2L: rule(pLong, D), opcodes.D2F: rule(pFloat, D),
+	opcodes.I2B: rule(pInt, I), opcodes.I2C: rule(pInt, I), opcodes.I2S: rule(pInt, I),
+
+	opcodes.LCMP: rule(pInt, J, J), opcodes.FCMPL: rule(pInt, F, F), opcodes.FCMPG: rule(pInt, F, F),
+	opcodes.DCMPL: rule(pInt, D, D), opcodes.DCMPG: rule(pInt, D, D),
+
+	opcodes.ICONST_M1: rule(pInt), opcodes.ICONST_0: rule(pInt), opcodes.ICONST_1: rule(pInt), opcodes.ICONST_2: rule(pInt),
+	opcodes.ICONST_3: rule(pInt), opcodes.ICONST_4: rule(pInt), opcodes.ICONST_5: rule(pInt),
+	opcodes.LCONST_0: rule(pLong), opcodes.LCONST_1: rule(pLong),
+	opcodes.FCONST_0: rule(pFloat), opcodes.FCONST_1: rule(pFloat), opcodes.FCONST_2: rule(pFloat),
+	opcodes.DCONST_0: rule(pDouble), opcodes.DCONST_1: rule(pDouble),
+	opcodes.BIPUSH: rule(pInt), opcodes.SIPUSH: rule(pInt),
+}
+
+// primitive array element type by xaload / xastore opcode (baload: byte[] or boolean[])
+var arrayLoadTypes = map[uint8]string{
+	opcodes.IALOAD: "[I", opcodes.LALOAD: "[J", opcodes.FALOAD: "[F", opcodes.DALOAD: "[D",
+	opcodes.BALOAD: "[B", opcodes.CALOAD: "[C", opcodes.SALOAD: "[S",
+}
+
+var arrayStoreTypes = map[uint8]string{
+	opcodes.IASTORE: "[I", opcodes.LASTORE: "[J", opcodes.FASTORE: "[F", opcodes.DASTORE: "[D",
+	opcodes.BASTORE: "[B", opcodes.CASTORE: "[C", opcodes.SASTORE: "[S",
+}
+
+// newarray atype -> array type
+var newArrayTypes = map[int]string{4: "[Z", 5: "[C", 6: "[F", 7: "[D", 8: "[B", 9: "[S", 10: "[I", 11: "[J"}
+
+// loadStoreType xload / xstore (index form and _n form) -> value type, slot index
+func loadStoreType(inst *vInstruction) (vType, int) {
+	types := []vType{vTypeInt, vTypeLong, vTypeFloat, vTypeDouble, vTypeObject}
+	op := inst.opcode
+	switch {
+	case op >= opcodes.ILOAD && op <= opcodes.ALOAD:
+		return types[op-opcodes.ILOAD], inst.index
+	case op >= opcodes.ILOAD_0 && op <= opcodes.ALOAD_3:
+		return types[(op-opcodes.ILOAD_0)/4], int(op-opcodes.ILOAD_0) % 4
+	case op >= opcodes.ISTORE && op <= opcodes.ASTORE:
+		return types[op-opcodes.ISTORE], inst.index
+	default: // ISTORE_0 ~ ASTORE_3
+		return types[(op-opcodes.ISTORE_0)/4], int(op-opcodes.ISTORE_0) % 4
+	}
+}
+
+func (v *methodVerifier) execute(inst *vInstruction, f *vFrame) bool {
+	op := inst.opcode
+
+	if r, ok := simpleRules[op]; ok {
+		for i := len(r.pops) - 1; i >= 0; i-- {
+			v.pop(f, r.pops[i])
+		}
+		v.push(f, *r.push)
+		return true
+	}
+
+	switch {
+	// ---------------- loads & stores ----------------
+	case op >= opcodes.ILOAD && op <= opcodes.ALOAD, op >= opcodes.ILOAD_0 && op <= opcodes.ALOAD_3:
+		t, index := loadStoreType(inst)
+		if t.kind == vRef {
+			// aload: any reference, including uninitialized
+			v.checkLocalIndex(index, 1)
+			if !f.locals[index].isReference() {
+				v.fail("Bad local variable type: local %d is %s, expected reference", index, f.locals[index])
+			}
+			v.push(f, f.locals[index])
+		} else {
+			v.load(f, index, t)
+			v.push(f, t)
+		}
+		return true
+
+	case op >= opcodes.ISTORE && op <= opcodes.ASTORE, op >= opcodes.ISTORE_0 && op <= opcodes.ASTORE_3:
+		t, index := loadStoreType(inst)
+		if t.kind == vRef {
+			// astore: reference or returnAddress (finally subroutine)
+			value := v.popEntry(f)
+			if !value.isReference() && value.kind != vReturnAddress {
+				v.fail("Bad type on operand stack: expected reference, found %s", value)
+			}
+			v.store(f, index, value)
+		} else {
+			v.pop(f, t)
+			v.store(f, index, t)
+		}
+		return true
+
+	case op == opcodes.IINC:
+		v.load(f, inst.index, vTypeInt)
+		return true
+
+	// ---------------- arrays ----------------
+	case arrayLoadTypes[op] != "":
+		v.pop(f, vTypeInt)
+		v.popPrimitiveArray(f, arrayLoadTypes[op])
+		v.push(f, vTypeOfDescriptor(arrayLoadTypes[op][1:]))
+		return true
+
+	case arrayStoreTypes[op] != "":
+		v.pop(f, vTypeOfDescriptor(arrayStoreTypes[op][1:]))
+		v.pop(f, vTypeInt)
+		v.popPrimitiveArray(f, arrayStoreTypes[op])
+		return true
+	}
+
+	switch op {
+	case opcodes.NOP:
+	case opcodes.ACONST_NULL:
+		v.push(f, vTypeNull)
+	case opcodes.LDC, opcodes.LDC_W, opcodes.LDC2_W:
+		v.push(f, v.ldcType(inst))
+
+	case opcodes.AALOAD:
+		v.pop(f, vTypeInt)
+		array := v.popArray(f)
+		if array.kind == vNull {
+			v.push(f, vTypeNull)
+		} else if component := array.name[1:]; isPrimitiveDescriptor(component) {
+			v.fail("Bad type on operand stack: aaload on %s", array)
+		} else {
+			v.push(f, vTypeOfDescriptor(component))
+		}
+	case opcodes.AASTORE:
+		v.popReference(f)
+		v.pop(f, vTypeInt)
+		if array := v.popArray(f); array.kind != vNull && isPrimitiveDescriptor(array.name[1:]) {
+			v.fail("Bad type on operand stack: aastore on %s", array)
+		}
+	case opcodes.ARRAYLENGTH:
+		v.popArray(f)
+		v.push(f, vTypeInt)
+	case opcodes.NEWARRAY:
+		arrayType, ok := newArrayTypes[inst.value]
+		if !ok {
+			v.fail("Illegal newarray type %d", inst.value)
+		}
+		v.pop(f, vTypeInt)
+		v.push(f, vRefType(arrayType))
+	case opcodes.ANEWARRAY:
+		className := v.classRef(inst.index).className
+		if strings.Count(className, "[") >= 255 {
+			v.fail("Array with too many dimensions")
+		}
+		v.pop(f, vTypeInt)
+		v.push(f, arrayOf(className))
+	case opcodes.MULTIANEWARRAY:
+		className := v.classRef(inst.index).className
+		if inst.value < 1 || !strings.HasPrefix(className, strings.Repeat("[", inst.value)) {
+			v.fail("Illegal dimension %d of multianewarray %s", inst.value, className)
+		}
+		for i := 0; i < inst.value; i++ {
+			v.pop(f, vTypeInt)
+		}
+		v.push(f, vRefType(className))
+
+	// ---------------- stack ----------------
+	case opcodes.POP:
+		v.checkCategory1(f, 1)
+		v.popEntry(f)
+	case opcodes.POP2:
+		v.checkNotSplit(f, 2)
+		v.popEntry(f)
+		v.popEntry(f)
+	case opcodes.DUP:
+		v.checkCategory1(f, 1)
+		v.dupInsert(f, 1, 1)
+	case opcodes.DUP_X1:
+		v.checkCategory1(f, 2)
+		v.dupInsert(f, 1, 2)
+	case opcodes.DUP_X2:
+		v.checkCategory1(f, 1)
+		v.checkNotSplit(f, 3)
+		v.dupInsert(f, 1, 3)
+	case opcodes.DUP2:
+		v.checkNotSplit(f, 2)
+		v.dupInsert(f, 2, 2)
+	case opcodes.DUP2_X1:
+		v.checkNotSplit(f, 2)
+		v.checkNotSplit(f, 3)
+		v.dupInsert(f, 2, 3)
+	case opcodes.DUP2_X2:
+		v.checkNotSplit(f, 2)
+		v.checkNotSplit(f, 4)
+		v.dupInsert(f, 2, 4)
+	case opcodes.SWAP:
+		v.checkCategory1(f, 2)
+		n := len(f.stack)
+		f.stack[n-1], f.stack[n-2] = f.stack[n-2], f.stack[n-1]
+
+	// ---------------- branches ----------------
+	case opcodes.IFEQ, opcodes.IFNE, opcodes.IFLT, opcodes.IFGE, opcodes.IFGT, opcodes.IFLE:
+		v.pop(f, vTypeInt)
+		v.checkBranch(f, inst.targets[0])
+	case opcodes.IF_ICMPEQ, opcodes.IF_ICMPNE, opcodes.IF_ICMPLT, opcodes.IF_ICMPGE, opcodes.IF_ICMPGT, opcodes.IF_ICMPLE:
+		v.pop(f, vTypeInt)
+		v.pop(f, vTypeInt)
+		v.checkBranch(f, inst.targets[0])
+	case opcodes.IF_ACMPEQ, opcodes.IF_ACMPNE:
+		v.popReference(f)
+		v.popReference(f)
+		v.checkBranch(f, inst.targets[0])
+	case opcodes.IFNULL, opcodes.IFNONNULL:
+		v.popReference(f)
+		v.checkBranch(f, inst.targets[0])
+	case opcodes.GOTO, opcodes.GOTO_W:
+		v.checkBranch(f, inst.targets[0])
+		return false
+	case opcodes.TABLESWITCH, opcodes.LOOKUPSWITCH:
+		v.pop(f, vTypeInt)
+		for _, target := range inst.targets {
+			v.checkBranch(f, target)
+		}
+		return false
+	case opcodes.JSR, opcodes.JSR_W, opcodes.RET:
+		// jsr / ret are not allowed in type checking (class file >= 51),
+		// javac stopped emitting them since Java 6 (finally is inlined)
+		if !v.inference {
+			v.fail("jsr / ret are not supported by type checking verifier (class file version %s)", v.class.versionString())
+		}
+		if op == opcodes.RET {
+			v.ret(inst, f)
+		} else {
+			v.jsr(inst, f)
+		}
+		return false
+
+	// ---------------- return & throw ----------------
+	case opcodes.IRETURN, opcodes.LRETURN, opcodes.FRETURN, opcodes.DRETURN, opcodes.ARETURN:
+		if v.returnType == "V" {
+			v.fail("Method expects no return value")
+		}
+		expected := vTypeOfDescriptor(v.returnType)
+		if returnOpcodeType(op).kind != expected.kind {
+			v.fail("Wrong return type in function: expected %s", expected)
+		}
+		v.pop(f, expected)
+		return false
+	case opcodes.RETURN:
+		if v.returnType != "V" {
+			v.fail("Method expects a return value")
+		}
+		if v.method.name == "<init>" && f.flagThisUninit {
+			v.fail("Constructor must call super() or this() before return")
+		}
+		return false
+	case opcodes.ATHROW:
+		v.pop(f, vTypeThrowable)
+		return false
+
+	// ---------------- fields ----------------
+	case opcodes.GETSTATIC:
+		v.push(f, vTypeOfDescriptor(v.fieldRef(inst.index).descriptor))
+	case opcodes.PUTSTATIC:
+		v.pop(f, vTypeOfDescriptor(v.fieldRef(inst.index).descriptor))
+	case opcodes.GETFIELD:
+		ref := v.fieldRef(inst.index)
+		v.pop(f, vTypeOfClassName(ref.className))
+		v.push(f, vTypeOfDescriptor(ref.descriptor))
+	case opcodes.PUTFIELD:
+		ref := v.fieldRef(inst.index)
+		v.pop(f, vTypeOfDescriptor(ref.descriptor))
+		// constructor may assign its own fields before super() (ex: this$0 of inner class)
+		if top := f.stack; len(top) > 0 && top[len(top)-1].kind == vUninitThis && ref.className == v.class.name {
+			v.popEntry(f)
+		} else {
+			v.pop(f, vTypeOfClassName(ref.className))
+		}
+
+	// ---------------- invoke ----------------
+	case opcodes.INVOKEVIRTUAL, opcodes.INVOKESPECIAL, opcodes.INVOKESTATIC, opcodes.INVOKEINTERFACE:
+		v.invoke(inst, f)
+	case opcodes.INVOKEDYNAMIC:
+		if v.class.majorVersion < classfile.FEATURE_INVOKEDYNAMIC {
+			v.fail("invokedynamic is not allowed in class file version %s", v.class.versionString())
+		}
+		ref, ok := v.constant(inst.index).(*InvokeDynamicRef)
+		if !ok {
+			v.fail("Illegal constant pool index %d: expected InvokeDynamic", inst.index)
+		}
+		v.popArgsPushReturn(f, ref.descriptor)
+
+	// ---------------- objects ----------------
+	case opcodes.NEW:
+		className := v.classRef(inst.index).className
+		if strings.HasPrefix(className, "[") {
+			v.fail("Illegal use of new with array class %s", className)
+		}
+		// same uninitialized type must not already exist (new inside a loop without <init>)
+		uninit := vUninitType(inst.pc)
+		for i, t := range f.stack {
+			if t == uninit {
+				f.stack[i] = vTypeTop
+			}
+		}
+		for i, t := range f.locals {
+			if t == uninit {
+				f.locals[i] = vTypeTop
+			}
+		}
+		v.push(f, uninit)
+	case opcodes.CHECKCAST:
+		className := v.classRef(inst.index).className
+		v.popReference(f)
+		v.push(f, vTypeOfClassName(className))
+	case opcodes.INSTANCEOF:
+		v.classRef(inst.index)
+		v.popReference(f)
+		v.push(f, vTypeInt)
+	case opcodes.MONITORENTER, opcodes.MONITOREXIT:
+		v.popReference(f)
+
+	default:
+		v.fail("Illegal instruction")
+	}
+	return true
+}
+
+// popPrimitiveArray pop array of given type (or null), baload / bastore accept boolean[] as well
+func (v *methodVerifier) popPrimitiveArray(f *vFrame, arrayType string) {
+	array := v.popArray(f)
+	if array.kind == vNull || array.name == arrayType || (arrayType == "[B" && array.name == "[Z") {
+		return
+	}
+	v.fail("Bad type on operand stack: expected %s, found %s", arrayType, array)
+}
+
+// dupInsert copy top `count` entries and insert them under top `depth` entries
+// dup: (1, 1), dup_x1: (1, 2), dup2_x2: (2, 4) ...
+func (v *methodVerifier) dupInsert(f *vFrame, count, depth int) {
+	n := len(f.stack)
+	copied := append([]vType(nil), f.stack[n-count:]...)
+	stack := append([]vType(nil), f.stack[:n-depth]...)
+	stack = append(stack, copied...)
+	stack = append(stack, f.stack[n-depth:]...)
+	f.stack = stack
+	if len(f.stack) > int(v.method.maxStack) {
+		v.fail("Exceeded max stack size %d", v.method.maxStack)
+	}
+}
+
+func returnOpcodeType(op uint8) vType {
+	switch op {
+	case opcodes.IRETURN:
+		return vTypeInt
+	case opcodes.LRETURN:
+		return vTypeLong
+	case opcodes.FRETURN:
+		return vTypeFloat
+	case opcodes.DRETURN:
+		return vTypeDouble
+	default:
+		return vTypeObject
+	}
+}
+
+// ============================================================
+// invoke
+// ============================================================
+
+func (v *methodVerifier) invoke(inst *vInstruction, f *vFrame) {
+	op := inst.opcode
+	className, name, descriptor := v.methodRef(inst)
+
+	if name == "<clinit>" || (name == "<init>" && op != opcodes.INVOKESPECIAL) {
+		v.fail("Illegal call to internal method %s", name)
+	}
+	if !isValidMethodDescriptor(descriptor) {
+		v.fail("Illegal method descriptor %s", descriptor)
+	}
+
+	md := parseMethodDescriptor(descriptor)
+	if op == opcodes.INVOKEINTERFACE {
+		argSlots := 1
+		for _, paramType := range md.parameterTypes {
+			argSlots += int(slotSizeOf(paramType))
+		}
+		if inst.value != argSlots {
+			v.fail("Inconsistent args count operand in invokeinterface")
+		}
+	}
+
+	for i := len(md.parameterTypes) - 1; i >= 0; i-- {
+		v.pop(f, vTypeOfDescriptor(md.parameterTypes[i]))
+	}
+
+	switch {
+	case op == opcodes.INVOKESTATIC:
+	case name == "<init>":
+		v.invokeInit(f, className)
+	case op == opcodes.INVOKEINTERFACE:
+		// interface type is treated as Object
+		if receiver := v.popReference(f); receiver.kind == vUninit || receiver.kind == vUninitThis {
+			v.fail("Bad type on operand stack: method invoked on %s", receiver)
+		}
+	case op == opcodes.INVOKESPECIAL:
+		// super.m() / private m(): receiver must be current class
+		v.pop(f, vTypeOfClassName(v.class.name))
+	default:
+		v.pop(f, vTypeOfClassName(className))
+	}
+
+	if md.returnType != "V" {
+		v.push(f, vTypeOfDescriptor(md.returnType))
+	}
+}
+
+// invokeInit invokespecial <init>: uninitialized -> initialized in whole frame
+//   - uninitializedThis: this() or super() in constructor
+//   - uninitialized(pc): new C; dup; ...; invokespecial C.<init>
+func (v *methodVerifier) invokeInit(f *vFrame, className string) {
+	receiver := v.popReference(f)
+	switch receiver.kind {
+	case vUninitThis:
+		if className != v.class.name && className != v.class.superClassName {
+			v.fail("Bad <init> method call: %s is not current class or its super class", className)
+		}
+		f.replaceUninit(receiver, vTypeOfClassName(v.class.name))
+	case vUninit:
+		newInst := v.instructionAt(receiver.offset)
+		if newInst == nil || newInst.opcode != opcodes.NEW {
+			v.fail("Bad <init> method call: %s does not point to new", receiver)
+		}
+		if newClass := v.classRef(newInst.index).className; newClass != className {
+			v.fail("Bad <init> method call: %s on uninitialized %s", className, newClass)
+		}
+		f.replaceUninit(receiver, vTypeOfClassName(className))
+	default:
+		v.fail("Bad type on operand stack: <init> invoked on initialized object %s", receiver)
+	}
+}
+
+func (v *methodVerifier) popArgsPushReturn(f *vFrame, descriptor string) {
+	if !isValidMethodDescriptor(descriptor) {
+		v.fail("Illegal method descriptor %s", descriptor)
+	}
+	md := parseMethodDescriptor(descriptor)
+	for i := len(md.parameterTypes) - 1; i >= 0; i-- {
+		v.pop(f, vTypeOfDescriptor(md.parameterTypes[i]))
+	}
+	if md.returnType != "V" {
+		v.push(f, vTypeOfDescriptor(md.returnType))
+	}
+}
+
+// ============================================================
+// constant pool access (checked)
+// ============================================================
+
+func (v *methodVerifier) constant(index int) Constant {
+	consts := v.class.constantPool.consts
+	if index <= 0 || index >= len(consts) || consts[index] == nil {
+		v.fail("Illegal constant pool index %d", index)
+	}
+	return consts[index]
+}
+
+func (v *methodVerifier) classRef(index int) *ClassRef {
+	ref, ok := v.constant(index).(*ClassRef)
+	if !ok {
+		v.fail("Illegal constant pool index %d: expected Class", index)
+	}
+	return ref
+}
+
+func (v *methodVerifier) fieldRef(index int) *FieldRef {
+	ref, ok := v.constant(index).(*FieldRef)
+	if !ok {
+		v.fail("Illegal constant pool index %d: expected Fieldref", index)
+	}
+	if !isValidFieldDescriptor(ref.descriptor) {
+		v.fail("Illegal field descriptor %s", ref.descriptor)
+	}
+	return ref
+}
+
+// methodRef invokeinterface need InterfaceMethodref, others accept Methodref
+// (invokestatic / invokespecial on interface method is allowed since Java 8)
+func (v *methodVerifier) methodRef(inst *vInstruction) (className, name, descriptor string) {
+	switch ref := v.constant(inst.index).(type) {
+	case *MethodRef:
+		if inst.opcode != opcodes.INVOKEINTERFACE {
+			return ref.className, ref.name, ref.descriptor
+		}
+	case *InterfaceMethodRef:
+		if inst.opcode != opcodes.INVOKEVIRTUAL {
+			return ref.className, ref.name, ref.descriptor
+		}
+	}
+	v.fail("Illegal constant pool index %d: wrong method reference type for %s", inst.index, opcodeName(inst.opcode))
+	return
+}
+
+// ldcType type of loadable constant
+func (v *methodVerifier) ldcType(inst *vInstruction) vType {
+	c := v.constant(inst.index)
+	if inst.opcode == opcodes.LDC2_W {
+		switch c := c.(type) {
+		case int64:
+			return vTypeLong
+		case float64:
+			return vTypeDouble
+		case *DynamicConstantRef:
+			if t := vTypeOfDescriptor(c.descriptor); t.isCategory2() {
+				return t
+			}
+		}
+		v.fail("Illegal type of constant for ldc2_w")
+	}
+
+	switch c := c.(type) {
+	case int32:
+		return vTypeInt
+	case float32:
+		return vTypeFloat
+	case string:
+		return vTypeString
+	case *ClassRef:
+		if v.class.majorVersion < classfile.JAVA_5 {
+			break
+		}
+		return vTypeClass
+	case *MethodTypeRef:
+		return vRefType("java/lang/invoke/MethodType")
+	case *MethodHandleRef:
+		return vRefType("java/lang/invoke/MethodHandle")
+	case *DynamicConstantRef:
+		if t := vTypeOfDescriptor(c.descriptor); !t.isCategory2() {
+			return t
+		}
+	}
+	v.fail("Illegal type of constant for %s", opcodeName(inst.opcode))
+	return vTypeTop
+}
+
+// ============================================================
+// descriptor validation (hostile class file may contain anything)
+// ============================================================
+
+func isValidFieldDescriptor(descriptor string) bool {
+	end := validFieldTypeEnd(descriptor, 0)
+	return end == len(descriptor)
+}
+
+func isValidMethodDescriptor(descriptor string) bool {
+	if len(descriptor) < 3 || descriptor[0] != '(' {
+		return false
+	}
+	i := 1
+	for i < len(descriptor) && descriptor[i] != ')' {
+		if i = validFieldTypeEnd(descriptor, i); i < 0 {
+			return false
+		}
+	}
+	if i >= len(descriptor) {
+		return false
+	}
+	i++ // ')'
+	if descriptor[i:] == "V" {
+		return true
+	}
+	return validFieldTypeEnd(descriptor, i) == len(descriptor)
+}
+
+// validFieldTypeEnd return end index of field type starting at i, -1 if invalid
+func validFieldTypeEnd(descriptor string, i int) int {
+	dimensions := 0
+	for i < len(descriptor) && descriptor[i] == '[' {
+		dimensions++
+		i++
+	}
+	if dimensions > 255 || i >= len(descriptor) {
+		return -1
+	}
+	switch descriptor[i] {
+	case 'B', 'C', 'D', 'F', 'I', 'J', 'S', 'Z':
+		return i + 1
+	case 'L':
+		end := strings.IndexByte(descriptor[i:], ';')
+		if end <= 1 {
+			return -1
+		}
+		return i + end + 1
+	default:
+		return -1
+	}
+}
+
+func slotSizeOf(descriptor string) uint {
+	if descriptor == "J" || descriptor == "D" {
+		return 2
+	}
+	return 1
+}
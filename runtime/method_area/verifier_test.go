@@ -0,0 +1,115 @@
+package method_area
+
+import (
+	"github.com/Johnny1110/gogo_jvm/common"
+	"github.com/Johnny1110/gogo_jvm/instructions/base/opcodes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testClassPath = "../../test/class"
+
+// javac 編譯的測試 class 全部都要通過驗證
+func Test_verify_testClasses(t *testing.T) {
+	defer SetVerifyMode("remote")
+	assert.NoError(t, SetVerifyMode("all"))
+
+	files, _ := filepath.Glob(filepath.Join(testClassPath, "*.class"))
+	assert.NotEmpty(t, files)
+
+	loader := NewClassLoader(testClassPath)
+	for _, file := range files {
+		className := strings.TrimSuffix(filepath.Base(file), ".class")
+		assert.NotPanics(t, func() { loader.LoadClass(className, false) }, className)
+	}
+}
+
+func Test_typeCheckMethod_badCode(t *testing.T) {
+	if _, err := os.Stat(testClassPath); err != nil {
+		t.Skip("test classes not found")
+	}
+	class := NewClassLoader(testClassPath).LoadClass("java/lang/Object", false)
+
+	cases := []struct {
+		name       string
+		descriptor string
+		maxStack   uint16
+		maxLocals  uint16
+		code       []byte
+		message    string
+	}{
+		{"wrongReturn", "()I", 1, 0, []byte{opcodes.FCONST_0, opcodes.IRETURN}, "is not assignable to integer"},
+		{"underflow", "()V", 1, 0, []byte{opcodes.POP, opcodes.RETURN}, "Attempt to pop empty stack"},
+		{"maxStack", "()V", 1, 0, []byte{opcodes.ICONST_0, opcodes.ICONST_1, opcodes.RETURN}, "Exceeded max stack size"},
+		{"maxLocals", "()V", 1, 1, []byte{opcodes.ICONST_0, opcodes.ISTORE_1, opcodes.RETURN}, "Illegal local variable number 1"},
+		{"fallOff", "()V", 1, 0, []byte{opcodes.NOP}, "Falling off the end of the code"},
+		{"badTarget", "()V", 1, 0, []byte{opcodes.GOTO, 0x00, 0x02, opcodes.RETURN}, "Illegal target of jump or branch"},
+		{"noStackMap", "()V", 1, 0, []byte{opcodes.GOTO, 0x00, 0x03, opcodes.RETURN}, "Expecting a stack map frame"},
+		{"splitLong", "()V", 2, 0, []byte{opcodes.LCONST_0, opcodes.POP, opcodes.RETURN}, "expected category 1 value"},
+		{"uninitLocal", "()I", 1, 1, []byte{opcodes.ILOAD_0, opcodes.IRETURN}, "Bad local variable type"},
+	}
+	for _, c := range cases {
+		method := &Method{accessFlags: common.ACC_STATIC, class: class, name: c.name, descriptor: c.descriptor,
+			maxStack: c.maxStack, maxLocals: c.maxLocals, code: c.code}
+		failure := typeCheckMethod(class, method)
+		if assert.NotNil(t, failure, c.name) {
+			assert.Contains(t, failure.message, c.message, c.name)
+		}
+	}
+}
+
+func Test_inferMethod(t *testing.T) {
+	if _, err := os.Stat(testClassPath); err != nil {
+		t.Skip("test classes not found")
+	}
+	class := NewClassLoader(testClassPath).LoadClass("java/lang/Object", false)
+
+	// static int f(int x) { try { return x > 0 ? x * 2 : -1; } finally { x = 0; } } (jsr / ret)
+	finally := []byte{
+		opcodes.ILOAD_0, opcodes.IFLE, 0, 12,
+		opcodes.ILOAD_0, opcodes.ICONST_2, opcodes.IMUL, opcodes.ISTORE_1, opcodes.JSR, 0, 18, opcodes.ILOAD_1, opcodes.IRETURN,
+		opcodes.ICONST_M1, opcodes.ISTORE_1, opcodes.JSR, 0, 11, opcodes.ILOAD_1, opcodes.IRETURN,
+		opcodes.ASTORE_2, opcodes.JSR, 0, 5, opcodes.ALOAD_2, opcodes.ATHROW,
+		opcodes.ASTORE_3, opcodes.ICONST_0, opcodes.ISTORE_0, opcodes.RET, 3,
+	}
+	// subroutine overwrite local 1 with float
+	badFinally := append([]byte(nil), finally...)
+	badFinally[27], badFinally[28] = opcodes.FCONST_0, opcodes.FSTORE_1
+
+	cases := []struct {
+		name      string
+		maxStack  uint16
+		maxLocals uint16
+		code      []byte
+		message   string // empty: pass
+	}{
+		{"finally", 2, 4, finally, ""},
+		{"badFinally", 2, 4, badFinally, "local 1 is float, expected integer"},
+		// loop: i = 0; while (i < 10) i++; return i
+		{"loop", 2, 1, []byte{opcodes.ICONST_0, opcodes.ISTORE_0, opcodes.ILOAD_0, opcodes.BIPUSH, 10, opcodes.IF_ICMPGE, 0, 9,
+			opcodes.IINC, 0, 1, opcodes.GOTO, 0xFF, 0xF7, opcodes.ILOAD_0, opcodes.IRETURN}, ""},
+		// push in loop: stack height differs at loop head
+		{"stackHeight", 2, 0, []byte{opcodes.ICONST_0, opcodes.GOTO, 0xFF, 0xFF}, "Inconsistent stack height"},
+		// local 0 is int or float after merge -> unusable
+		{"mergeToTop", 1, 1, []byte{opcodes.ICONST_0, opcodes.ISTORE_0, opcodes.ICONST_0, opcodes.IFEQ, 0, 5,
+			opcodes.FCONST_0, opcodes.FSTORE_0, opcodes.ILOAD_0, opcodes.IRETURN}, "local 0 is top"},
+		{"retWithoutJsr", 1, 1, []byte{opcodes.ICONST_0, opcodes.ISTORE_0, opcodes.RET, 0}, "expected returnAddress"},
+	}
+	for _, c := range cases {
+		method := &Method{accessFlags: common.ACC_STATIC, class: class, name: c.name, descriptor: "()I",
+			maxStack: c.maxStack, maxLocals: c.maxLocals, code: c.code}
+		if c.name == "finally" || c.name == "badFinally" {
+			method.descriptor = "(I)I"
+			method.exceptionTable = ExceptionTable{{StartPC: 0, EndPC: 20, HandlerPC: 20}}
+		}
+		failure := inferMethod(class, method)
+		if c.message == "" {
+			assert.Nil(t, failure, c.name)
+		} else if assert.NotNil(t, failure, c.name) {
+			assert.Contains(t, failure.message, c.message, c.name)
+		}
+	}
+}
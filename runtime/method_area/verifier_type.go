@@ -0,0 +1,215 @@
+package method_area
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================================
+// Verification Type (JVMS 4.10.1.2)
+// ============================================================
+//
+//	               top
+//	      ┌─────────┴──────────┐
+//	 oneWord                twoWord
+//	 ┌───┬─┴─────┐          ┌──┴───┐
+//	int float reference    long  double
+//	          ┌───┴──────────┐
+//	   uninitialized     Object (class / array)
+//	   ┌───┴────────┐        │
+//	uninitThis uninit(pc)   null
+//
+// boolean, byte, char, short are int.
+// long & double take 2 entries in locals / operand stack: [long, top], top is the second half.
+
+type vKind uint8
+
+const (
+	vTop vKind = iota
+	vInt
+	vFloat
+	vLong
+	vDouble
+	vNull
+	vUninitThis
+	vUninit        // new instruction at `offset`, before <init> called
+	vRef           // class or array, `name` is internal name (java/lang/String) or array descriptor ([I)
+	vReturnAddress // jsr return address (type inference verifier only)
+)
+
+type vType struct {
+	kind   vKind
+	name   string // vRef
+	offset int    // vUninit: pc of `new`, vReturnAddress: pc of jsr target
+}
+
+var (
+	vTypeTop        = vType{kind: vTop}
+	vTypeInt        = vType{kind: vInt}
+	vTypeFloat      = vType{kind: vFloat}
+	vTypeLong       = vType{kind: vLong}
+	vTypeDouble     = vType{kind: vDouble}
+	vTypeNull       = vType{kind: vNull}
+	vTypeUninitThis = vType{kind: vUninitThis}
+	vTypeObject     = vRefType("java/lang/Object")
+	vTypeString     = vRefType("java/lang/String")
+	vTypeClass      = vRefType("java/lang/Class")
+	vTypeThrowable  = vRefType("java/lang/Throwable")
+)
+
+func vRefType(name string) vType { return vType{kind: vRef, name: name} }
+
+func vUninitType(offset int) vType { return vType{kind: vUninit, offset: offset} }
+
+// isCategory2 long / double
+func (t vType) isCategory2() bool { return t.kind == vLong || t.kind == vDouble }
+
+// isReference null / class / array / uninitialized
+func (t vType) isReference() bool {
+	return t.kind == vNull || t.kind == vRef || t.kind == vUninit || t.kind == vUninitThis
+}
+
+func (t vType) isArray() bool { return t.kind == vRef && strings.HasPrefix(t.name, "[") }
+
+func (t vType) String() string {
+	switch t.kind {
+	case vTop:
+		return "top"
+	case vInt:
+		return "integer"
+	case vFloat:
+		return "float"
+	case vLong:
+		return "long"
+	case vDouble:
+		return "double"
+	case vNull:
+		return "null"
+	case vUninitThis:
+		return "uninitializedThis"
+	case vUninit:
+		return fmt.Sprintf("uninitialized(%d)", t.offset)
+	case vReturnAddress:
+		return fmt.Sprintf("returnAddress(%d)", t.offset)
+	default:
+		return "'" + t.name + "'"
+	}
+}
+
+// vTypeOfDescriptor field descriptor -> verification type
+// Z B C S I -> int, Ljava/lang/String; -> java/lang/String, [I -> [I
+func vTypeOfDescriptor(descriptor string) vType {
+	switch descriptor[0] {
+	case 'Z', 'B', 'C', 'S', 'I':
+		return vTypeInt
+	case 'F':
+		return vTypeFloat
+	case 'J':
+		return vTypeLong
+	case 'D':
+		return vTypeDouble
+	case 'L':
+		return vRefType(descriptor[1 : len(descriptor)-1])
+	default: // '['
+		return vRefType(descriptor)
+	}
+}
+
+// vTypeOfClassName CONSTANT_Class name -> verification type (array class name is descriptor already)
+func vTypeOfClassName(className string) vType {
+	return vRefType(className)
+}
+
+// arrayOf component class name -> array type, ex: java/lang/String -> [Ljava/lang/String;, [I -> [[I
+func arrayOf(className string) vType {
+	if strings.HasPrefix(className, "[") {
+		return vRefType("[" + className)
+	}
+	return vRefType("[L" + className + ";")
+}
+
+// ============================================================
+// assignability (JVMS 4.10.1.2 isAssignable)
+// ============================================================
+
+// isAssignable value of type `from` can be used as type `to`
+func (v *methodVerifier) isAssignable(from, to vType) bool {
+	if from == to || to.kind == vTop {
+		return true
+	}
+	switch to.kind {
+	case vRef:
+		if from.kind == vNull {
+			return true
+		}
+		return from.kind == vRef && v.isJavaAssignable(from.name, to.name)
+	default:
+		return false
+	}
+}
+
+// isJavaAssignable reference type assignability by class hierarchy
+// interfaces are treated as java/lang/Object (checked at runtime by invokeinterface / checkcast),
+// class which can not be loaded is assumed assignable: loading error will be thrown when it is really used.
+func (v *methodVerifier) isJavaAssignable(from, to string) bool {
+	if from == to || to == "java/lang/Object" {
+		return true
+	}
+
+	if strings.HasPrefix(to, "[") {
+		if !strings.HasPrefix(from, "[") {
+			return false
+		}
+		fromComponent, toComponent := from[1:], to[1:]
+		if isPrimitiveDescriptor(fromComponent) || isPrimitiveDescriptor(toComponent) {
+			return fromComponent == toComponent
+		}
+		return v.isJavaAssignable(componentClassName(fromComponent), componentClassName(toComponent))
+	}
+
+	toClass := v.tryLoadClass(to)
+	if toClass == nil || toClass.IsInterface() {
+		return true
+	}
+	if strings.HasPrefix(from, "[") {
+		// array only assignable to Object, Cloneable, Serializable (interfaces are handled above)
+		return false
+	}
+	fromClass := v.tryLoadClass(from)
+	if fromClass == nil {
+		return true
+	}
+	return fromClass == toClass || fromClass.IsSubClassOf(toClass)
+}
+
+// tryLoadClass load class for assignability check, nil if can not be loaded
+func (v *methodVerifier) tryLoadClass(name string) (class *Class) {
+	if name == v.class.name {
+		return v.class
+	}
+	loader := v.class.loader
+	if loader == nil {
+		return nil
+	}
+	if loaded := loader.FindLoadedClass(name); loaded != nil {
+		return loaded
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			class = nil
+		}
+	}()
+	return loader.LoadClass(name, false)
+}
+
+func isPrimitiveDescriptor(descriptor string) bool {
+	return len(descriptor) == 1
+}
+
+// componentClassName Ljava/lang/String; -> java/lang/String, [I -> [I
+func componentClassName(descriptor string) string {
+	if descriptor[0] == 'L' {
+		return descriptor[1 : len(descriptor)-1]
+	}
+	return descriptor
+}
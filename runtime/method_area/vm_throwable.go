@@ -0,0 +1,71 @@
+package method_area
+
+import (
+	"github.com/Johnny1110/gogo_jvm/common"
+)
+
+// ============================================================
+// VM Throwable Classes
+// ============================================================
+// exceptions raised by VM itself (NullPointerException, NoSuchMethodError, StackOverflowError ...)
+// must be instantiated even if rt classes are not in classpath,
+// missing one is defined as synthetic class with its JDK super class, so java code can still catch it by super type.
+
+// vmThrowableSupers JDK hierarchy of VM raised throwables: class -> super class
+var vmThrowableSupers = map[string]string{
+	"java/lang/Exception":                      "java/lang/Throwable",
+	"java/lang/RuntimeException":               "java/lang/Exception",
+	"java/lang/ArithmeticException":            "java/lang/RuntimeException",
+	"java/lang/ArrayStoreException":            "java/lang/RuntimeException",
+	"java/lang/ClassCastException":             "java/lang/RuntimeException",
+	"java/lang/IllegalArgumentException":       "java/lang/RuntimeException",
+	"java/lang/IllegalMonitorStateException":   "java/lang/RuntimeException",
+	"java/lang/IndexOutOfBoundsException":      "java/lang/RuntimeException",
+	"java/lang/ArrayIndexOutOfBoundsException": "java/lang/IndexOutOfBoundsException",
+	"java/lang/NegativeArraySizeException":     "java/lang/RuntimeException",
+	"java/lang/NullPointerException":           "java/lang/RuntimeException",
+	"java/lang/UnsupportedOperationException":  "java/lang/RuntimeException",
+	"java/lang/SecurityException":              "java/lang/RuntimeException",
+	"java/lang/ReflectiveOperationException":   "java/lang/Exception",
+	"java/lang/ClassNotFoundException":         "java/lang/ReflectiveOperationException",
+	"java/lang/InstantiationException":         "java/lang/ReflectiveOperationException",
+	"java/lang/CloneNotSupportedException":     "java/lang/Exception",
+	"java/lang/InterruptedException":           "java/lang/Exception",
+	"java/lang/Error":                          "java/lang/Throwable",
+	"java/lang/AssertionError":                 "java/lang/Error",
+	"java/lang/VirtualMachineError":            "java/lang/Error",
+	"java/lang/InternalError":                  "java/lang/VirtualMachineError",
+	"java/lang/OutOfMemoryError":               "java/lang/VirtualMachineError",
+	"java/lang/StackOverflowError":             "java/lang/VirtualMachineError",
+	"java/lang/LinkageError":                   "java/lang/Error",
+	"java/lang/ClassCircularityError":          "java/lang/LinkageError",
+	"java/lang/ClassFormatError":               "java/lang/LinkageError",
+	"java/lang/UnsupportedClassVersionError":   "java/lang/ClassFormatError",
+	"java/lang/ExceptionInInitializerError":    "java/lang/LinkageError",
+	"java/lang/NoClassDefFoundError":           "java/lang/LinkageError",
+	"java/lang/UnsatisfiedLinkError":           "java/lang/LinkageError",
+	"java/lang/VerifyError":                    "java/lang/LinkageError",
+	"java/lang/IncompatibleClassChangeError":   "java/lang/LinkageError",
+	"java/lang/AbstractMethodError":            "java/lang/IncompatibleClassChangeError",
+	"java/lang/IllegalAccessError":             "java/lang/IncompatibleClassChangeError",
+	"java/lang/InstantiationError":             "java/lang/IncompatibleClassChangeError",
+	"java/lang/NoSuchFieldError":               "java/lang/IncompatibleClassChangeError",
+	"java/lang/NoSuchMethodError":              "java/lang/IncompatibleClassChangeError",
+	"java/lang/BootstrapMethodError":           "java/lang/LinkageError",
+}
+
+// isVMThrowableClass class can be defined by defineVMThrowableClass
+func isVMThrowableClass(name string) bool {
+	_, ok := vmThrowableSupers[name]
+	return ok
+}
+
+// defineVMThrowableClass define VM raised throwable class which is not found in classpath (synthetic)
+func (loader *ClassLoader) defineVMThrowableClass(name string) *Class {
+	superClassName := vmThrowableSupers[name]
+	// load super first, it may be synthetic as well
+	loader.LoadClass(superClassName, false)
+	builder := NewSyntheticClassBuilder(loader, name, superClassName, nil)
+	builder.Class().accessFlags = common.ACC_PUBLIC | common.ACC_SUPER | common.ACC_SYNTHETIC
+	return builder.Define()
+}
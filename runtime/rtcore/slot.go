@@ -113,3 +113,8 @@ func (s Slot) String() string {
 	}
 	return fmt.Sprintf("Num<%d>", s.Num)
 }
+
+// ReturnAddress jsr / jsr_w push it to op-stack, astore move it into local var, ret read it back.
+// it's a bytecode address (pc) inside current method, not an object,
+// so store it in Slot.Ref with its own type to tell apart from real reference.
+type ReturnAddress int
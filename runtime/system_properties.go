@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"os"
+	goruntime "runtime"
+	"sort"
+)
+
+// ============================================================
+// System Properties - System.getProperty()
+// ============================================================
+// defaults are set at startup, -D<key>=<value> override them.
+
+var systemProperties = defaultSystemProperties()
+
+func defaultSystemProperties() map[string]string {
+	props := map[string]string{
+		"java.vm.name":       "Gogo JVM",
+		"java.vm.vendor":     "gogo_jvm",
+		"java.vendor":        "gogo_jvm",
+		"java.class.version": "65.0",
+		"java.class.path":    ".",
+		"os.name":            osName(),
+		"os.arch":            goruntime.GOARCH,
+		"file.separator":     string(os.PathSeparator),
+		"path.separator":     string(os.PathListSeparator),
+		"line.separator":     "\n",
+		"java.io.tmpdir":     os.TempDir(),
+		"file.encoding":      "UTF-8",
+	}
+	if goruntime.GOOS == "windows" {
+		props["line.separator"] = "\r\n"
+	}
+	if dir, err := os.Getwd(); err == nil {
+		props["user.dir"] = dir
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		props["user.home"] = home
+	}
+	if user := os.Getenv("USER"); user != "" {
+		props["user.name"] = user
+	}
+	return props
+}
+
+// osName same value as real JVM: Linux, Mac OS X, Windows ...
+func osName() string {
+	switch goruntime.GOOS {
+	case "linux":
+		return "Linux"
+	case "darwin":
+		return "Mac OS X"
+	case "windows":
+		return "Windows"
+	default:
+		return goruntime.GOOS
+	}
+}
+
+// SetSystemProperty -D<key>=<value>
+func SetSystemProperty(key, value string) {
+	systemProperties[key] = value
+}
+
+// GetSystemProperty return value and whether key exists
+func GetSystemProperty(key string) (string, bool) {
+	value, ok := systemProperties[key]
+	return value, ok
+}
+
+// SystemPropertyNames sorted property names
+func SystemPropertyNames() []string {
+	names := make([]string, 0, len(systemProperties))
+	for name := range systemProperties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
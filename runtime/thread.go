@@ -5,18 +5,46 @@ import (
 	"github.com/Johnny1110/gogo_jvm/runtime/method_area"
 )
 
+// DEFAULT_STACK_SIZE max frames of a thread's JVM stack
 const DEFAULT_STACK_SIZE = 1024
 
+// STACK_FRAME_BYTES estimated bytes per frame, for -Xss<size> → frames (-Xss1m → 1024 frames)
+const STACK_FRAME_BYTES = 1024
+
+var defaultStackSize uint = DEFAULT_STACK_SIZE
+
+// SetDefaultStackSize -Xss, max frames of new threads
+func SetDefaultStackSize(frames uint) {
+	if frames == 0 {
+		frames = 1
+	}
+	defaultStackSize = frames
+}
+
+// StackFramesOf convert stack size in bytes to max frames
+func StackFramesOf(bytes uint64) uint {
+	return uint(bytes / STACK_FRAME_BYTES)
+}
+
 type Thread struct {
 	pc    int       // Program Counter
 	stack *JVMStack // JVM Frame Stack
 }
 
-// NewThread create new Thread
+// NewThread create new Thread, max stack depth is -Xss (or DEFAULT_STACK_SIZE)
 func NewThread() *Thread {
+	return NewThreadWithStackDepth(defaultStackSize)
+}
+
+// NewThreadWithStackDepth create new Thread with max frames of its JVM stack
+// (java.lang.Thread is not runnable in this VM yet, so there is no Thread(..., stackSize) path: only main thread and -Xss)
+func NewThreadWithStackDepth(frames uint) *Thread {
+	if frames == 0 {
+		frames = 1
+	}
 	return &Thread{
 		pc:    0,
-		stack: NewJVMStack(DEFAULT_STACK_SIZE),
+		stack: NewJVMStack(frames),
 	}
 }
 
@@ -54,6 +82,16 @@ func (t *Thread) StackDepth() uint {
 	return t.stack.Size()
 }
 
+// MaxStackDepth max frames of this thread's JVM stack
+func (t *Thread) MaxStackDepth() uint {
+	return t.stack.MaxSize()
+}
+
+// IsStackFull push one more frame will overflow, caller should throw java/lang/StackOverflowError
+func (t *Thread) IsStackFull() bool {
+	return t.stack.IsFull()
+}
+
 func (t *Thread) GetFrames() []*Frame {
 	return t.stack.GetFrames()
 }
@@ -0,0 +1,43 @@
+import java.lang.annotation.Retention;
+import java.lang.annotation.RetentionPolicy;
+
+/**
+ * TestAnnotation.java
+ * annotation 相關 attribute 的 class file 測試 (classfile/attribute_test.go)
+ *
+ * - RuntimeVisibleAnnotations: class / field / method
+ * - RuntimeVisibleParameterAnnotations: method parameter
+ * - AnnotationDefault: annotation interface element default value
+ * - Deprecated: @Deprecated 同時產生 Deprecated attribute
+ *
+ * element_value: String ('s'), int ('I'), enum ('e'), array ('[')
+ * VM 不處理 annotation (沒有 reflection), 執行時只是一般的 class
+ *
+ * 預期輸出:
+ * 7
+ */
+@TestAnnotation.Tag(value = "class", kinds = {RetentionPolicy.CLASS, RetentionPolicy.RUNTIME})
+public class TestAnnotation {
+
+    @Retention(RetentionPolicy.RUNTIME)
+    @interface Tag {
+        String value();
+
+        int level() default 1;
+
+        RetentionPolicy[] kinds() default {};
+    }
+
+    @Tag(value = "field", level = 2)
+    static int counter = 7;
+
+    @Deprecated
+    @Tag("method")
+    static int read(@Tag("param") int x) {
+        return x;
+    }
+
+    public static void main(String[] args) {
+        System.out.println(read(counter));
+    }
+}
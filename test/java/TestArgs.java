@@ -0,0 +1,20 @@
+/**
+ * TestArgs.java
+ * 測試命令列參數傳入 main(String[] args)
+ *
+ * 執行: gogo_jvm TestArgs.class hello -debug "two words"
+ * 預期輸出:
+ * 3
+ * hello
+ * -debug
+ * two words
+ */
+public class TestArgs {
+
+    public static void main(String[] args) {
+        System.out.println(args.length);
+        for (int i = 0; i < args.length; i++) {
+            System.out.println(args[i]);
+        }
+    }
+}
@@ -0,0 +1,70 @@
+/**
+ * TestClassInit.java
+ * JVMS 5.5 class initialization
+ * - superclass → superinterface with default method → class itself
+ * - interface without default method is not initialized by implementing class
+ * - <clinit> throws: ExceptionInInitializerError, then class is erroneous: NoClassDefFoundError
+ *
+ * 預期輸出:
+ * 123
+ * 3
+ */
+public class TestClassInit {
+    static int order;  // init order, one digit per class
+    static int caught;
+
+    static int mark(int digit) {
+        order = order * 10 + digit;
+        return digit;
+    }
+
+    public static void main(String[] args) {
+        new InitSub();
+        try {
+            int x = InitBad.x;
+        } catch (ExceptionInInitializerError e) {
+            caught += 1;  // <clinit> failed
+        }
+        try {
+            int x = InitBad.x;
+        } catch (NoClassDefFoundError e) {
+            caught += 2;  // erroneous class
+        }
+        System.out.println(order);   // 123
+        System.out.println(caught);  // 3
+    }
+}
+
+class InitBase {
+    static int a = TestClassInit.mark(1);
+}
+
+interface InitIface {
+    int b = TestClassInit.mark(2);
+
+    default int name() {
+        return 0;
+    }
+}
+
+interface InitNoDefault {
+    int d = TestClassInit.mark(9);
+
+    int value();
+}
+
+class InitSub extends InitBase implements InitNoDefault, InitIface {
+    static int c = TestClassInit.mark(3);
+
+    public int value() {
+        return c;
+    }
+}
+
+class InitBad {
+    static int x = fail();
+
+    static int fail() {
+        throw new RuntimeException();
+    }
+}
@@ -0,0 +1,99 @@
+/**
+ * TestClassLoader.java
+ * user-defined class loader (JVMS 5.3)
+ * - built-in loaders: app → platform → bootstrap (null)
+ * - same bytes defined by 2 loaders are 2 different classes, class knows its defining loader
+ * - loadClass is parent first: Greeter is shared, Plugin resolves Greeter / TestClassLoader by its loader
+ * - a loader defines a name only once: LinkageError
+ * - defined class name must be the requested name: NoClassDefFoundError (wrong name)
+ *
+ * Plugin.class is NOT on classpath, PluginLoader.PLUGIN_CLASS is compiled plugin/Plugin.java
+ *
+ * 預期輸出:
+ * true
+ * true
+ * true
+ * true
+ * false
+ * true
+ * true
+ * true
+ * 42
+ * duplicate
+ * wrong name
+ */
+public class TestClassLoader {
+    static int base() {
+        return 35;
+    }
+
+    public static void main(String[] args) throws Exception {
+        ClassLoader app = ClassLoader.getSystemClassLoader();
+        System.out.println(TestClassLoader.class.getClassLoader() == app);
+        System.out.println(app.getParent() == ClassLoader.getPlatformClassLoader());
+        System.out.println(app.getParent().getParent() == null);
+        System.out.println(Object.class.getClassLoader() == null);
+
+        PluginLoader l1 = new PluginLoader(app);
+        PluginLoader l2 = new PluginLoader(app);
+        Class c1 = l1.loadClass("Plugin");
+        Class c2 = l2.loadClass("Plugin");
+        System.out.println(c1 == c2);                             // false
+        System.out.println(c1.getClassLoader() == l1);            // true
+        System.out.println(l1.loadClass("Plugin") == c1);         // true: already loaded
+        System.out.println(l1.loadClass("Greeter") == Greeter.class); // true: parent first
+
+        Greeter greeter = (Greeter) c1.newInstance();
+        System.out.println(greeter.greet());                      // 42
+        try {
+            l1.redefine();
+        } catch (LinkageError e) {
+            System.out.println("duplicate");
+        }
+        try {
+            l1.loadClass("Missing");
+        } catch (NoClassDefFoundError e) {
+            System.out.println("wrong name");
+        }
+    }
+}
+
+interface Greeter {
+    int greet();
+}
+
+class PluginLoader extends ClassLoader {
+    private static final byte[] PLUGIN_CLASS = {
+            -54, -2, -70, -66, 0, 0, 0, 52, 0, 22, 1, 0, 16, 106, 97, 118,
+            97, 47, 108, 97, 110, 103, 47, 79, 98, 106, 101, 99, 116, 7, 0, 1,
+            1, 0, 6, 60, 105, 110, 105, 116, 62, 1, 0, 3, 40, 41, 86, 12,
+            0, 3, 0, 4, 10, 0, 2, 0, 5, 1, 0, 15, 76, 105, 110, 101,
+            78, 117, 109, 98, 101, 114, 84, 97, 98, 108, 101, 1, 0, 4, 67, 111,
+            100, 101, 1, 0, 15, 84, 101, 115, 116, 67, 108, 97, 115, 115, 76, 111,
+            97, 100, 101, 114, 7, 0, 9, 1, 0, 4, 98, 97, 115, 101, 1, 0,
+            3, 40, 41, 73, 12, 0, 11, 0, 12, 10, 0, 10, 0, 13, 1, 0,
+            5, 103, 114, 101, 101, 116, 1, 0, 10, 83, 111, 117, 114, 99, 101, 70,
+            105, 108, 101, 1, 0, 11, 80, 108, 117, 103, 105, 110, 46, 106, 97, 118,
+            97, 1, 0, 6, 80, 108, 117, 103, 105, 110, 7, 0, 18, 1, 0, 7,
+            71, 114, 101, 101, 116, 101, 114, 7, 0, 20, 0, 33, 0, 19, 0, 2,
+            0, 1, 0, 21, 0, 0, 0, 2, 0, 1, 0, 3, 0, 4, 0, 1,
+            0, 8, 0, 0, 0, 29, 0, 1, 0, 1, 0, 0, 0, 5, 42, -73,
+            0, 6, -79, 0, 0, 0, 1, 0, 7, 0, 0, 0, 6, 0, 1, 0,
+            0, 0, 6, 0, 1, 0, 15, 0, 12, 0, 1, 0, 8, 0, 0, 0,
+            31, 0, 2, 0, 1, 0, 0, 0, 7, -72, 0, 14, 16, 7, 96, -84,
+            0, 0, 0, 1, 0, 7, 0, 0, 0, 6, 0, 1, 0, 0, 0, 8,
+            0, 1, 0, 16, 0, 0, 0, 2, 0, 17,
+    };
+
+    PluginLoader(ClassLoader parent) {
+        super(parent);
+    }
+
+    protected Class findClass(String name) {
+        return defineClass(name, PLUGIN_CLASS, 0, PLUGIN_CLASS.length);
+    }
+
+    Class redefine() {
+        return defineClass("Plugin", PLUGIN_CLASS, 0, PLUGIN_CLASS.length);
+    }
+}
@@ -0,0 +1,29 @@
+/**
+ * TestClassUnloading.java
+ * class unloading: plugin host creates a loader per job,
+ * Plugin defined by the loader is unloaded with the loader once job is done (nothing refers to them).
+ * run with -verbose:class to see unloading (reported after GC):
+ *   [Unloading class Plugin]
+ *   [Unloaded 1 classes of loader PluginLoader, total unloaded: 1]
+ *
+ * Plugin, PluginLoader: see TestClassLoader.java
+ *
+ * 預期輸出:
+ * 420
+ */
+public class TestClassUnloading {
+    public static void main(String[] args) throws Exception {
+        int sum = 0;
+        for (int i = 0; i < 10; i++) {
+            sum += runJob();
+        }
+        System.gc();
+        System.out.println(sum);  // 420
+    }
+
+    static int runJob() throws Exception {
+        PluginLoader loader = new PluginLoader(ClassLoader.getSystemClassLoader());
+        Greeter greeter = (Greeter) loader.loadClass("Plugin").newInstance();
+        return greeter.greet();
+    }
+}
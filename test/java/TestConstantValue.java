@@ -0,0 +1,34 @@
+/**
+ * TestConstantValue.java
+ * static final 常量在 preparation 階段由 ConstantValue attribute 初始化 (不是 <clinit>)
+ * class file 中 main 用 getstatic 讀取 ConstHolder (如同 ConstHolder 分開編譯，常量沒有被 javac inline)
+ *
+ * 預期輸出:
+ * 42
+ * 1234567890123
+ * 1.5
+ * 2.25
+ * A
+ * gogo
+ * true
+ */
+public class TestConstantValue {
+    public static void main(String[] args) {
+        System.out.println(ConstHolder.INT);
+        System.out.println(ConstHolder.LONG);
+        System.out.println(ConstHolder.FLOAT);
+        System.out.println(ConstHolder.DOUBLE);
+        System.out.println(ConstHolder.CHAR);
+        System.out.println(ConstHolder.STRING);
+        System.out.println(ConstHolder.STRING == "gogo");  // String ConstantValue is interned
+    }
+}
+
+class ConstHolder {
+    static final int INT = 42;
+    static final long LONG = 1234567890123L;
+    static final float FLOAT = 1.5f;
+    static final double DOUBLE = 2.25;
+    static final char CHAR = 'A';
+    static final String STRING = "gogo";
+}
@@ -0,0 +1,57 @@
+/**
+ * fcmpl / fcmpg / dcmpl / dcmpg
+ *
+ * javac pick the variant so that NaN always makes the condition false:
+ *   a < b, a <= b  ->  fcmpg / dcmpg (NaN -> 1)
+ *   a > b, a >= b  ->  fcmpl / dcmpl (NaN -> -1)
+ *
+ * expected output: 6 booleans per check (< <= > >= == !=) then an empty line.
+ * Any comparison with NaN is false except `!=`, and +0.0 == -0.0.
+ */
+public class TestFloatCompare {
+
+    public static void main(String[] args) {
+        float fNaN = Float.NaN;
+        double dNaN = Double.NaN;
+
+        // ===== float =====
+        checkFloat(fNaN, 1.0f);    // false false false false false true
+        checkFloat(1.0f, fNaN);    // false false false false false true
+        checkFloat(fNaN, fNaN);    // false false false false false true
+        checkFloat(0.0f, -0.0f);   // false true  false true  true  false
+        checkFloat(-0.0f, 0.0f);   // false true  false true  true  false
+        checkFloat(-0.0f, fNaN);   // false false false false false true
+        checkFloat(1.0f, 2.0f);    // true  true  false false false true
+        checkFloat(2.0f, 1.0f);    // false false true  true  false true
+
+        // ===== double =====
+        checkDouble(dNaN, 1.0);    // false false false false false true
+        checkDouble(1.0, dNaN);    // false false false false false true
+        checkDouble(dNaN, dNaN);   // false false false false false true
+        checkDouble(0.0, -0.0);    // false true  false true  true  false
+        checkDouble(-0.0, 0.0);    // false true  false true  true  false
+        checkDouble(0.0, dNaN);    // false false false false false true
+        checkDouble(1.0, 2.0);     // true  true  false false false true
+        checkDouble(2.0, 1.0);     // false false true  true  false true
+    }
+
+    static void checkFloat(float a, float b) {
+        System.out.println(a < b);   // fcmpg
+        System.out.println(a <= b);  // fcmpg
+        System.out.println(a > b);   // fcmpl
+        System.out.println(a >= b);  // fcmpl
+        System.out.println(a == b);  // fcmpl
+        System.out.println(a != b);  // fcmpl
+        System.out.println();
+    }
+
+    static void checkDouble(double a, double b) {
+        System.out.println(a < b);   // dcmpg
+        System.out.println(a <= b);  // dcmpg
+        System.out.println(a > b);   // dcmpl
+        System.out.println(a >= b);  // dcmpl
+        System.out.println(a == b);  // dcmpl
+        System.out.println(a != b);  // dcmpl
+        System.out.println();
+    }
+}
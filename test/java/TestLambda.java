@@ -0,0 +1,87 @@
+/**
+ * TestLambda.java
+ * 測試 invokedynamic + LambdaMetafactory (lambda / method reference)
+ *
+ * 不使用 java.util.function (目前沒有 rt.jar), 自己定義 functional interface
+ * 不使用字串串接 (會產生 StringConcatFactory 的 invokedynamic)
+ *
+ * 預期輸出:
+ * 42    (無捕獲 lambda: () -> 42)
+ * 15    (捕獲區域變數: x -> x + base)
+ * 7     (static method reference: TestLambda::add)
+ * 30    (捕獲 this, instance method: x -> x * factor)
+ * 9     (constructor reference: Box::new, 再呼叫 get())
+ * 3     (泛型 interface, 參數需要 checkcast: s -> s.value)
+ * 100   (同一個 invokedynamic 執行兩次, 第二次直接使用已連結的 CallSite)
+ * 200
+ */
+public class TestLambda {
+
+    interface IntSupplier {
+        int get();
+    }
+
+    interface IntOp {
+        int apply(int x);
+    }
+
+    interface IntBinOp {
+        int apply(int a, int b);
+    }
+
+    interface BoxFactory {
+        Box create(int v);
+    }
+
+    interface Mapper<T, R> {
+        R map(T t);
+    }
+
+    static class Box {
+        int value;
+
+        Box(int value) {
+            this.value = value;
+        }
+
+        int get() {
+            return value;
+        }
+    }
+
+    private int factor = 3;
+
+    public static void main(String[] args) {
+        IntSupplier s = () -> 42;
+        System.out.println(s.get());
+
+        int base = 10;
+        IntOp plus = x -> x + base;
+        System.out.println(plus.apply(5));
+
+        IntBinOp add = TestLambda::add;
+        System.out.println(add.apply(3, 4));
+
+        TestLambda t = new TestLambda();
+        System.out.println(t.times().apply(10));
+
+        BoxFactory factory = Box::new;
+        System.out.println(factory.create(9).get());
+
+        Mapper<Box, Box> identity = b -> b;
+        System.out.println(identity.map(new Box(3)).value);
+
+        for (int i = 1; i <= 2; i++) {
+            IntOp hundred = x -> x * 100;
+            System.out.println(hundred.apply(i));
+        }
+    }
+
+    static int add(int a, int b) {
+        return a + b;
+    }
+
+    IntOp times() {
+        return x -> x * factor;
+    }
+}
@@ -0,0 +1,174 @@
+/**
+ * TestLoaderConstraint.java
+ * loader constraints (JVMS 5.3.4): type in field / method descriptor must be the same class in both loaders
+ * - l1 defines its own Secret: resolving Holder.secret, Holder.get() and overriding Exposer.expose() → LinkageError
+ * - l2 shares Secret of app: constraint is recorded when resolving Holder.get() (Secret is not loaded),
+ *   defining its own Secret afterwards → LinkageError
+ *
+ * constraint/*.java are NOT on classpath, their bytes are embedded in ConstraintLoader
+ *
+ * 預期輸出:
+ * 42
+ * 42
+ * field
+ * method
+ * override
+ * true
+ * load
+ */
+public class TestLoaderConstraint {
+    public static void main(String[] args) {
+        ClassLoader app = ClassLoader.getSystemClassLoader();
+        System.out.println(new Secret().value);   // 42
+
+        ConstraintLoader l1 = new ConstraintLoader(app);
+        l1.defineSecret();
+        Probe p1 = l1.newProbe();
+        System.out.println(p1.ok());              // 42: Object is the same class
+        try {
+            p1.viaField();
+        } catch (LinkageError e) {
+            System.out.println("field");
+        }
+        try {
+            p1.viaMethod();
+        } catch (LinkageError e) {
+            System.out.println("method");
+        }
+        try {
+            l1.defineImpl();
+        } catch (LinkageError e) {
+            System.out.println("override");
+        }
+
+        ConstraintLoader l2 = new ConstraintLoader(app);
+        Probe p2 = l2.newProbe();
+        System.out.println(p2.viaMethod());       // true
+        try {
+            l2.defineSecret();
+        } catch (LinkageError e) {
+            System.out.println("load");
+        }
+    }
+}
+
+class Secret {
+    public int value = 42;
+}
+
+class Holder {
+    static Secret secret = new Secret();
+
+    static Secret get() {
+        return secret;
+    }
+
+    static int twice(Object o, int x) {
+        return x * 2;
+    }
+}
+
+interface Probe {
+    int ok();
+
+    boolean viaField();
+
+    boolean viaMethod();
+}
+
+interface Exposer {
+    Secret expose();
+}
+
+class ConstraintLoader extends ClassLoader {
+    private static final byte[] SECRET_CLASS = {
+            -54, -2, -70, -66, 0, 0, 0, 52, 0, 17, 1, 0, 5, 115, 116, 97,
+            109, 112, 1, 0, 1, 74, 1, 0, 5, 118, 97, 108, 117, 101, 1, 0,
+            1, 73, 1, 0, 16, 106, 97, 118, 97, 47, 108, 97, 110, 103, 47, 79,
+            98, 106, 101, 99, 116, 7, 0, 5, 1, 0, 6, 60, 105, 110, 105, 116,
+            62, 1, 0, 3, 40, 41, 86, 12, 0, 7, 0, 8, 10, 0, 6, 0,
+            9, 1, 0, 15, 76, 105, 110, 101, 78, 117, 109, 98, 101, 114, 84, 97,
+            98, 108, 101, 1, 0, 4, 67, 111, 100, 101, 1, 0, 10, 83, 111, 117,
+            114, 99, 101, 70, 105, 108, 101, 1, 0, 11, 83, 101, 99, 114, 101, 116,
+            46, 106, 97, 118, 97, 1, 0, 6, 83, 101, 99, 114, 101, 116, 7, 0,
+            15, 0, 33, 0, 16, 0, 6, 0, 0, 0, 2, 0, 1, 0, 1, 0,
+            2, 0, 0, 0, 1, 0, 3, 0, 4, 0, 0, 0, 1, 0, 1, 0,
+            7, 0, 8, 0, 1, 0, 12, 0, 0, 0, 29, 0, 2, 0, 1, 0,
+            0, 0, 5, 42, -73, 0, 10, -79, 0, 0, 0, 1, 0, 11, 0, 0,
+            0, 6, 0, 1, 0, 0, 0, 6, 0, 1, 0, 13, 0, 0, 0, 2,
+            0, 14,
+    };
+    private static final byte[] CLIENT_CLASS = {
+            -54, -2, -70, -66, 0, 0, 0, 52, 0, 35, 1, 0, 16, 106, 97, 118,
+            97, 47, 108, 97, 110, 103, 47, 79, 98, 106, 101, 99, 116, 7, 0, 1,
+            1, 0, 6, 60, 105, 110, 105, 116, 62, 1, 0, 3, 40, 41, 86, 12,
+            0, 3, 0, 4, 10, 0, 2, 0, 5, 1, 0, 15, 76, 105, 110, 101,
+            78, 117, 109, 98, 101, 114, 84, 97, 98, 108, 101, 1, 0, 4, 67, 111,
+            100, 101, 1, 0, 6, 72, 111, 108, 100, 101, 114, 7, 0, 9, 1, 0,
+            5, 116, 119, 105, 99, 101, 1, 0, 22, 40, 76, 106, 97, 118, 97, 47,
+            108, 97, 110, 103, 47, 79, 98, 106, 101, 99, 116, 59, 73, 41, 73, 12,
+            0, 11, 0, 12, 10, 0, 10, 0, 13, 1, 0, 2, 111, 107, 1, 0,
+            3, 40, 41, 73, 1, 0, 6, 115, 101, 99, 114, 101, 116, 1, 0, 8,
+            76, 83, 101, 99, 114, 101, 116, 59, 12, 0, 17, 0, 18, 9, 0, 10,
+            0, 19, 1, 0, 6, 67, 108, 105, 101, 110, 116, 7, 0, 21, 1, 0,
+            13, 83, 116, 97, 99, 107, 77, 97, 112, 84, 97, 98, 108, 101, 1, 0,
+            8, 118, 105, 97, 70, 105, 101, 108, 100, 1, 0, 3, 40, 41, 90, 1,
+            0, 3, 103, 101, 116, 1, 0, 10, 40, 41, 76, 83, 101, 99, 114, 101,
+            116, 59, 12, 0, 26, 0, 27, 10, 0, 10, 0, 28, 1, 0, 9, 118,
+            105, 97, 77, 101, 116, 104, 111, 100, 1, 0, 10, 83, 111, 117, 114, 99,
+            101, 70, 105, 108, 101, 1, 0, 11, 67, 108, 105, 101, 110, 116, 46, 106,
+            97, 118, 97, 1, 0, 5, 80, 114, 111, 98, 101, 7, 0, 33, 0, 33,
+            0, 22, 0, 2, 0, 1, 0, 34, 0, 0, 0, 4, 0, 1, 0, 3,
+            0, 4, 0, 1, 0, 8, 0, 0, 0, 29, 0, 2, 0, 1, 0, 0,
+            0, 5, 42, -73, 0, 6, -79, 0, 0, 0, 1, 0, 7, 0, 0, 0,
+            6, 0, 1, 0, 0, 0, 6, 0, 1, 0, 15, 0, 16, 0, 1, 0,
+            8, 0, 0, 0, 31, 0, 2, 0, 1, 0, 0, 0, 7, 42, 16, 21,
+            -72, 0, 14, -84, 0, 0, 0, 1, 0, 7, 0, 0, 0, 6, 0, 1,
+            0, 0, 0, 8, 0, 1, 0, 24, 0, 25, 0, 1, 0, 8, 0, 0,
+            0, 65, 0, 1, 0, 1, 0, 0, 0, 12, -78, 0, 20, -58, 0, 7,
+            4, -89, 0, 4, 3, -84, 0, 0, 0, 2, 0, 7, 0, 0, 0, 6,
+            0, 1, 0, 0, 0, 12, 0, 23, 0, 0, 0, 23, 0, 2, -1, 0,
+            10, 0, 1, 7, 0, 22, 0, 0, -1, 0, 0, 0, 1, 7, 0, 22,
+            0, 1, 1, 0, 1, 0, 30, 0, 25, 0, 1, 0, 8, 0, 0, 0,
+            65, 0, 1, 0, 1, 0, 0, 0, 12, -72, 0, 29, -58, 0, 7, 4,
+            -89, 0, 4, 3, -84, 0, 0, 0, 2, 0, 7, 0, 0, 0, 6, 0,
+            1, 0, 0, 0, 16, 0, 23, 0, 0, 0, 23, 0, 2, -1, 0, 10,
+            0, 1, 7, 0, 22, 0, 0, -1, 0, 0, 0, 1, 7, 0, 22, 0,
+            1, 1, 0, 1, 0, 31, 0, 0, 0, 2, 0, 32,
+    };
+    private static final byte[] IMPL_CLASS = {
+            -54, -2, -70, -66, 0, 0, 0, 52, 0, 17, 1, 0, 16, 106, 97, 118,
+            97, 47, 108, 97, 110, 103, 47, 79, 98, 106, 101, 99, 116, 7, 0, 1,
+            1, 0, 6, 60, 105, 110, 105, 116, 62, 1, 0, 3, 40, 41, 86, 12,
+            0, 3, 0, 4, 10, 0, 2, 0, 5, 1, 0, 15, 76, 105, 110, 101,
+            78, 117, 109, 98, 101, 114, 84, 97, 98, 108, 101, 1, 0, 4, 67, 111,
+            100, 101, 1, 0, 6, 101, 120, 112, 111, 115, 101, 1, 0, 10, 40, 41,
+            76, 83, 101, 99, 114, 101, 116, 59, 1, 0, 10, 83, 111, 117, 114, 99,
+            101, 70, 105, 108, 101, 1, 0, 9, 73, 109, 112, 108, 46, 106, 97, 118,
+            97, 1, 0, 4, 73, 109, 112, 108, 7, 0, 13, 1, 0, 7, 69, 120,
+            112, 111, 115, 101, 114, 7, 0, 15, 0, 33, 0, 14, 0, 2, 0, 1,
+            0, 16, 0, 0, 0, 2, 0, 1, 0, 3, 0, 4, 0, 1, 0, 8,
+            0, 0, 0, 29, 0, 2, 0, 1, 0, 0, 0, 5, 42, -73, 0, 6,
+            -79, 0, 0, 0, 1, 0, 7, 0, 0, 0, 6, 0, 1, 0, 0, 0,
+            6, 0, 1, 0, 9, 0, 10, 0, 1, 0, 8, 0, 0, 0, 26, 0,
+            1, 0, 1, 0, 0, 0, 2, 1, -80, 0, 0, 0, 1, 0, 7, 0,
+            0, 0, 6, 0, 1, 0, 0, 0, 8, 0, 1, 0, 11, 0, 0, 0,
+            2, 0, 12,
+    };
+
+    ConstraintLoader(ClassLoader parent) {
+        super(parent);
+    }
+
+    void defineSecret() {
+        defineClass("Secret", SECRET_CLASS, 0, SECRET_CLASS.length);
+    }
+
+    void defineImpl() {
+        defineClass("Impl", IMPL_CLASS, 0, IMPL_CLASS.length);
+    }
+
+    Probe newProbe() {
+        return (Probe) defineClass("Client", CLIENT_CLASS, 0, CLIENT_CLASS.length).newInstance();
+    }
+}
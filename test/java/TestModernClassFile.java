@@ -0,0 +1,87 @@
+/**
+ * TestModernClassFile.java
+ * 測試 Java 11 ~ 21 class file (major version 55 ~ 65)
+ *
+ * 編譯: javac --release 21 TestModernClassFile.java (class file version 65.0)
+ *
+ * - nestmates (Java 11): Inner 直接存取 outer 的 private 成員, 不再產生 access$000,
+ *   private method 透過 invokevirtual 呼叫 (不做 dynamic binding)
+ * - record (Java 16): 只使用 constructor 與 accessor
+ *   (toString / equals / hashCode 走 ObjectMethods bootstrap, 目前不支援)
+ * - sealed (Java 17): Shape permits Circle, Square
+ *
+ * 不使用字串串接
+ *
+ * 預期輸出:
+ * 10    (Inner 讀取 outer private field)
+ * 14    (Inner 呼叫 outer private method)
+ * 3     (record accessor: p.x())
+ * 4     (record accessor: p.y())
+ * 12    (sealed permitted subclass: Circle.area())
+ * 25    (sealed permitted subclass: Square.area())
+ */
+public class TestModernClassFile {
+
+    private int secret = 10;
+
+    private int twice(int x) {
+        return x * 2;
+    }
+
+    class Inner {
+        int readSecret() {
+            return secret;
+        }
+
+        int callTwice(int x) {
+            return twice(x);
+        }
+    }
+
+    record Point(int x, int y) {
+    }
+
+    static sealed abstract class Shape permits Circle, Square {
+        abstract int area();
+    }
+
+    static final class Circle extends Shape {
+        private final int r;
+
+        Circle(int r) {
+            this.r = r;
+        }
+
+        int area() {
+            return 3 * r * r;
+        }
+    }
+
+    static final class Square extends Shape {
+        private final int side;
+
+        Square(int side) {
+            this.side = side;
+        }
+
+        int area() {
+            return side * side;
+        }
+    }
+
+    public static void main(String[] args) {
+        TestModernClassFile outer = new TestModernClassFile();
+        Inner inner = outer.new Inner();
+        System.out.println(inner.readSecret());
+        System.out.println(inner.callTwice(7));
+
+        Point p = new Point(3, 4);
+        System.out.println(p.x());
+        System.out.println(p.y());
+
+        Shape circle = new Circle(2);
+        Shape square = new Square(5);
+        System.out.println(circle.area());
+        System.out.println(square.area());
+    }
+}
@@ -0,0 +1,26 @@
+/**
+ * TestStackOverflow.java
+ * StackOverflowError 是 java exception, 可以被 catch
+ *
+ * 預期輸出 (-Xss64k → 64 frames):
+ * 63
+ * 2
+ */
+public class TestStackOverflow {
+    static int depth;
+
+    static void recurse() {
+        depth++;
+        recurse();
+    }
+
+    public static void main(String[] args) {
+        try {
+            recurse();
+            System.out.println(0);      // 不應該執行
+        } catch (StackOverflowError e) {
+            System.out.println(depth);  // 應輸出 stack depth - 1
+        }
+        System.out.println(2);          // 應輸出 2
+    }
+}
@@ -0,0 +1,35 @@
+/**
+ * TestStackTrace.java
+ * Throwable 建構時記錄 stack trace (fillInStackTrace), getStackTrace() / printStackTrace()
+ *
+ * 預期輸出:
+ * 2
+ * create
+ * 19
+ * 23
+ * System.err:
+ * java.lang.Throwable: outer
+ * 	at TestStackTrace.wrap(TestStackTrace.java:33)
+ * 	at TestStackTrace.main(TestStackTrace.java:29)
+ * Caused by: java.lang.RuntimeException
+ * 	at TestStackTrace.create(TestStackTrace.java:19)
+ * 	... 1 more
+ */
+public class TestStackTrace {
+    static RuntimeException create() { return new RuntimeException(); }
+
+    public static void main(String[] args) {
+        // trace starts at create() (constructor frames are skipped)
+        RuntimeException e = create();
+        StackTraceElement[] trace = e.getStackTrace();
+        System.out.println(trace.length);             // 2
+        System.out.println(trace[0].getMethodName()); // create
+        System.out.println(trace[0].getLineNumber()); // 19
+        System.out.println(trace[1].getLineNumber()); // 23
+        wrap(create()).printStackTrace();
+    }
+
+    static Throwable wrap(Throwable cause) {
+        return new Throwable("outer", cause);
+    }
+}
@@ -0,0 +1,67 @@
+/**
+ * TestStringConcat.java
+ * 測試 JDK 9+ 字串串接: invokedynamic + StringConcatFactory.makeConcatWithConstants
+ *
+ * javac (target >= 9) 把 "a=" + a 編譯成 invokedynamic, recipe 例如 "a=\u0001"
+ * \u0001 = 動態參數, \u0002 = bootstrap 常數 (recipe 本身含有 \u0001 / \u0002 字元時才會出現)
+ *
+ * 預期輸出:
+ * a=1, b=true, c=X
+ * long=9000000000, float=1.5, double=0.25
+ * big=1.0E7, small=1.0E-4
+ * null=null
+ * boxed=7, char=Y
+ * point=(1, 2)
+ * Hello, GoGo JVM!
+ */
+public class TestStringConcat {
+
+    public static void main(String[] args) {
+        int a = 1;
+        boolean b = true;
+        char c = 'X';
+        System.out.println("a=" + a + ", b=" + b + ", c=" + c);
+
+        long l = 9000000000L;
+        float f = 1.5f;
+        double d = 0.25;
+        System.out.println("long=" + l + ", float=" + f + ", double=" + d);
+
+        double big = 1.0e7;
+        double small = 0.0001;
+        System.out.println("big=" + big + ", small=" + small);
+
+        String n = null;
+        System.out.println("null=" + n);
+
+        // boxed 參數 = String.valueOf(Object), 要印出值而不是 className@hash
+        Integer boxed = Integer.valueOf(7);
+        Character ch = Character.valueOf('Y');
+        System.out.println("boxed=" + boxed + ", char=" + ch);
+
+        // 覆寫的 toString() 由 VM upcall 呼叫
+        Point p = new Point(1, 2);
+        System.out.println("point=" + p);
+
+        String name = "GoGo JVM";
+        System.out.println(greet(name));
+    }
+
+    static String greet(String name) {
+        return "Hello, " + name + "!";
+    }
+
+    static class Point {
+        int x;
+        int y;
+
+        Point(int x, int y) {
+            this.x = x;
+            this.y = y;
+        }
+
+        public String toString() {
+            return "(" + x + ", " + y + ")";
+        }
+    }
+}
@@ -0,0 +1,48 @@
+/**
+ * tableswitch / lookupswitch
+ *
+ * dense cases  -> tableswitch
+ * sparse cases -> lookupswitch
+ * switch opcode of dense() is at pc 1 (2 padding bytes), sparse() stores local `pad` first,
+ * so its switch is at pc 3 (1 padding byte). padding at pc%4 = 0~3 is covered by
+ * TestTableSwitchPadding / TestLookupSwitchPadding (interpreter_test.go).
+ *
+ * expected output:
+ * 10 11 12 -1 -1 (dense)
+ * 100 200 300 -1 -1 (sparse)
+ */
+public class TestSwitch {
+
+    public static void main(String[] args) {
+        System.out.println(dense(0));
+        System.out.println(dense(1));
+        System.out.println(dense(2));
+        System.out.println(dense(3));   // > high  -> default
+        System.out.println(dense(-1));  // < low   -> default
+
+        System.out.println(sparse(-1000));
+        System.out.println(sparse(7));
+        System.out.println(sparse(100000));
+        System.out.println(sparse(8));  // miss -> default
+        System.out.println(sparse(Integer.MIN_VALUE));
+    }
+
+    static int dense(int x) {
+        switch (x) {
+            case 0: return 10;
+            case 1: return 11;
+            case 2: return 12;
+            default: return -1;
+        }
+    }
+
+    static int sparse(int x) {
+        int pad = 1;
+        switch (x) {
+            case -1000: return 100 * pad;
+            case 7: return 200 * pad;
+            case 100000: return 300 * pad;
+            default: return -1;
+        }
+    }
+}
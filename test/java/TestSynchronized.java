@@ -0,0 +1,58 @@
+/**
+ * TestSynchronized.java
+ * 測試 monitorenter / monitorexit 與 ACC_SYNCHRONIZED 方法
+ *
+ * 預期輸出:
+ * 1   (synchronized block)
+ * 2   (巢狀 synchronized 同一物件: 重入)
+ * 3   (synchronized instance method, 鎖 this)
+ * 4   (synchronized static method, 鎖 TestSynchronized.class)
+ * 5   (synchronized block 內拋出例外, 鎖仍然被釋放)
+ * 6   (再次進入同一物件的 synchronized block)
+ */
+public class TestSynchronized {
+
+    private int count;
+
+    public static void main(String[] args) {
+        Object lock = new Object();
+
+        synchronized (lock) {
+            System.out.println(1);
+        }
+
+        synchronized (lock) {
+            synchronized (lock) {
+                System.out.println(2);
+            }
+        }
+
+        TestSynchronized t = new TestSynchronized();
+        t.count = 2;
+        System.out.println(t.inc());
+
+        System.out.println(staticSync());
+
+        try {
+            synchronized (lock) {
+                throw new RuntimeException();
+            }
+        } catch (RuntimeException e) {
+            System.out.println(5);
+        }
+
+        synchronized (lock) {
+            System.out.println(6);
+        }
+    }
+
+    public synchronized int inc() {
+        synchronized (this) {
+            return ++count;
+        }
+    }
+
+    public static synchronized int staticSync() {
+        return 4;
+    }
+}
@@ -7,7 +7,7 @@
  * - anewarray (0xBD)
  *
  * 編譯指令：javac TestTypeSystem.java
- * 執行指令：gogo_jvm TestTypeSystem.class -debug
+ * 執行指令：gogo_jvm -debug TestTypeSystem.class
  */
 public class TestTypeSystem {
 
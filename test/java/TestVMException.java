@@ -0,0 +1,32 @@
+/**
+ * TestVMException.java
+ * VM 拋出的 exception (不是 athrow) 也可以被 catch
+ * ArrayIndexOutOfBoundsException / NegativeArraySizeException 不在 classpath 也沒關係 (synthetic class)
+ *
+ * 預期輸出:
+ * 7
+ */
+public class TestVMException {
+    int v;
+    static int caught;
+
+    public static void main(String[] args) {
+        try {
+            int[] a = new int[2];
+            a[5] = 1;
+        } catch (ArrayIndexOutOfBoundsException e) {
+            caught += 1;
+        }
+        try {
+            int x = ((TestVMException) null).v;
+        } catch (NullPointerException e) {
+            caught += 2;
+        }
+        try {
+            int[] a = new int[-1];
+        } catch (RuntimeException e) {  // NegativeArraySizeException
+            caught += 4;
+        }
+        System.out.println(caught);
+    }
+}
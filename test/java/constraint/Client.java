@@ -0,0 +1,18 @@
+/**
+ * Client.java
+ * defined by ConstraintLoader (see TestLoaderConstraint.java), NOT on classpath:
+ * uses Holder / Probe of app loader
+ */
+public class Client implements Probe {
+    public int ok() {
+        return Holder.twice(this, 21);
+    }
+
+    public boolean viaField() {
+        return Holder.secret != null;
+    }
+
+    public boolean viaMethod() {
+        return Holder.get() != null;
+    }
+}
@@ -0,0 +1,10 @@
+/**
+ * Impl.java
+ * defined by ConstraintLoader (see TestLoaderConstraint.java), NOT on classpath:
+ * overrides Exposer.expose() of app loader
+ */
+public class Impl implements Exposer {
+    public Secret expose() {
+        return null;
+    }
+}
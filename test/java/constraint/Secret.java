@@ -0,0 +1,9 @@
+/**
+ * Secret.java
+ * defined by ConstraintLoader (see TestLoaderConstraint.java), NOT on classpath:
+ * same name as Secret on classpath, different field layout
+ */
+public class Secret {
+    public long stamp;
+    public int value;
+}
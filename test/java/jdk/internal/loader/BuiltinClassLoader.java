@@ -0,0 +1,15 @@
+package jdk.internal.loader;
+
+/**
+ * minimal built-in class loader (platform, app) for gogo_jvm
+ * object is created by VM (ClassLoader.getSystemClassLoader()), delegation is done by VM
+ */
+public class BuiltinClassLoader extends ClassLoader {
+
+    BuiltinClassLoader(ClassLoader parent) {
+        super(parent);
+    }
+
+    @Override
+    protected native Class<?> loadClass(String name, boolean resolve) throws ClassNotFoundException;
+}
@@ -0,0 +1,19 @@
+package java.lang;
+
+// 測試用精簡版 (沒有 rt.jar): 只有 boxing / unboxing, toString 由 VM 的 string concat 處理
+public final class Character {
+
+    private final char value;
+
+    public Character(char value) {
+        this.value = value;
+    }
+
+    public static Character valueOf(char c) {
+        return new Character(c);
+    }
+
+    public char charValue() {
+        return value;
+    }
+}
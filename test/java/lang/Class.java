@@ -12,5 +12,13 @@ public class Class {
     }
 
     private native String initClassName();
+
+    public ClassLoader getClassLoader() {
+        return getClassLoader0();
+    }
+
+    private native ClassLoader getClassLoader0();
+
+    public native Object newInstance();
    
-}
\ No newline at end of file
+}
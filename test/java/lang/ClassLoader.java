@@ -0,0 +1,87 @@
+package java.lang;
+
+import java.security.ProtectionDomain;
+
+/**
+ * minimal java.lang.ClassLoader for gogo_jvm
+ * - parent-first delegation is done by java code (loadClass), like JDK
+ * - built-in loaders (platform, app) are jdk.internal.loader.BuiltinClassLoader created by VM
+ */
+public abstract class ClassLoader {
+
+    private final ClassLoader parent;
+    private final String name;
+
+    protected ClassLoader(ClassLoader parent) {
+        this.parent = parent;
+        this.name = null;
+    }
+
+    protected ClassLoader() {
+        this(getSystemClassLoader());
+    }
+
+    public Class<?> loadClass(String name) throws ClassNotFoundException {
+        return loadClass(name, false);
+    }
+
+    protected Class<?> loadClass(String name, boolean resolve) throws ClassNotFoundException {
+        Class<?> c = findLoadedClass(name);
+        if (c == null) {
+            try {
+                if (parent != null) {
+                    c = parent.loadClass(name, false);
+                } else {
+                    c = findBootstrapClass(name);
+                }
+            } catch (ClassNotFoundException e) {
+                // not found by parent
+            }
+            if (c == null) {
+                c = findClass(name);
+            }
+        }
+        if (resolve) {
+            resolveClass(c);
+        }
+        return c;
+    }
+
+    protected Class<?> findClass(String name) throws ClassNotFoundException {
+        throw new ClassNotFoundException(name);
+    }
+
+    protected final Class<?> defineClass(String name, byte[] b, int off, int len) throws ClassFormatError {
+        return defineClass1(this, name, b, off, len, null, null);
+    }
+
+    protected final void resolveClass(Class<?> c) {
+        resolveClass0(c);
+    }
+
+    protected final Class<?> findLoadedClass(String name) {
+        return findLoadedClass0(name);
+    }
+
+    public final ClassLoader getParent() {
+        return parent;
+    }
+
+    public String getName() {
+        return name;
+    }
+
+    // minimal rt: native in gogo_jvm
+    public static native ClassLoader getSystemClassLoader();
+
+    public static native ClassLoader getPlatformClassLoader();
+
+    static native Class<?> defineClass1(ClassLoader loader, String name, byte[] b, int off, int len,
+                                        ProtectionDomain pd, String source);
+
+    private native void resolveClass0(Class<?> c);
+
+    private final native Class<?> findLoadedClass0(String name);
+
+    private static native Class<?> findBootstrapClass(String name);
+}
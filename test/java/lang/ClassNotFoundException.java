@@ -0,0 +1,12 @@
+package java.lang;
+
+public class ClassNotFoundException extends ReflectiveOperationException {
+
+    public ClassNotFoundException() {
+        super();
+    }
+
+    public ClassNotFoundException(String s) {
+        super(s);
+    }
+}
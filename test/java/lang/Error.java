@@ -0,0 +1,4 @@
+package java.lang;
+
+public class Error extends Throwable {
+}
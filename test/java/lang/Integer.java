@@ -0,0 +1,19 @@
+package java.lang;
+
+// 測試用精簡版 (沒有 rt.jar): 只有 boxing / unboxing, toString 由 VM 的 string concat 處理
+public final class Integer {
+
+    private final int value;
+
+    public Integer(int value) {
+        this.value = value;
+    }
+
+    public static Integer valueOf(int i) {
+        return new Integer(i);
+    }
+
+    public int intValue() {
+        return value;
+    }
+}
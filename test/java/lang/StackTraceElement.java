@@ -0,0 +1,35 @@
+package java.lang;
+
+/**
+ * An element in a stack trace, as returned by {@link
+ * Throwable#getStackTrace()}.  Each element represents a single stack frame.
+ */
+public final class StackTraceElement {
+
+    private String declaringClass;
+    private String methodName;
+    private String fileName;
+    private int lineNumber;
+
+    /**
+     * Created by the JVM (Throwable.getStackTraceElement)
+     */
+    private StackTraceElement() {
+    }
+
+    public String getFileName() {
+        return fileName;
+    }
+
+    public int getLineNumber() {
+        return lineNumber;
+    }
+
+    public String getClassName() {
+        return declaringClass;
+    }
+
+    public String getMethodName() {
+        return methodName;
+    }
+}
@@ -4,8 +4,27 @@ import java.io.*;
 
 public class Throwable implements Serializable {
 
+    /**
+     * The JVM saves some indication of the stack backtrace in this slot.
+     */
+    private transient Object backtrace;
+
     private String detailMessage;
 
+    /**
+     * The throwable that caused this throwable to get thrown, or null if this
+     * throwable was not caused by another throwable, or if the causative
+     * throwable is unknown.  If this field is equal to this throwable itself,
+     * it indicates that the cause of this throwable has not yet been
+     * initialized.
+     */
+    private Throwable cause = this;
+
+    /**
+     * The JVM code sets the depth of the backtrace for later retrieval
+     */
+    private transient int depth;
+
     /**
      * Constructs a new throwable with {@code null} as its detail message.
      * The cause is not initialized, and may subsequently be initialized by a
@@ -15,6 +34,7 @@ public class Throwable implements Serializable {
      * the stack trace data in the newly created throwable.
      */
     public Throwable() {
+        fillInStackTrace();
     }
 
     /**
@@ -29,7 +49,48 @@ public class Throwable implements Serializable {
      *          later retrieval by the {@link #getMessage()} method.
      */
     public Throwable(String message) {
+        fillInStackTrace();
+        detailMessage = message;
+    }
+
+    /**
+     * Constructs a new throwable with the specified detail message and
+     * cause.
+     */
+    public Throwable(String message, Throwable cause) {
+        fillInStackTrace();
         detailMessage = message;
+        this.cause = cause;
     }
 
+    public String getMessage() {
+        return detailMessage;
+    }
+
+    /**
+     * Fills in the execution stack trace.
+     */
+    public Throwable fillInStackTrace() {
+        fillInStackTrace(0);
+        return this;
+    }
+
+    private native Throwable fillInStackTrace(int dummy);
+
+    public StackTraceElement[] getStackTrace() {
+        int depth = getStackTraceDepth();
+        StackTraceElement[] stackTrace = new StackTraceElement[depth];
+        for (int i = 0; i < depth; i++)
+            stackTrace[i] = getStackTraceElement(i);
+        return stackTrace;
+    }
+
+    native int getStackTraceDepth();
+
+    native StackTraceElement getStackTraceElement(int index);
+
+    /**
+     * gogo_jvm: native, prints this throwable and its backtrace to System.err
+     */
+    public native void printStackTrace();
 }
\ No newline at end of file
@@ -0,0 +1,6 @@
+package java.lang.annotation;
+
+// 測試用精簡版: annotation interface (@interface) 的 super interface
+public interface Annotation {
+    Class<? extends Annotation> annotationType();
+}
@@ -0,0 +1,10 @@
+/**
+ * Plugin.java
+ * loaded by PluginLoader (see TestClassLoader.java), Plugin.class is NOT on classpath:
+ * its bytes are embedded in PluginLoader.PLUGIN_CLASS
+ */
+public class Plugin implements Greeter {
+    public int greet() {
+        return TestClassLoader.base() + 7;
+    }
+}